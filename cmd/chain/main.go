@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"git.codenrock.com/tender/internal/app"
+)
+
+const configPath = "config"
+
+func main() {
+	os.Exit(app.RunChain(configPath, os.Args[1:]))
+}
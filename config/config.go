@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -10,13 +12,26 @@ import (
 
 type (
 	Config struct {
-		HTTP `mapstructure:"http"`
-		Log  `mapstructure:"log"`
-		PG   `mapstructure:"postgres"`
+		HTTP   `mapstructure:"http"`
+		Log    `mapstructure:"log"`
+		PG     `mapstructure:"postgres"`
+		Sealed `mapstructure:"sealed"`
+		Admin  `mapstructure:"admin"`
+
+		Attachments `mapstructure:"attachments"`
+		Conflicts   `mapstructure:"conflicts"`
+		Series      `mapstructure:"series"`
+		Opening     `mapstructure:"opening"`
+		Checkpoints `mapstructure:"checkpoints"`
+		Outbox      `mapstructure:"outbox"`
 	}
 
+	// HTTP sets the listen address and the proxies in front of the service,
+	// as IP addresses or CIDR networks. The forwarding headers of a request
+	// are only believed when it comes from one of the trusted proxies.
 	HTTP struct {
-		Adress string
+		Adress         string
+		TrustedProxies []string `mapstructure:"trusted_proxies"`
 	}
 
 	Log struct {
@@ -27,6 +42,79 @@ type (
 		MaxPoolSize int `mapstructure:"max_pool_size"`
 		Conn        string
 	}
+
+	// Sealed holds the base64 encoded AES-256 key that encrypts the contents
+	// of sealed bids until their tender is opened. It is required and is
+	// never kept in the repository. Operators generate it, for example with
+	// `openssl rand -base64 32`, and supply SEALED_BID_KEY from their secret
+	// store.
+	Sealed struct {
+		Key string
+	}
+
+	Admin struct {
+		Usernames []string
+	}
+
+	Attachments struct {
+		Storage      string   `mapstructure:"storage"`
+		Dir          string   `mapstructure:"dir"`
+		MaxSize      int64    `mapstructure:"max_size"`
+		AllowedTypes []string `mapstructure:"allowed_types"`
+		S3           S3
+	}
+
+	// Conflicts sets for each conflict-of-interest rule whether a violation
+	// is blocked ("block") or let through and flagged for review ("flag").
+	Conflicts struct {
+		Membership string `mapstructure:"membership"`
+		Declared   string `mapstructure:"declared"`
+	}
+
+	// Series sets how often the scheduler creates the due tenders of the
+	// recurring series. A zero period disables the scheduler.
+	Series struct {
+		Period time.Duration `mapstructure:"period"`
+	}
+
+	// Opening sets how often the sealed tenders whose submission deadline
+	// passed are opened. A zero period disables the job, and the tenders are
+	// then only opened by the decisions and reviews of their bids.
+	Opening struct {
+		Period time.Duration `mapstructure:"period"`
+	}
+
+	// Checkpoints sets how often the heads of the hash chains are signed.
+	// A zero period disables the checkpoints. Key is the base64 encoded
+	// Ed25519 seed or private key they are signed with; it is required and
+	// is never kept in the repository. Operators generate it, for example
+	// with `openssl rand -base64 32`, and supply CHECKPOINT_SIGNING_KEY from
+	// their secret store.
+	Checkpoints struct {
+		Period time.Duration `mapstructure:"period"`
+		Key    string
+	}
+
+	// Outbox sets how often the domain events are relayed and the sink they
+	// are delivered to, "log" or "webhook". A zero period disables the relay.
+	Outbox struct {
+		Period  time.Duration `mapstructure:"period"`
+		Sink    string        `mapstructure:"sink"`
+		Webhook Webhook
+	}
+
+	Webhook struct {
+		URL    string
+		Secret string
+	}
+
+	S3 struct {
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+	}
 )
 
 func LoadConfig(configPath string) (config *Config, err error) {
@@ -53,6 +141,40 @@ func LoadConfig(configPath string) (config *Config, err error) {
 
 	config.Conn = os.Getenv("POSTGRES_CONN")
 	config.Adress = os.Getenv("SERVER_ADDRESS")
+	config.Sealed.Key = os.Getenv("SEALED_BID_KEY")
+	config.Checkpoints.Key = os.Getenv("CHECKPOINT_SIGNING_KEY")
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		config.HTTP.TrustedProxies = nil
+		for _, proxy := range strings.Split(proxies, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy != "" {
+				config.HTTP.TrustedProxies = append(config.HTTP.TrustedProxies, proxy)
+			}
+		}
+	}
+	for _, username := range strings.Split(os.Getenv("ADMIN_USERNAMES"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			config.Admin.Usernames = append(config.Admin.Usernames, username)
+		}
+	}
+
+	if storage := os.Getenv("ATTACHMENT_STORAGE"); storage != "" {
+		config.Attachments.Storage = storage
+	}
+	config.Attachments.S3 = S3{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+	}
+
+	if sink := os.Getenv("OUTBOX_SINK"); sink != "" {
+		config.Outbox.Sink = sink
+	}
+	config.Outbox.Webhook = Webhook{
+		URL:    os.Getenv("OUTBOX_WEBHOOK_URL"),
+		Secret: os.Getenv("OUTBOX_WEBHOOK_SECRET"),
+	}
 
 	return config, nil
 }
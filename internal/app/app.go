@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log"
 	sl "log/slog"
@@ -13,8 +14,12 @@ import (
 	v1 "git.codenrock.com/tender/internal/controller/http/v1"
 	"git.codenrock.com/tender/internal/repo"
 	"git.codenrock.com/tender/internal/service"
+	"git.codenrock.com/tender/pkg/blobstore"
+	"git.codenrock.com/tender/pkg/eventsink"
 	"git.codenrock.com/tender/pkg/postgres"
+	"git.codenrock.com/tender/pkg/sealer"
 	"git.codenrock.com/tender/pkg/server"
+	"git.codenrock.com/tender/pkg/signer"
 )
 
 func Run(configPath string) {
@@ -39,15 +44,92 @@ func Run(configPath string) {
 	sl.Info("Initializing repositories...")
 	repositories := repo.NewRepositories(pg)
 
+	// Sealed bids encryption
+	if cfg.Sealed.Key == "" {
+		log.Fatal("app - Run: SEALED_BID_KEY is not set")
+	}
+	bidSealer, err := sealer.NewFromBase64(cfg.Sealed.Key)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - sealer.NewFromBase64: %w", err))
+	}
+
+	// Hash chain checkpoints signing
+	if cfg.Checkpoints.Key == "" {
+		log.Fatal("app - Run: CHECKPOINT_SIGNING_KEY is not set")
+	}
+	checkpointSigner, err := signer.NewFromBase64(cfg.Checkpoints.Key)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - signer.NewFromBase64: %w", err))
+	}
+
+	// Attachments storage
+	sl.Info("Initializing attachment storage...", sl.Any("storage", cfg.Attachments.Storage))
+	blobs, err := newBlobStore(cfg.Attachments)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - newBlobStore: %w", err))
+	}
+
+	// Domain events sink
+	sl.Info("Initializing event sink...", sl.Any("sink", cfg.Outbox.Sink))
+	sink, err := newEventSink(cfg.Outbox)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - newEventSink: %w", err))
+	}
+
 	// Services dependencies
 	sl.Info("Initializing services...")
 	deps := service.ServicesDependencies{
-		Repos: repositories,
+		Repos:          repositories,
+		Sealer:         bidSealer,
+		Signer:         checkpointSigner,
+		AdminUsernames: cfg.Admin.Usernames,
+		Blobs:          blobs,
+		Sink:           sink,
+		Attachments: service.AttachmentLimits{
+			MaxSize:      cfg.Attachments.MaxSize,
+			AllowedTypes: cfg.Attachments.AllowedTypes,
+		},
+		Conflicts: service.ConflictPolicy{
+			Membership: cfg.Conflicts.Membership,
+			Declared:   cfg.Conflicts.Declared,
+		},
 	}
 	services := service.NewServices(deps)
 
+	// Recurring tenders scheduler
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if cfg.Series.Period > 0 {
+		sl.Info("Starting recurring tenders scheduler...", sl.Any("period", cfg.Series.Period))
+		go services.Series.Run(schedulerCtx, cfg.Series.Period)
+	} else {
+		sl.Warn("Series period is not set, recurring tenders will not be created")
+	}
+	if cfg.Opening.Period > 0 {
+		sl.Info("Starting sealed tenders opening...", sl.Any("period", cfg.Opening.Period))
+		go services.Opening.Run(schedulerCtx, cfg.Opening.Period)
+	} else {
+		sl.Warn("Opening period is not set, sealed tenders will only be opened by decisions on their bids")
+	}
+	if cfg.Checkpoints.Period > 0 {
+		sl.Info("Starting hash chain checkpoints...", sl.Any("period", cfg.Checkpoints.Period))
+		go services.Chain.Run(schedulerCtx, cfg.Checkpoints.Period)
+	} else {
+		sl.Warn("Checkpoints period is not set, hash chains will not be checkpointed")
+	}
+	if cfg.Outbox.Period > 0 {
+		sl.Info("Starting outbox relay...", sl.Any("period", cfg.Outbox.Period))
+		go services.Outbox.Run(schedulerCtx, cfg.Outbox.Period)
+	} else {
+		sl.Warn("Outbox period is not set, domain events will not be delivered")
+	}
+
 	// Mux handler
 	sl.Info("Initializing handlers and routes...")
+	trustedProxies, err := v1.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - v1.ParseTrustedProxies: %w", err))
+	}
 	handler := http.NewServeMux()
 	// setup handler validator as lib validator
 	v1.NewRouter(handler, services)
@@ -55,7 +137,7 @@ func Run(configPath string) {
 	// HTTP server
 	sl.Info("Starting http server...")
 	sl.Debug("Server address", sl.Any("address", cfg.Adress))
-	httpServer := server.New(handler, server.Address(cfg.Adress))
+	httpServer := server.New(v1.RequestInfoMiddleware(trustedProxies)(handler), server.Address(cfg.Adress))
 
 	// Waiting signal
 	sl.Info("Configuring graceful shutdown...")
@@ -71,8 +153,44 @@ func Run(configPath string) {
 
 	// Graceful shutdown
 	sl.Info("Shutting down...")
+	stopScheduler()
 	err = httpServer.Shutdown()
 	if err != nil {
 		sl.Error("app - Run - httpServer.Shutdown: ", sl.Any("error", err.Error()))
 	}
 }
+
+func newBlobStore(cfg config.Attachments) (blobstore.BlobStore, error) {
+	switch cfg.Storage {
+	case "local":
+		return blobstore.NewLocal(cfg.Dir)
+	case "s3":
+		s3, err := blobstore.NewS3(blobstore.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = s3.EnsureBucket(context.Background()); err != nil {
+			return nil, err
+		}
+		return s3, nil
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", cfg.Storage)
+	}
+}
+
+func newEventSink(cfg config.Outbox) (eventsink.Sink, error) {
+	switch cfg.Sink {
+	case "log":
+		return eventsink.NewLog(sl.Default()), nil
+	case "webhook":
+		return eventsink.NewWebhook(cfg.Webhook.URL, cfg.Webhook.Secret)
+	default:
+		return nil, fmt.Errorf("unknown event sink %q", cfg.Sink)
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+
+	"git.codenrock.com/tender/config"
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/service"
+	"git.codenrock.com/tender/pkg/postgres"
+	"git.codenrock.com/tender/pkg/signer"
+)
+
+const chainUsage = `usage:
+  chain verify [chain]  recompute the chains, or the given one, and report the first broken link
+  chain checkpoint      sign the heads of the chains that moved since their last checkpoint
+`
+
+// RunChain runs the hash chain command given by args and returns the exit
+// code: 1 when a chain is broken or the command fails, 2 on a usage error.
+func RunChain(configPath string, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, chainUsage)
+		return 2
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+	setUpLogger(cfg.Log.Level)
+
+	pg, err := postgres.New(cfg.Conn, postgres.MaxPoolSize(cfg.PG.MaxPoolSize))
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - RunChain - postgres.New: %w", err))
+	}
+	defer pg.Close()
+
+	// Checkpoints are only trusted when signed by the configured key, so it
+	// is needed to verify the chains too.
+	if cfg.Checkpoints.Key == "" {
+		log.Fatal("app - RunChain: CHECKPOINT_SIGNING_KEY is not set")
+	}
+	checkpointSigner, err := signer.NewFromBase64(cfg.Checkpoints.Key)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - RunChain - signer.NewFromBase64: %w", err))
+	}
+
+	repositories := repo.NewRepositories(pg)
+	chains := service.NewChainService(repositories.Chain, repositories.Audit, repositories.Bid, checkpointSigner)
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "verify" && len(args) <= 2:
+		names := entity.Chains
+		if len(args) == 2 {
+			names = args[1:]
+		}
+		return verifyChains(ctx, chains, names)
+	case args[0] == "checkpoint" && len(args) == 1:
+		checkpoints, err := chains.Checkpoint(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "checkpoint: %s\n", err)
+			return 1
+		}
+		for _, c := range checkpoints {
+			fmt.Printf("%s: signed seq %d hash %s with key %s\n", c.Chain, c.Seq, c.Hash, base64.StdEncoding.EncodeToString(c.PublicKey))
+		}
+		if len(checkpoints) == 0 {
+			fmt.Println("no chain moved since its last checkpoint")
+		}
+		return 0
+	default:
+		fmt.Fprint(os.Stderr, chainUsage)
+		return 2
+	}
+}
+
+func verifyChains(ctx context.Context, chains *service.ChainService, names []string) int {
+	code := 0
+	for _, name := range names {
+		verification, err := chains.VerifyChain(ctx, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+			code = 1
+			continue
+		}
+
+		if brk := verification.Break; brk != nil {
+			fmt.Printf("%s: BROKEN at seq %d: %s\n", name, brk.Seq, brk.Reason)
+			if brk.EntityID != nil {
+				fmt.Printf("  entry:    %s\n", brk.EntityID)
+			}
+			if brk.ExpectedHash != "" || brk.ActualHash != "" {
+				fmt.Printf("  expected: %s\n  actual:   %s\n", brk.ExpectedHash, brk.ActualHash)
+			}
+			code = 1
+			continue
+		}
+		fmt.Printf("%s: OK, %d entries, head %s, %d checkpoints\n", name, verification.Length, verification.HeadHash, verification.Checkpoints)
+	}
+	return code
+}
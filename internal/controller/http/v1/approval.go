@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseApprovalPolicy struct {
+	ID                uuid.UUID `json:"id"`
+	OrganizationID    uuid.UUID `json:"organizationId"`
+	Name              string    `json:"name"`
+	MinBudget         *string   `json:"minBudget,omitempty"`
+	RequiredApprovals int       `json:"requiredApprovals"`
+	CreatedBy         uuid.UUID `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type ResponseApprovalStep struct {
+	ApproverID uuid.UUID `json:"approverId"`
+	Decision   string    `json:"decision"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type ResponseApprovalRequest struct {
+	ID                uuid.UUID              `json:"id"`
+	TenderID          uuid.UUID              `json:"tenderId"`
+	PolicyID          *uuid.UUID             `json:"policyId,omitempty"`
+	RequiredApprovals int                    `json:"requiredApprovals"`
+	RequestedBy       uuid.UUID              `json:"requestedBy"`
+	Status            string                 `json:"status"`
+	CreatedAt         time.Time              `json:"createdAt"`
+	DecidedAt         *time.Time             `json:"decidedAt,omitempty"`
+	Steps             []ResponseApprovalStep `json:"steps"`
+}
+
+func newResponseApprovalPolicy(p *service.ApprovalPolicyOutput) ResponseApprovalPolicy {
+	return ResponseApprovalPolicy{
+		ID:                p.ID,
+		OrganizationID:    p.OrganizationID,
+		Name:              p.Name,
+		MinBudget:         p.MinBudget,
+		RequiredApprovals: p.RequiredApprovals,
+		CreatedBy:         p.CreatedBy,
+		CreatedAt:         p.CreatedAt,
+	}
+}
+
+func newResponseApprovalRequest(a *service.ApprovalRequestOutput) ResponseApprovalRequest {
+	steps := make([]ResponseApprovalStep, 0, len(a.Steps))
+	for _, s := range a.Steps {
+		steps = append(steps, ResponseApprovalStep{
+			ApproverID: s.ApproverID,
+			Decision:   s.Decision,
+			Comment:    s.Comment,
+			CreatedAt:  s.CreatedAt,
+		})
+	}
+
+	return ResponseApprovalRequest{
+		ID:                a.ID,
+		TenderID:          a.TenderID,
+		PolicyID:          a.PolicyID,
+		RequiredApprovals: a.RequiredApprovals,
+		RequestedBy:       a.RequestedBy,
+		Status:            a.Status,
+		CreatedAt:         a.CreatedAt,
+		DecidedAt:         a.DecidedAt,
+		Steps:             steps,
+	}
+}
+
+func (tr *tenderRouter) decideApprovalHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		data, problems, err := decodeValid[model.ApprovalDecision](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		approval, err := services.Approval.DecideApproval(r.Context(), &service.DecideApprovalInput{
+			TenderID:   tID,
+			ApproverID: user.ID,
+			Decision:   data.Decision,
+			Comment:    data.Comment,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrApprovalNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrSelfApproval):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrAlreadyApproved), errors.Is(err, service.ErrTenderCanceled):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to decide approval: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseApprovalRequest(approval))
+	}
+}
+
+func (tr *tenderRouter) getApprovalsHandler(as service.Approval) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		approvals, err := as.GetTenderApprovals(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get approvals: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseApprovalRequest, 0, len(approvals))
+		for _, a := range approvals {
+			response = append(response, newResponseApprovalRequest(a))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func respondWithApprovalPolicyError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrApprovalPolicyNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotOrganizationResponsible):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (or *organizationRouter) createApprovalPolicyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.ApprovalPolicy](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		policy, err := or.approvalService.CreatePolicy(r.Context(), &service.CreateApprovalPolicyInput{
+			OrganizationID:    oID,
+			Name:              data.Name,
+			MinBudget:         data.MinBudget,
+			RequiredApprovals: data.RequiredApprovals,
+			CreatedBy:         user.ID,
+		})
+		if err != nil {
+			respondWithApprovalPolicyError(w, err, "Failed to create approval policy: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseApprovalPolicy(policy))
+	}
+}
+
+func (or *organizationRouter) getApprovalPoliciesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		policies, err := or.approvalService.GetPolicies(r.Context(), oID, user.ID)
+		if err != nil {
+			respondWithApprovalPolicyError(w, err, "Failed to get approval policies: ")
+			return
+		}
+
+		response := make([]ResponseApprovalPolicy, 0, len(policies))
+		for _, p := range policies {
+			response = append(response, newResponseApprovalPolicy(p))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (or *organizationRouter) deleteApprovalPolicyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+		pID, err := uuid.Parse(r.PathValue("policyId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid policy ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		if err = or.approvalService.DeletePolicy(r.Context(), oID, pID, user.ID); err != nil {
+			respondWithApprovalPolicyError(w, err, "Failed to delete approval policy: ")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+// attachmentFormField is the multipart field carrying the uploaded file.
+const attachmentFormField = "file"
+
+var errAttachmentFileRequired = errors.New(`multipart field "file" is required`)
+
+type ResponseAttachment struct {
+	ID          uuid.UUID  `json:"id"`
+	TenderID    *uuid.UUID `json:"tenderId,omitempty"`
+	BidID       *uuid.UUID `json:"bidId,omitempty"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	Checksum    string     `json:"checksum"`
+	UploadedBy  uuid.UUID  `json:"uploadedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func newResponseAttachment(a *service.AttachmentOutput) ResponseAttachment {
+	return ResponseAttachment{
+		ID:          a.ID,
+		TenderID:    a.TenderID,
+		BidID:       a.BidID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func newResponseAttachments(attachments []*service.AttachmentOutput) []ResponseAttachment {
+	response := make([]ResponseAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		response = append(response, newResponseAttachment(a))
+	}
+	return response
+}
+
+func respondWithAttachmentError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrTenderNotFound), errors.Is(err, service.ErrBidNotFound), errors.Is(err, service.ErrAttachmentNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotBidAuthor):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrBidSealed), errors.Is(err, service.ErrSubmissionClosed):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		respondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		respondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, service.ErrAttachmentEmpty):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+// attachmentFile returns the file part of a multipart/form-data request. The
+// part is streamed, the request is never buffered as a whole.
+func attachmentFile(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errAttachmentFileRequired
+			}
+			return nil, err
+		}
+		if part.FormName() == attachmentFormField && part.FileName() != "" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// requestUser resolves the employee behind the username query parameter.
+// Access has already been checked by a middleware at this point.
+func requestUser(w http.ResponseWriter, r *http.Request, employeeService service.Employee) (*service.EmployeeOutput, bool) {
+	user, err := employeeService.GetByUsername(r.Context(), r.URL.Query().Get("username"))
+	if err != nil {
+		if errors.Is(err, service.ErrEmployeeNotFound) {
+			respondWithError(w, http.StatusUnauthorized, "User does not exist")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve user: "+err.Error())
+		}
+		return nil, false
+	}
+	return user, true
+}
+
+type uploadAttachmentFunc func(ctx context.Context, input *service.UploadAttachmentInput) (*service.AttachmentOutput, error)
+
+func uploadAttachment(w http.ResponseWriter, r *http.Request, parentID, uploaderID uuid.UUID, upload uploadAttachmentFunc) {
+	part, err := attachmentFile(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+		return
+	}
+	defer part.Close()
+
+	attachment, err := upload(r.Context(), &service.UploadAttachmentInput{
+		ParentID:   parentID,
+		UploaderID: uploaderID,
+		FileName:   part.FileName(),
+		Content:    part,
+	})
+	if err != nil {
+		respondWithAttachmentError(w, err, "Failed to upload attachment: ")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, newResponseAttachment(attachment))
+}
+
+func writeAttachment(w http.ResponseWriter, attachment *service.AttachmentContentOutput) {
+	defer attachment.Content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, attachment.Content)
+}
+
+func (tr *tenderRouter) uploadTenderAttachmentHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		uploadAttachment(w, r, tID, user.ID, services.Attachment.UploadTenderAttachment)
+	}
+}
+
+func (tr *tenderRouter) getTenderAttachmentsHandler(as service.Attachment) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		attachments, err := as.GetTenderAttachments(r.Context(), tID)
+		if err != nil {
+			respondWithAttachmentError(w, err, "Failed to get attachments: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseAttachments(attachments))
+	}
+}
+
+func (tr *tenderRouter) downloadTenderAttachmentHandler(as service.Attachment) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+		aID, err := uuid.Parse(r.PathValue("attachmentId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid attachment ID format: "+err.Error())
+			return
+		}
+
+		attachment, err := as.DownloadTenderAttachment(r.Context(), tID, aID)
+		if err != nil {
+			respondWithAttachmentError(w, err, "Failed to download attachment: ")
+			return
+		}
+
+		writeAttachment(w, attachment)
+	}
+}
+
+func (br *bidRouter) uploadBidAttachmentHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		uploadAttachment(w, r, bID, user.ID, services.Attachment.UploadBidAttachment)
+	}
+}
+
+func (br *bidRouter) getBidAttachmentsHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		attachments, err := services.Attachment.GetBidAttachments(r.Context(), bID, user.ID)
+		if err != nil {
+			respondWithAttachmentError(w, err, "Failed to get attachments: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseAttachments(attachments))
+	}
+}
+
+func (br *bidRouter) downloadBidAttachmentHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format: "+err.Error())
+			return
+		}
+		aID, err := uuid.Parse(r.PathValue("attachmentId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid attachment ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		attachment, err := services.Attachment.DownloadBidAttachment(r.Context(), bID, aID, user.ID)
+		if err != nil {
+			respondWithAttachmentError(w, err, "Failed to download attachment: ")
+			return
+		}
+
+		writeAttachment(w, attachment)
+	}
+}
@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"git.codenrock.com/tender/pkg/websocket"
+	"github.com/google/uuid"
+)
+
+const (
+	auctionPingPeriod   = 30 * time.Second
+	auctionReadTimeout  = 2 * auctionPingPeriod
+	auctionWriteTimeout = 5 * time.Second
+)
+
+type auctionRequest struct {
+	Type  string    `json:"type"`
+	BidID uuid.UUID `json:"bidId"`
+	Price string    `json:"price"`
+}
+
+type ResponseAuctionRank struct {
+	Rank       int       `json:"rank"`
+	BidID      uuid.UUID `json:"bidId"`
+	AuthorType string    `json:"authorType"`
+	AuthorID   uuid.UUID `json:"authorId"`
+	Price      string    `json:"price"`
+}
+
+type ResponseAuctionState struct {
+	Type      string                `json:"type"`
+	TenderID  uuid.UUID             `json:"tenderId"`
+	Currency  *string               `json:"currency,omitempty"`
+	StartsAt  time.Time             `json:"startsAt"`
+	EndsAt    time.Time             `json:"endsAt"`
+	Running   bool                  `json:"running"`
+	Finished  bool                  `json:"finished"`
+	BestPrice *string               `json:"bestPrice,omitempty"`
+	BidCount  int                   `json:"bidCount"`
+	Bids      []ResponseAuctionRank `json:"bids"`
+}
+
+type ResponseAuctionError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// newResponseAuctionState shows tender responsibles the whole ranking, while
+// bidders only see the best price and the rank of their own bids.
+func newResponseAuctionState(state *service.AuctionStateOutput, userID uuid.UUID, responsible bool) ResponseAuctionState {
+	response := ResponseAuctionState{
+		Type:      "state",
+		TenderID:  state.TenderID,
+		Currency:  state.Currency,
+		StartsAt:  state.StartsAt,
+		EndsAt:    state.EndsAt,
+		Running:   state.Running,
+		Finished:  state.Finished,
+		BestPrice: state.BestPrice,
+		BidCount:  len(state.Ranking),
+		Bids:      []ResponseAuctionRank{},
+	}
+
+	for _, rank := range state.Ranking {
+		if !responsible && rank.AuthorID != userID {
+			continue
+		}
+		response.Bids = append(response.Bids, ResponseAuctionRank{
+			Rank:       rank.Rank,
+			BidID:      rank.BidID,
+			AuthorType: rank.AuthorType,
+			AuthorID:   rank.AuthorID,
+			Price:      rank.Price,
+		})
+	}
+	return response
+}
+
+func newAuctionError(message string) ResponseAuctionError {
+	return ResponseAuctionError{Type: "error", Message: message}
+}
+
+// auctionHandler streams the state of a reverse auction over a WebSocket.
+// Bidders lower their prices by sending {"type":"offer","bidId":...,"price":...}.
+func (tr *tenderRouter) auctionHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		username := r.URL.Query().Get("username")
+		if username == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+
+		user, err := services.Employee.GetByUsername(r.Context(), username)
+		if err != nil {
+			if errors.Is(err, service.ErrEmployeeNotFound) {
+				respondWithError(w, http.StatusUnauthorized, "User does not exist")
+			} else {
+				respondWithError(w, http.StatusInternalServerError, "Failed to retrieve user: "+err.Error())
+			}
+			return
+		}
+
+		responsible, err := services.Organization.IsResponsibleForTender(r.Context(), user.ID, tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to check responsibility: "+err.Error())
+			return
+		}
+		if !responsible {
+			if _, err = services.Bid.GetBidByTenderAndAuthor(r.Context(), tID, user.ID); err != nil {
+				respondWithError(w, http.StatusForbidden, "Only bidders and tender responsibles can follow the auction")
+				return
+			}
+		}
+
+		// Subscribe before reading the state so that no offer is missed in between.
+		updates, unsubscribe := services.Auction.Subscribe(tID)
+		defer unsubscribe()
+
+		state, err := services.Auction.GetAuctionState(r.Context(), tID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrNotAuction):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to get auction: "+err.Error())
+			}
+			return
+		}
+
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.SetWriteTimeout(auctionWriteTimeout)
+		if err = conn.SetReadTimeout(auctionReadTimeout); err != nil {
+			return
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			readAuctionOffers(r.Context(), conn, services.Auction, tID, user.ID)
+		}()
+
+		if err = conn.WriteJSON(newResponseAuctionState(state, user.ID, responsible)); err != nil {
+			return
+		}
+
+		ping := time.NewTicker(auctionPingPeriod)
+		defer ping.Stop()
+		// Clients are told when the auction opens; a nil channel never fires.
+		var started <-chan time.Time
+		if wait := time.Until(state.StartsAt); wait > 0 {
+			start := time.NewTimer(wait)
+			defer start.Stop()
+			started = start.C
+		}
+		end := time.NewTimer(time.Until(state.EndsAt))
+		defer end.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case state = <-updates:
+				if err = conn.WriteJSON(newResponseAuctionState(state, user.ID, responsible)); err != nil {
+					return
+				}
+				end.Reset(time.Until(state.EndsAt))
+			case <-ping.C:
+				if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			case <-started:
+				if state, err = services.Auction.GetAuctionState(r.Context(), tID); err != nil {
+					return
+				}
+				if err = conn.WriteJSON(newResponseAuctionState(state, user.ID, responsible)); err != nil {
+					return
+				}
+			case <-end.C:
+				if state, err = services.Auction.GetAuctionState(r.Context(), tID); err != nil {
+					return
+				}
+				// The auction may have been extended by an offer placed on another instance.
+				if !state.Finished {
+					end.Reset(time.Until(state.EndsAt))
+					continue
+				}
+
+				_ = conn.WriteJSON(newResponseAuctionState(state, user.ID, responsible))
+				_ = conn.WriteClose(websocket.CloseNormalClosure, "auction finished")
+				select {
+				case <-done:
+				case <-time.After(auctionWriteTimeout):
+				}
+				return
+			}
+		}
+	}
+}
+
+// readAuctionOffers places the offers received on conn until the connection
+// is closed. Accepted offers reach every client through the state broadcast.
+func readAuctionOffers(ctx context.Context, conn *websocket.Conn, as service.Auction, tenderID, userID uuid.UUID) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req auctionRequest
+		if err = json.Unmarshal(data, &req); err != nil || req.Type != "offer" || req.BidID == uuid.Nil {
+			_ = conn.WriteJSON(newAuctionError(`Invalid message, expected {"type":"offer","bidId":"...","price":"..."}`))
+			continue
+		}
+		if !model.ValidAmount(req.Price) {
+			_ = conn.WriteJSON(newAuctionError("Price must be a positive decimal with at most 2 fraction digits"))
+			continue
+		}
+
+		_, err = as.PlaceOffer(ctx, &service.PlaceOfferInput{
+			TenderID: tenderID,
+			BidID:    req.BidID,
+			AuthorID: userID,
+			Price:    req.Price,
+		})
+		if err != nil {
+			_ = conn.WriteJSON(newAuctionError(err.Error()))
+		}
+	}
+}
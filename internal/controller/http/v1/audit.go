@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type auditRouter struct {
+	auditService service.Audit
+	chainService service.Chain
+}
+
+// newAuditRouter serves the audit trail and the verification of the hash
+// chains. It is read by the administrators only.
+func newAuditRouter(auditService service.Audit, chainService service.Chain, services *service.Services) http.Handler {
+	r := &auditRouter{
+		auditService: auditService,
+		chainService: chainService,
+	}
+
+	mux := http.NewServeMux()
+
+	adminMiddleware := adminMiddleware(services)
+
+	mux.Handle("GET /", adminMiddleware(http.HandlerFunc(r.getAuditEventsHandler())))
+	mux.Handle("GET /chains/{chain}/verify", adminMiddleware(http.HandlerFunc(r.verifyChainHandler())))
+	mux.Handle("GET /chains/{chain}/checkpoints", adminMiddleware(http.HandlerFunc(r.getCheckpointsHandler())))
+
+	return http.StripPrefix("/api/audit", mux)
+}
+
+type ResponseAuditEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   uuid.UUID       `json:"entityId"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+	IP         string          `json:"ip,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Seq        *int64          `json:"seq,omitempty"`
+	PrevHash   string          `json:"prevHash,omitempty"`
+	Hash       string          `json:"hash,omitempty"`
+}
+
+// getAuditEventsHandler lists the events matching the query, newest first.
+// from and to bound the creation time and are RFC 3339 timestamps.
+func (ar *auditRouter) getAuditEventsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, offset := pagination(r)
+
+		input := &service.GetAuditEventsInput{
+			Actor:      query.Get("actor"),
+			Action:     query.Get("action"),
+			EntityType: query.Get("entityType"),
+			RequestID:  query.Get("requestId"),
+			Limit:      limit,
+			Offset:     offset,
+		}
+		if entityIDParam := query.Get("entityId"); entityIDParam != "" {
+			entityID, err := uuid.Parse(entityIDParam)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid entity ID format")
+				return
+			}
+			input.EntityID = &entityID
+		}
+		for param, bound := range map[string]**time.Time{"from": &input.From, "to": &input.To} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid "+param+" time, RFC 3339 expected")
+				return
+			}
+			*bound = &t
+		}
+
+		events, err := ar.auditService.GetAuditEvents(r.Context(), input)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get audit events: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseAuditEvent, 0, len(events))
+		for _, e := range events {
+			response = append(response, ResponseAuditEvent{
+				ID:         e.ID,
+				Actor:      e.Actor,
+				Action:     e.Action,
+				EntityType: e.EntityType,
+				EntityID:   e.EntityID,
+				Before:     e.Before,
+				After:      e.After,
+				RequestID:  e.RequestID,
+				IP:         e.IP,
+				CreatedAt:  e.CreatedAt,
+				Seq:        e.Seq,
+				PrevHash:   e.PrevHash,
+				Hash:       e.Hash,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+type ResponseChainBreak struct {
+	Seq          int64      `json:"seq"`
+	EntityID     *uuid.UUID `json:"entityId,omitempty"`
+	Reason       string     `json:"reason"`
+	ExpectedHash string     `json:"expectedHash,omitempty"`
+	ActualHash   string     `json:"actualHash,omitempty"`
+}
+
+type ResponseChainVerification struct {
+	Chain       string              `json:"chain"`
+	Valid       bool                `json:"valid"`
+	Length      int64               `json:"length"`
+	HeadSeq     int64               `json:"headSeq"`
+	HeadHash    string              `json:"headHash,omitempty"`
+	Checkpoints int                 `json:"checkpoints"`
+	Break       *ResponseChainBreak `json:"break,omitempty"`
+	VerifiedAt  time.Time           `json:"verifiedAt"`
+}
+
+// ResponseChainCheckpoint holds the public key and the signature encoded in
+// standard base64.
+type ResponseChainCheckpoint struct {
+	ID        uuid.UUID `json:"id"`
+	Chain     string    `json:"chain"`
+	Seq       int64     `json:"seq"`
+	Hash      string    `json:"hash"`
+	PublicKey []byte    `json:"publicKey"`
+	Signature []byte    `json:"signature"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func respondWithChainError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrChainNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+// verifyChainHandler recomputes the chain and reports its first broken link.
+// A broken chain is still a successful verification.
+func (ar *auditRouter) verifyChainHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		verification, err := ar.chainService.VerifyChain(r.Context(), r.PathValue("chain"))
+		if err != nil {
+			respondWithChainError(w, err, "Failed to verify chain: ")
+			return
+		}
+
+		response := ResponseChainVerification{
+			Chain:       verification.Chain,
+			Valid:       verification.Valid,
+			Length:      verification.Length,
+			HeadSeq:     verification.HeadSeq,
+			HeadHash:    verification.HeadHash,
+			Checkpoints: verification.Checkpoints,
+			VerifiedAt:  verification.VerifiedAt,
+		}
+		if brk := verification.Break; brk != nil {
+			response.Break = &ResponseChainBreak{
+				Seq:          brk.Seq,
+				EntityID:     brk.EntityID,
+				Reason:       brk.Reason,
+				ExpectedHash: brk.ExpectedHash,
+				ActualHash:   brk.ActualHash,
+			}
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (ar *auditRouter) getCheckpointsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checkpoints, err := ar.chainService.GetCheckpoints(r.Context(), r.PathValue("chain"))
+		if err != nil {
+			respondWithChainError(w, err, "Failed to get checkpoints: ")
+			return
+		}
+
+		response := make([]ResponseChainCheckpoint, 0, len(checkpoints))
+		for _, c := range checkpoints {
+			response = append(response, ResponseChainCheckpoint{
+				ID:        c.ID,
+				Chain:     c.Chain,
+				Seq:       c.Seq,
+				Hash:      c.Hash,
+				PublicKey: c.PublicKey,
+				Signature: c.Signature,
+				CreatedAt: c.CreatedAt,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/model"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
 	"git.codenrock.com/tender/internal/service"
@@ -29,25 +30,74 @@ func newbidRouter(bidService service.Bid, services *service.Services) http.Handl
 
 	mux.Handle("POST /new", createBidMiddleware(http.HandlerFunc(r.createBidHandler())))
 	mux.Handle("GET /my", r.GetUserBidsHandler(services.Employee))
-	mux.Handle("GET /{tenderId}/list", r.getBidsByTenderHandler(services.Employee))
+	mux.Handle("GET /{tenderId}/list", r.getBidsByTenderHandler(services.Employee, services.Organization))
 	mux.Handle("GET /{bidId}/status", authorOrResponsibleMiddleware(http.HandlerFunc(r.getBidStatusHandler())))
 	mux.Handle("PUT /{bidId}/status", authorOrResponsibleMiddleware(http.HandlerFunc(r.updateBidStatusHandler())))
-	mux.Handle("PATCH /{bidId}/edit", authorOrResponsibleMiddleware(http.HandlerFunc(r.updateBidHandler())))
+	mux.Handle("PATCH /{bidId}/edit", authorOrResponsibleMiddleware(http.HandlerFunc(r.updateBidHandler(services.Employee))))
+	mux.Handle("PUT /{bidId}/acknowledge", r.acknowledgeBidHandler(services.Employee))
+	mux.Handle("POST /{bidId}/withdraw", r.withdrawBidHandler(services.Employee))
+	mux.Handle("POST /{bidId}/resubmit", r.resubmitBidHandler(services.Employee))
+	mux.Handle("GET /{bidId}/versions", authorOrResponsibleMiddleware(http.HandlerFunc(r.getBidVersionsHandler(services.Employee))))
+	mux.Handle("GET /{bidId}/versions/{version}/signature", r.verifyBidSignatureHandler())
 
-	mux.Handle("PUT /{bidId}/submit_decision", accessMiddleware(http.HandlerFunc(r.updateBidDecisionHandler(services.Tender))))
-	mux.Handle("PUT /{bidId}/feedback", accessMiddleware(http.HandlerFunc(r.updateBidFeedbackHandler())))
+	mux.Handle("POST /{bidId}/attachments", authorOrResponsibleMiddleware(http.HandlerFunc(r.uploadBidAttachmentHandler(services))))
+	mux.Handle("GET /{bidId}/attachments", authorOrResponsibleMiddleware(http.HandlerFunc(r.getBidAttachmentsHandler(services))))
+	mux.Handle("GET /{bidId}/attachments/{attachmentId}", authorOrResponsibleMiddleware(http.HandlerFunc(r.downloadBidAttachmentHandler(services))))
+
+	mux.Handle("POST /{bidId}/messages", r.sendMessageHandler(services))
+	mux.Handle("GET /{bidId}/messages", r.getMessagesHandler(services))
+
+	mux.Handle("POST /{bidId}/negotiation", accessMiddleware(http.HandlerFunc(r.proposeCounterOfferHandler(services))))
+	mux.Handle("PUT /{bidId}/negotiation/respond", r.respondCounterOfferHandler(services))
+	mux.Handle("GET /{bidId}/negotiation", authorOrResponsibleMiddleware(http.HandlerFunc(r.getNegotiationRoundsHandler(services))))
+
+	mux.Handle("PUT /{bidId}/submit_decision", accessMiddleware(http.HandlerFunc(r.updateBidDecisionHandler(services.Employee, services.Tender))))
+	mux.Handle("PUT /{bidId}/feedback", accessMiddleware(http.HandlerFunc(r.updateBidFeedbackHandler(services.Employee))))
+	mux.Handle("PUT /{bidId}/scores", accessMiddleware(http.HandlerFunc(r.scoreBidHandler(services.Employee, services.Evaluation))))
 
 	return http.StripPrefix("/api/bids", mux)
 }
 
 type ResponseBid struct {
-	ID         uuid.UUID `json:"id"`
-	Name       string    `json:"name"`
-	Status     string    `json:"status"`
-	AuthorType string    `json:"authorType"`
-	AuthorID   uuid.UUID `json:"authorId"`
-	Version    int       `json:"version"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Status       string      `json:"status"`
+	AuthorType   string      `json:"authorType"`
+	AuthorID     uuid.UUID   `json:"authorId"`
+	Version      int         `json:"version"`
+	Price        *string     `json:"price,omitempty"`
+	Currency     *string     `json:"currency,omitempty"`
+	DeliveryDays *int        `json:"deliveryDays,omitempty"`
+	ValidityDays *int        `json:"validityDays,omitempty"`
+	Score        *string     `json:"score,omitempty"`
+	LotIDs       []uuid.UUID `json:"lotIds,omitempty"`
+	Sealed       bool        `json:"sealed,omitempty"`
+	// NeedsAcknowledgement is set while the author has not acknowledged
+	// the latest amendment of the tender.
+	NeedsAcknowledgement bool      `json:"needsAcknowledgement,omitempty"`
+	AuthorReputation     *float64  `json:"authorReputation,omitempty"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+func newResponseBid(bid *service.BidOutput) ResponseBid {
+	return ResponseBid{
+		ID:                   bid.ID,
+		Name:                 bid.Name,
+		Status:               bid.Status,
+		AuthorType:           bid.AuthorType,
+		AuthorID:             bid.AuthorID,
+		Version:              bid.Version,
+		Price:                bid.Price,
+		Currency:             bid.Currency,
+		DeliveryDays:         bid.DeliveryDays,
+		ValidityDays:         bid.ValidityDays,
+		Score:                bid.TotalScore,
+		LotIDs:               bid.LotIDs,
+		Sealed:               bid.Sealed,
+		NeedsAcknowledgement: bid.NeedsAck,
+		AuthorReputation:     bid.AuthorReputation,
+		CreatedAt:            bid.CreatedAt,
+	}
 }
 
 func (br *bidRouter) createBidHandler() http.HandlerFunc {
@@ -64,26 +114,40 @@ func (br *bidRouter) createBidHandler() http.HandlerFunc {
 		}
 
 		createdBid, err := br.bidService.CreateBid(r.Context(), &service.CreateBidInput{
-			Name:        bid.Name,
-			Description: bid.Description,
-			TenderID:    bid.TenderID,
-			AuthorType:  bid.AuthorType,
-			AuthorID:    bid.AuthorID,
+			Name:         bid.Name,
+			Description:  bid.Description,
+			TenderID:     bid.TenderID,
+			AuthorType:   bid.AuthorType,
+			AuthorID:     bid.AuthorID,
+			Price:        bid.Price,
+			Currency:     bid.Currency,
+			DeliveryDays: bid.DeliveryDays,
+			ValidityDays: bid.ValidityDays,
+			LotIDs:       bid.LotIDs,
+			Signature:    newBidSignatureInput(bid.Signature),
 		})
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			if respondWithSignatureError(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound), errors.Is(err, service.ErrLotNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrLotRequired):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			case errors.Is(err, service.ErrNotInvited), errors.Is(err, service.ErrSupplierDebarred),
+				errors.Is(err, service.ErrConflictOfInterest):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrSubmissionClosed), errors.Is(err, service.ErrBidAlreadyExists),
+				errors.Is(err, service.ErrCurrencyMismatch):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, err.Error())
+			}
 			return
 		}
 
-		response := ResponseBid{
-			ID:         createdBid.ID,
-			Name:       createdBid.Name,
-			Status:     createdBid.Status,
-			AuthorType: createdBid.AuthorType,
-			AuthorID:   createdBid.AuthorID,
-			Version:    createdBid.Version,
-			CreatedAt:  createdBid.CreatedAt,
-		}
+		response := newResponseBid(createdBid)
 		respondWithJSON(w, http.StatusOK, response)
 	}
 }
@@ -131,21 +195,13 @@ func (br *bidRouter) GetUserBidsHandler(es service.Employee) http.HandlerFunc {
 
 		var bidsResponse []ResponseBid
 		for _, bid := range bids {
-			bidsResponse = append(bidsResponse, ResponseBid{
-				ID:         bid.ID,
-				Name:       bid.Name,
-				Status:     bid.Status,
-				AuthorType: bid.AuthorType,
-				AuthorID:   bid.AuthorID,
-				Version:    bid.Version,
-				CreatedAt:  bid.CreatedAt,
-			})
+			bidsResponse = append(bidsResponse, newResponseBid(bid))
 		}
 		respondWithJSON(w, http.StatusOK, bidsResponse)
 	}
 }
 
-func (br *bidRouter) getBidsByTenderHandler(es service.Employee) http.HandlerFunc {
+func (br *bidRouter) getBidsByTenderHandler(es service.Employee, os service.Organization) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		username := r.URL.Query().Get("username")
 		if username == "" {
@@ -188,28 +244,32 @@ func (br *bidRouter) getBidsByTenderHandler(es service.Employee) http.HandlerFun
 			TenderID: tID,
 		})
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve bids "+err.Error())
+			if errors.Is(err, service.ErrTenderNotFound) {
+				respondWithError(w, http.StatusNotFound, "Tender not found "+err.Error())
+			} else {
+				respondWithError(w, http.StatusInternalServerError, "Failed to retrieve bids "+err.Error())
+			}
+			return
+		}
+
+		isResponsible, err := os.IsResponsibleForTender(r.Context(), user.ID, tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to check responsibility: "+err.Error())
 			return
 		}
 
-		for _, bid := range bids {
-			if bid.AuthorID != user.ID {
-				respondWithError(w, http.StatusForbidden, "User is not authorized to view these bids")
-				return
+		if !isResponsible {
+			for _, bid := range bids {
+				if bid.AuthorID != user.ID {
+					respondWithError(w, http.StatusForbidden, "User is not authorized to view these bids")
+					return
+				}
 			}
 		}
 
 		bidsResponse := make([]ResponseBid, 0, len(bids))
 		for _, bid := range bids {
-			bidsResponse = append(bidsResponse, ResponseBid{
-				ID:         bid.ID,
-				Name:       bid.Name,
-				Status:     bid.Status,
-				AuthorType: bid.AuthorType,
-				AuthorID:   bid.AuthorID,
-				Version:    bid.Version,
-				CreatedAt:  bid.CreatedAt,
-			})
+			bidsResponse = append(bidsResponse, newResponseBid(bid))
 		}
 
 		respondWithJSON(w, http.StatusOK, bidsResponse)
@@ -262,27 +322,34 @@ func (br *bidRouter) updateBidStatusHandler() http.HandlerFunc {
 			Status: status,
 		})
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Failed to update bid status "+err.Error())
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
+				respondWithError(w, http.StatusNotFound, "Bid not found "+err.Error())
+			case errors.Is(err, service.ErrBidWithdrawn), errors.Is(err, service.ErrBidCanceled),
+				errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrBidAlreadyExists):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to update bid status "+err.Error())
+			}
 			return
 		}
 
-		response := ResponseBid{
-			ID:         updatedBid.ID,
-			Name:       updatedBid.Name,
-			Status:     updatedBid.Status,
-			AuthorType: updatedBid.AuthorType,
-			AuthorID:   updatedBid.AuthorID,
-			Version:    updatedBid.Version,
-			CreatedAt:  updatedBid.CreatedAt,
-		}
+		response := newResponseBid(updatedBid)
 		respondWithJSON(w, http.StatusOK, response)
 	}
 }
 
-func (br *bidRouter) updateBidHandler() http.HandlerFunc {
+func (br *bidRouter) updateBidHandler(es service.Employee) http.HandlerFunc {
 	type Request struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		Price        string `json:"price"`
+		Currency     string `json:"currency"`
+		DeliveryDays int    `json:"deliveryDays"`
+		ValidityDays int    `json:"validityDays"`
+		// Signature optionally signs the canonical document of the
+		// revised bid version.
+		Signature *model.BidSignature `json:"signature,omitempty"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		data, err := decode[Request](r)
@@ -290,41 +357,277 @@ func (br *bidRouter) updateBidHandler() http.HandlerFunc {
 			respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
 			return
 		}
+		if data.Signature != nil {
+			if problems := data.Signature.Valid(r.Context()); len(problems) > 0 {
+				respondWithValidationErrors(w, problems)
+				return
+			}
+		}
+		if data.Price != "" && !model.ValidAmount(data.Price) {
+			respondWithError(w, http.StatusBadRequest, "Price must be a positive decimal with at most 2 fraction digits")
+			return
+		}
+		if data.Currency != "" && !model.ValidCurrency(data.Currency) {
+			respondWithError(w, http.StatusBadRequest, "Currency must be a 3-letter ISO 4217 code")
+			return
+		}
+		if data.DeliveryDays < 0 || data.ValidityDays < 0 {
+			respondWithError(w, http.StatusBadRequest, "Delivery time and validity period must be positive")
+			return
+		}
 		bidID := r.PathValue("bidId")
 		bID, err := uuid.Parse(bidID)
 		if err != nil {
 			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
 			return
 		}
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
 
 		updatedBid, err := br.bidService.UpdateBid(r.Context(), &service.UpdateBidInput{
-			BidID:       bID,
-			Name:        data.Name,
-			Description: data.Description,
+			BidID:        bID,
+			Name:         data.Name,
+			Description:  data.Description,
+			Price:        data.Price,
+			Currency:     data.Currency,
+			DeliveryDays: data.DeliveryDays,
+			ValidityDays: data.ValidityDays,
+			EditorID:     user.ID,
+			Signature:    newBidSignatureInput(data.Signature),
 		})
 		if err != nil {
-			if errors.Is(err, service.ErrBidNotFound) {
+			if respondWithSignatureError(w, err) {
+				return
+			}
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
 				respondWithError(w, http.StatusNotFound, "Bid not found "+err.Error())
-			} else {
+			case errors.Is(err, service.ErrSubmissionClosed), errors.Is(err, service.ErrCurrencyMismatch),
+				errors.Is(err, service.ErrAuctionPriceLocked), errors.Is(err, service.ErrBidWithdrawn):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
 				respondWithError(w, http.StatusInternalServerError, "Failed to update bid "+err.Error())
 			}
 			return
 		}
 
-		response := ResponseBid{
-			ID:         updatedBid.ID,
-			Name:       updatedBid.Name,
-			Status:     updatedBid.Status,
-			AuthorType: updatedBid.AuthorType,
-			AuthorID:   updatedBid.AuthorID,
-			Version:    updatedBid.Version,
-			CreatedAt:  updatedBid.CreatedAt,
+		response := newResponseBid(updatedBid)
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (br *bidRouter) acknowledgeBidHandler(es service.Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		bid, err := br.bidService.AcknowledgeBid(r.Context(), &service.AcknowledgeBidInput{
+			BidID:    bID,
+			AuthorID: user.ID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrNotBidAuthor):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to acknowledge bid: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseBid(bid))
+	}
+}
+
+func (br *bidRouter) withdrawBidHandler(es service.Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		bid, err := br.bidService.WithdrawBid(r.Context(), &service.WithdrawBidInput{
+			BidID:    bID,
+			AuthorID: user.ID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrNotBidAuthor):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrBidWithdrawn), errors.Is(err, service.ErrBidCanceled),
+				errors.Is(err, service.ErrSubmissionClosed):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to withdraw bid: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseBid(bid))
+	}
+}
+
+func (br *bidRouter) resubmitBidHandler(es service.Employee) http.HandlerFunc {
+	type Request struct {
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		Price        string `json:"price"`
+		Currency     string `json:"currency"`
+		DeliveryDays int    `json:"deliveryDays"`
+		ValidityDays int    `json:"validityDays"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		var data Request
+		if r.ContentLength != 0 {
+			if data, err = decode[Request](r); err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+				return
+			}
+		}
+		if data.Price != "" && !model.ValidAmount(data.Price) {
+			respondWithError(w, http.StatusBadRequest, "Price must be a positive decimal with at most 2 fraction digits")
+			return
+		}
+		if data.Currency != "" && !model.ValidCurrency(data.Currency) {
+			respondWithError(w, http.StatusBadRequest, "Currency must be a 3-letter ISO 4217 code")
+			return
+		}
+		if data.DeliveryDays < 0 || data.ValidityDays < 0 {
+			respondWithError(w, http.StatusBadRequest, "Delivery time and validity period must be positive")
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		bid, err := br.bidService.ResubmitBid(r.Context(), &service.ResubmitBidInput{
+			BidID:        bID,
+			AuthorID:     user.ID,
+			Name:         data.Name,
+			Description:  data.Description,
+			Price:        data.Price,
+			Currency:     data.Currency,
+			DeliveryDays: data.DeliveryDays,
+			ValidityDays: data.ValidityDays,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrNotBidAuthor):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrBidNotWithdrawn), errors.Is(err, service.ErrSubmissionClosed),
+				errors.Is(err, service.ErrCurrencyMismatch), errors.Is(err, service.ErrAuctionPriceLocked),
+				errors.Is(err, service.ErrBidAlreadyExists):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to resubmit bid: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseBid(bid))
+	}
+}
+
+type ResponseBidVersion struct {
+	Version      int       `json:"version"`
+	Status       string    `json:"status"`
+	Name         string    `json:"name,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Price        *string   `json:"price,omitempty"`
+	Currency     *string   `json:"currency,omitempty"`
+	DeliveryDays *int      `json:"deliveryDays,omitempty"`
+	ValidityDays *int      `json:"validityDays,omitempty"`
+	Sealed       bool      `json:"sealed,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (br *bidRouter) getBidVersionsHandler(es service.Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		versions, err := br.bidService.GetBidVersions(r.Context(), &service.GetBidVersionsInput{
+			BidID:       bID,
+			RequesterID: user.ID,
+		})
+		if err != nil {
+			if errors.Is(err, service.ErrBidNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusInternalServerError, "Failed to get bid versions: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseBidVersion, 0, len(versions))
+		for _, v := range versions {
+			response = append(response, ResponseBidVersion{
+				Version:      v.Version,
+				Status:       v.Status,
+				Name:         v.Name,
+				Description:  v.Description,
+				Price:        v.Price,
+				Currency:     v.Currency,
+				DeliveryDays: v.DeliveryDays,
+				ValidityDays: v.ValidityDays,
+				Sealed:       v.Sealed,
+				CreatedAt:    v.CreatedAt,
+			})
 		}
 		respondWithJSON(w, http.StatusOK, response)
 	}
 }
 
-func (br *bidRouter) updateBidDecisionHandler(ts service.Tender) http.HandlerFunc {
+func (br *bidRouter) updateBidDecisionHandler(es service.Employee, ts service.Tender) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		bidID := r.PathValue("bidId")
@@ -344,14 +647,30 @@ func (br *bidRouter) updateBidDecisionHandler(ts service.Tender) http.HandlerFun
 			return
 		}
 
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
 		updatedBid, err := br.bidService.UpdateBidDecision(r.Context(), &service.UpdateBidDecisionInput{
-			BidID:    bID,
-			Decision: decision,
+			BidID:     bID,
+			DeciderID: user.ID,
+			Decision:  decision,
 		})
 		if err != nil {
-			if errors.Is(err, service.ErrBidNotFound) {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
 				respondWithError(w, http.StatusNotFound, "Bid not found "+err.Error())
-			} else {
+			case errors.Is(err, service.ErrConflictOfInterest):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrBidSealed), errors.Is(err, service.ErrScoringIncomplete),
+				errors.Is(err, service.ErrLotDecisionRequired), errors.Is(err, service.ErrAuctionNotFinished),
+				errors.Is(err, service.ErrNotAuctionWinner), errors.Is(err, service.ErrBidNeedsAcknowledgement),
+				errors.Is(err, service.ErrBidCanceled), errors.Is(err, service.ErrBidWithdrawn),
+				errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrTenderNotPublished),
+				errors.Is(err, service.ErrTenderAlreadyAwarded):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
 				respondWithError(w, http.StatusInternalServerError, "Failed to update bid decision "+err.Error())
 			}
 			return
@@ -360,7 +679,7 @@ func (br *bidRouter) updateBidDecisionHandler(ts service.Tender) http.HandlerFun
 		if decision == "Approved" {
 			_, err := ts.UpdateTenderStatus(r.Context(), &service.UpdateTenderStatusInput{
 				TenderID: updatedBid.TenderID,
-				Status:   "Closed",
+				Status:   entity.TenderStatusClosed,
 			})
 			if err != nil {
 				respondWithError(w, http.StatusInternalServerError, "Failed to update tender status "+err.Error())
@@ -368,20 +687,12 @@ func (br *bidRouter) updateBidDecisionHandler(ts service.Tender) http.HandlerFun
 			}
 		}
 
-		response := ResponseBid{
-			ID:         updatedBid.ID,
-			Name:       updatedBid.Name,
-			Status:     updatedBid.Status,
-			AuthorType: updatedBid.AuthorType,
-			AuthorID:   updatedBid.AuthorID,
-			Version:    updatedBid.Version,
-			CreatedAt:  updatedBid.CreatedAt,
-		}
+		response := newResponseBid(updatedBid)
 		respondWithJSON(w, http.StatusOK, response)
 	}
 }
 
-func (br *bidRouter) updateBidFeedbackHandler() http.HandlerFunc {
+func (br *bidRouter) updateBidFeedbackHandler(es service.Employee) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bidID := r.PathValue("bidId")
 		bID, err := uuid.Parse(bidID)
@@ -390,27 +701,139 @@ func (br *bidRouter) updateBidFeedbackHandler() http.HandlerFunc {
 			return
 		}
 		feedback := r.URL.Query().Get("feedback")
+
+		var rating *int
+		if ratingParam := r.URL.Query().Get("rating"); ratingParam != "" {
+			value, err := strconv.Atoi(ratingParam)
+			if err != nil || value < 1 || value > 5 {
+				respondWithError(w, http.StatusBadRequest, "Rating must be a whole number of stars from 1 to 5")
+				return
+			}
+			rating = &value
+		}
+
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
 		updatedBid, err := br.bidService.UpdateBidFeedback(r.Context(), &service.UpdateBidFeedbackInput{
-			BidID:    bID,
-			Feedback: feedback,
+			BidID:      bID,
+			ReviewerID: user.ID,
+			Feedback:   feedback,
+			Rating:     rating,
 		})
 		if err != nil {
-			if errors.Is(err, service.ErrBidNotFound) {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound):
 				respondWithError(w, http.StatusNotFound, "Bid not found "+err.Error())
-			} else {
+			case errors.Is(err, service.ErrConflictOfInterest):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			default:
 				respondWithError(w, http.StatusInternalServerError, "Failed to update bid feedback "+err.Error())
 			}
 			return
 		}
 
-		response := ResponseBid{
-			ID:         updatedBid.ID,
-			Name:       updatedBid.Name,
-			Status:     updatedBid.Status,
-			AuthorType: updatedBid.AuthorType,
-			AuthorID:   updatedBid.AuthorID,
-			Version:    updatedBid.Version,
-			CreatedAt:  updatedBid.CreatedAt,
+		response := newResponseBid(updatedBid)
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func newBidSignatureInput(signature *model.BidSignature) *service.BidSignatureInput {
+	if signature == nil {
+		return nil
+	}
+	return &service.BidSignatureInput{
+		KeyID:     signature.KeyID,
+		Signature: signature.Signature,
+	}
+}
+
+// respondWithSignatureError responds to the errors of a signed submission
+// and reports whether err was one of them.
+func respondWithSignatureError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrKeyNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrKeyRevoked), errors.Is(err, service.ErrInvalidSignature):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrSignedVersionConflict):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
+// ResponseBidSignature is the verification of the signature of a bid
+// version. Document is the canonical document that was signed, as a string
+// so that its bytes are kept exactly, and the key is in its DER encoded PKIX
+// form.
+type ResponseBidSignature struct {
+	BidID          uuid.UUID  `json:"bidId"`
+	Version        int        `json:"version"`
+	Signed         bool       `json:"signed"`
+	Valid          bool       `json:"valid"`
+	Reason         string     `json:"reason,omitempty"`
+	KeyID          *uuid.UUID `json:"keyId,omitempty"`
+	Algorithm      string     `json:"algorithm,omitempty"`
+	PublicKey      []byte     `json:"publicKey,omitempty"`
+	Fingerprint    string     `json:"fingerprint,omitempty"`
+	KeyRevokedAt   *time.Time `json:"keyRevokedAt,omitempty"`
+	SignerID       *uuid.UUID `json:"signerId,omitempty"`
+	SignerUsername string     `json:"signerUsername,omitempty"`
+	Document       string     `json:"document,omitempty"`
+	Signature      []byte     `json:"signature,omitempty"`
+	SignedAt       *time.Time `json:"signedAt,omitempty"`
+}
+
+// verifyBidSignatureHandler lets anyone check the signature of a bid
+// version, without a username.
+func (br *bidRouter) verifyBidSignatureHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+		version, err := strconv.Atoi(r.PathValue("version"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid version")
+			return
+		}
+
+		verification, err := br.bidService.VerifyBidSignature(r.Context(), bID, version)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound), errors.Is(err, service.ErrBidVersionNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrBidSealed):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to verify signature: "+err.Error())
+			}
+			return
+		}
+
+		response := ResponseBidSignature{
+			BidID:   verification.BidID,
+			Version: verification.Version,
+			Signed:  verification.Signed,
+			Valid:   verification.Valid,
+			Reason:  verification.Reason,
+		}
+		if verification.Signed {
+			response.KeyID = &verification.KeyID
+			response.Algorithm = verification.Algorithm
+			response.PublicKey = verification.PublicKey
+			response.Fingerprint = verification.Fingerprint
+			response.KeyRevokedAt = verification.KeyRevokedAt
+			response.SignerID = &verification.SignerID
+			response.SignerUsername = verification.SignerUsername
+			response.Document = string(verification.Document)
+			response.Signature = verification.Signature
+			response.SignedAt = &verification.SignedAt
 		}
 		respondWithJSON(w, http.StatusOK, response)
 	}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type conflictRouter struct {
+	conflictService service.Conflict
+	employeeService service.Employee
+}
+
+// newConflictRouter serves the relationships employees declare for the
+// conflict-of-interest checks.
+func newConflictRouter(conflictService service.Conflict, services *service.Services) http.Handler {
+	r := &conflictRouter{
+		conflictService: conflictService,
+		employeeService: services.Employee,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("POST /declarations", r.declareRelationshipHandler())
+	mux.Handle("GET /declarations", r.getDeclarationsHandler())
+	mux.Handle("DELETE /declarations/{declarationId}", r.deleteDeclarationHandler())
+
+	return http.StripPrefix("/api/conflicts", mux)
+}
+
+type ResponseConflictDeclaration struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Relationship   string    `json:"relationship"`
+	Description    string    `json:"description,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type ResponseConflictFlag struct {
+	ID             uuid.UUID  `json:"id"`
+	BidID          uuid.UUID  `json:"bidId"`
+	EmployeeID     uuid.UUID  `json:"employeeId"`
+	OrganizationID *uuid.UUID `json:"organizationId,omitempty"`
+	Stage          string     `json:"stage"`
+	Rule           string     `json:"rule"`
+	Detail         string     `json:"detail"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func newResponseConflictDeclaration(d *service.ConflictDeclarationOutput) ResponseConflictDeclaration {
+	return ResponseConflictDeclaration{
+		ID:             d.ID,
+		OrganizationID: d.OrganizationID,
+		Relationship:   d.Relationship,
+		Description:    d.Description,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func (cr *conflictRouter) declareRelationshipHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.ConflictDeclaration](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		declaration, err := cr.conflictService.DeclareRelationship(r.Context(), &service.DeclareRelationshipInput{
+			EmployeeID:     user.ID,
+			OrganizationID: data.OrganizationID,
+			Relationship:   data.Relationship,
+			Description:    data.Description,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrOrganizationNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrDeclarationAlreadyExists):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to declare relationship: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseConflictDeclaration(declaration))
+	}
+}
+
+func (cr *conflictRouter) getDeclarationsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		declarations, err := cr.conflictService.GetDeclarations(r.Context(), user.ID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get declarations: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseConflictDeclaration, 0, len(declarations))
+		for _, d := range declarations {
+			response = append(response, newResponseConflictDeclaration(d))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (cr *conflictRouter) deleteDeclarationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("declarationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid declaration ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		if err = cr.conflictService.DeleteDeclaration(r.Context(), id, user.ID); err != nil {
+			if errors.Is(err, service.ErrDeclarationNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+			} else {
+				respondWithError(w, http.StatusInternalServerError, "Failed to delete declaration: "+err.Error())
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (tr *tenderRouter) getTenderConflictsHandler(cs service.Conflict) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		flags, err := cs.GetTenderConflicts(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get conflicts: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseConflictFlag, 0, len(flags))
+		for _, f := range flags {
+			response = append(response, ResponseConflictFlag{
+				ID:             f.ID,
+				BidID:          f.BidID,
+				EmployeeID:     f.EmployeeID,
+				OrganizationID: f.OrganizationID,
+				Stage:          f.Stage,
+				Rule:           f.Rule,
+				Detail:         f.Detail,
+				CreatedAt:      f.CreatedAt,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
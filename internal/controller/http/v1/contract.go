@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type contractRouter struct {
+	contractService service.Contract
+	employeeService service.Employee
+}
+
+func newContractRouter(contractService service.Contract, services *service.Services) http.Handler {
+	r := &contractRouter{
+		contractService: contractService,
+		employeeService: services.Employee,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /my", r.getUserContractsHandler())
+	mux.Handle("GET /{contractId}", r.getContractHandler())
+	mux.Handle("POST /{contractId}/milestones", r.addMilestoneHandler())
+	mux.Handle("PUT /{contractId}/milestones/{milestoneId}/confirm", r.confirmMilestoneHandler())
+
+	return http.StripPrefix("/api/contracts", mux)
+}
+
+type ResponseContract struct {
+	ID                     uuid.UUID           `json:"id"`
+	TenderID               uuid.UUID           `json:"tenderId"`
+	BidID                  uuid.UUID           `json:"bidId"`
+	LotID                  *uuid.UUID          `json:"lotId,omitempty"`
+	BidVersion             int                 `json:"bidVersion"`
+	BuyerOrganizationID    uuid.UUID           `json:"buyerOrganizationId"`
+	SupplierOrganizationID *uuid.UUID          `json:"supplierOrganizationId,omitempty"`
+	SupplierID             uuid.UUID           `json:"supplierId"`
+	Price                  *string             `json:"price,omitempty"`
+	Currency               *string             `json:"currency,omitempty"`
+	Status                 string              `json:"status"`
+	CreatedAt              time.Time           `json:"createdAt"`
+	CompletedAt            *time.Time          `json:"completedAt,omitempty"`
+	Milestones             []ResponseMilestone `json:"milestones"`
+}
+
+type ResponseMilestone struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Description         string     `json:"description"`
+	DueDate             time.Time  `json:"dueDate"`
+	SupplierConfirmedAt *time.Time `json:"supplierConfirmedAt,omitempty"`
+	BuyerConfirmedAt    *time.Time `json:"buyerConfirmedAt,omitempty"`
+	CompletedAt         *time.Time `json:"completedAt,omitempty"`
+	Overdue             bool       `json:"overdue"`
+	CreatedAt           time.Time  `json:"createdAt"`
+}
+
+func newResponseContract(c *service.ContractOutput) ResponseContract {
+	response := ResponseContract{
+		ID:                     c.ID,
+		TenderID:               c.TenderID,
+		BidID:                  c.BidID,
+		LotID:                  c.LotID,
+		BidVersion:             c.BidVersion,
+		BuyerOrganizationID:    c.BuyerOrganizationID,
+		SupplierOrganizationID: c.SupplierOrganizationID,
+		SupplierID:             c.SupplierID,
+		Price:                  c.Price,
+		Currency:               c.Currency,
+		Status:                 c.Status,
+		CreatedAt:              c.CreatedAt,
+		CompletedAt:            c.CompletedAt,
+		Milestones:             make([]ResponseMilestone, 0, len(c.Milestones)),
+	}
+	for _, m := range c.Milestones {
+		response.Milestones = append(response.Milestones, newResponseMilestone(m))
+	}
+	return response
+}
+
+func newResponseMilestone(m *service.MilestoneOutput) ResponseMilestone {
+	return ResponseMilestone{
+		ID:                  m.ID,
+		Name:                m.Name,
+		Description:         m.Description,
+		DueDate:             m.DueDate,
+		SupplierConfirmedAt: m.SupplierConfirmedAt,
+		BuyerConfirmedAt:    m.BuyerConfirmedAt,
+		CompletedAt:         m.CompletedAt,
+		Overdue:             m.Overdue,
+		CreatedAt:           m.CreatedAt,
+	}
+}
+
+func respondWithContractError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrContractNotFound), errors.Is(err, service.ErrMilestoneNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotContractParty):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrContractCompleted), errors.Is(err, service.ErrMilestoneConfirmed):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (cr *contractRouter) getUserContractsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		limit := 5
+		offset := 0
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if l, err := strconv.Atoi(limitParam); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+		if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+			if o, err := strconv.Atoi(offsetParam); err == nil && o >= 0 {
+				offset = o
+			}
+		}
+
+		contracts, err := cr.contractService.GetUserContracts(r.Context(), &service.GetContractsInput{
+			EmployeeID:  user.ID,
+			OverdueOnly: r.URL.Query().Get("overdue") == "true",
+			Limit:       limit,
+			Offset:      offset,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get contracts: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseContract, 0, len(contracts))
+		for _, c := range contracts {
+			response = append(response, newResponseContract(c))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (cr *contractRouter) getContractHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cID, err := uuid.Parse(r.PathValue("contractId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid contract ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		contract, err := cr.contractService.GetContract(r.Context(), cID, user.ID)
+		if err != nil {
+			respondWithContractError(w, err, "Failed to get contract: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseContract(contract))
+	}
+}
+
+func (cr *contractRouter) addMilestoneHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cID, err := uuid.Parse(r.PathValue("contractId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid contract ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.Milestone](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		milestone, err := cr.contractService.AddMilestone(r.Context(), &service.AddMilestoneInput{
+			ContractID:  cID,
+			EmployeeID:  user.ID,
+			Name:        data.Name,
+			Description: data.Description,
+			DueDate:     *data.DueDate,
+		})
+		if err != nil {
+			respondWithContractError(w, err, "Failed to add milestone: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseMilestone(milestone))
+	}
+}
+
+func (cr *contractRouter) confirmMilestoneHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cID, err := uuid.Parse(r.PathValue("contractId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid contract ID format: "+err.Error())
+			return
+		}
+		mID, err := uuid.Parse(r.PathValue("milestoneId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid milestone ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, cr.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.MilestoneConfirmation](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		milestone, err := cr.contractService.ConfirmMilestone(r.Context(), &service.ConfirmMilestoneInput{
+			ContractID:  cID,
+			MilestoneID: mID,
+			EmployeeID:  user.ID,
+			Side:        data.Side,
+		})
+		if err != nil {
+			respondWithContractError(w, err, "Failed to confirm milestone: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseMilestone(milestone))
+	}
+}
@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type debarmentRouter struct {
+	debarmentService service.Debarment
+	employeeService  service.Employee
+}
+
+// newDebarmentRouter serves the debarment registry. It is managed by the
+// administrators only.
+func newDebarmentRouter(debarmentService service.Debarment, services *service.Services) http.Handler {
+	r := &debarmentRouter{
+		debarmentService: debarmentService,
+		employeeService:  services.Employee,
+	}
+
+	mux := http.NewServeMux()
+
+	adminMiddleware := adminMiddleware(services)
+
+	mux.Handle("GET /", adminMiddleware(http.HandlerFunc(r.getDebarmentsHandler())))
+	mux.Handle("POST /new", adminMiddleware(http.HandlerFunc(r.createDebarmentHandler())))
+	mux.Handle("GET /blocked", adminMiddleware(http.HandlerFunc(r.getDebarmentBlocksHandler())))
+	mux.Handle("PATCH /{debarmentId}/edit", adminMiddleware(http.HandlerFunc(r.updateDebarmentHandler())))
+	mux.Handle("DELETE /{debarmentId}", adminMiddleware(http.HandlerFunc(r.liftDebarmentHandler())))
+
+	return http.StripPrefix("/api/debarments", mux)
+}
+
+type ResponseDebarment struct {
+	ID                  uuid.UUID  `json:"id"`
+	OrganizationID      *uuid.UUID `json:"organizationId,omitempty"`
+	EmployeeID          *uuid.UUID `json:"employeeId,omitempty"`
+	BuyerOrganizationID *uuid.UUID `json:"buyerOrganizationId,omitempty"`
+	Reason              string     `json:"reason"`
+	ExpiresAt           *time.Time `json:"expiresAt,omitempty"`
+	CreatedBy           uuid.UUID  `json:"createdBy"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	LiftedAt            *time.Time `json:"liftedAt,omitempty"`
+	Active              bool       `json:"active"`
+}
+
+type ResponseDebarmentBlock struct {
+	ID          uuid.UUID `json:"id"`
+	DebarmentID uuid.UUID `json:"debarmentId"`
+	TenderID    uuid.UUID `json:"tenderId"`
+	EmployeeID  uuid.UUID `json:"employeeId"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+}
+
+func newResponseDebarment(d *service.DebarmentOutput) ResponseDebarment {
+	return ResponseDebarment{
+		ID:                  d.ID,
+		OrganizationID:      d.OrganizationID,
+		EmployeeID:          d.EmployeeID,
+		BuyerOrganizationID: d.BuyerOrganizationID,
+		Reason:              d.Reason,
+		ExpiresAt:           d.ExpiresAt,
+		CreatedBy:           d.CreatedBy,
+		CreatedAt:           d.CreatedAt,
+		LiftedAt:            d.LiftedAt,
+		Active:              d.Active,
+	}
+}
+
+func respondWithDebarmentError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrDebarmentNotFound), errors.Is(err, service.ErrDebarmentSubjectNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrDebarmentLifted):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+// pagination reads the limit and offset query parameters.
+func pagination(r *http.Request) (int, int) {
+	limit := 5
+	offset := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if l, err := strconv.Atoi(limitParam); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+		if o, err := strconv.Atoi(offsetParam); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return limit, offset
+}
+
+func (dr *debarmentRouter) getDebarmentsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := pagination(r)
+
+		debarments, err := dr.debarmentService.GetDebarments(r.Context(), &service.GetDebarmentsInput{
+			ActiveOnly: r.URL.Query().Get("active") == "true",
+			Limit:      limit,
+			Offset:     offset,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get debarments: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseDebarment, 0, len(debarments))
+		for _, d := range debarments {
+			response = append(response, newResponseDebarment(d))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (dr *debarmentRouter) createDebarmentHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestUser(w, r, dr.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.Debarment](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		debarment, err := dr.debarmentService.CreateDebarment(r.Context(), &service.CreateDebarmentInput{
+			OrganizationID:      data.OrganizationID,
+			EmployeeID:          data.EmployeeID,
+			BuyerOrganizationID: data.BuyerOrganizationID,
+			Reason:              data.Reason,
+			ExpiresAt:           data.ExpiresAt,
+			CreatedBy:           user.ID,
+		})
+		if err != nil {
+			respondWithDebarmentError(w, err, "Failed to create debarment: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseDebarment(debarment))
+	}
+}
+
+func (dr *debarmentRouter) updateDebarmentHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("debarmentId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid debarment ID format: "+err.Error())
+			return
+		}
+
+		data, problems, err := decodeValid[model.DebarmentUpdate](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		debarment, err := dr.debarmentService.UpdateDebarment(r.Context(), &service.UpdateDebarmentInput{
+			DebarmentID: id,
+			Reason:      data.Reason,
+			ExpiresAt:   data.ExpiresAt,
+		})
+		if err != nil {
+			respondWithDebarmentError(w, err, "Failed to update debarment: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseDebarment(debarment))
+	}
+}
+
+func (dr *debarmentRouter) liftDebarmentHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("debarmentId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid debarment ID format: "+err.Error())
+			return
+		}
+
+		debarment, err := dr.debarmentService.LiftDebarment(r.Context(), id)
+		if err != nil {
+			respondWithDebarmentError(w, err, "Failed to lift debarment: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseDebarment(debarment))
+	}
+}
+
+func (dr *debarmentRouter) getDebarmentBlocksHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := pagination(r)
+
+		blocks, err := dr.debarmentService.GetDebarmentBlocks(r.Context(), limit, offset)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get blocked bids: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseDebarmentBlock, 0, len(blocks))
+		for _, b := range blocks {
+			response = append(response, ResponseDebarmentBlock{
+				ID:          b.ID,
+				DebarmentID: b.DebarmentID,
+				TenderID:    b.TenderID,
+				EmployeeID:  b.EmployeeID,
+				AttemptedAt: b.AttemptedAt,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
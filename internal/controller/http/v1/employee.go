@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type employeeRouter struct {
+	employeeService service.Employee
+}
+
+// newEmployeeRouter serves the public keys employees sign their bids with.
+func newEmployeeRouter(employeeService service.Employee) http.Handler {
+	r := &employeeRouter{
+		employeeService: employeeService,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("POST /keys", r.registerKeyHandler())
+	mux.Handle("GET /keys", r.getKeysHandler())
+	mux.Handle("DELETE /keys/{keyId}", r.revokeKeyHandler())
+
+	return http.StripPrefix("/api/employees", mux)
+}
+
+// ResponseEmployeeKey holds the public key in its DER encoded PKIX form,
+// base64 encoded.
+type ResponseEmployeeKey struct {
+	ID          uuid.UUID  `json:"id"`
+	Algorithm   string     `json:"algorithm"`
+	PublicKey   []byte     `json:"publicKey"`
+	Fingerprint string     `json:"fingerprint"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+}
+
+func newResponseEmployeeKey(k *service.EmployeeKeyOutput) ResponseEmployeeKey {
+	return ResponseEmployeeKey{
+		ID:          k.ID,
+		Algorithm:   k.Algorithm,
+		PublicKey:   k.PublicKey,
+		Fingerprint: k.Fingerprint,
+		CreatedAt:   k.CreatedAt,
+		RevokedAt:   k.RevokedAt,
+	}
+}
+
+func (er *employeeRouter) registerKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestUser(w, r, er.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.EmployeeKey](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+		publicKey, _ := data.Bytes()
+
+		key, err := er.employeeService.RegisterKey(r.Context(), &service.RegisterKeyInput{
+			EmployeeID: user.ID,
+			PublicKey:  publicKey,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidPublicKey):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			case errors.Is(err, service.ErrKeyAlreadyExists):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to register key: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseEmployeeKey(key))
+	}
+}
+
+func (er *employeeRouter) getKeysHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := requestUser(w, r, er.employeeService)
+		if !ok {
+			return
+		}
+
+		keys, err := er.employeeService.GetKeys(r.Context(), user.ID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get keys: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseEmployeeKey, 0, len(keys))
+		for _, k := range keys {
+			response = append(response, newResponseEmployeeKey(k))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+// revokeKeyHandler revokes the key rather than deleting it, so that the bids
+// it signed can still be verified.
+func (er *employeeRouter) revokeKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("keyId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid key ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, er.employeeService)
+		if !ok {
+			return
+		}
+
+		key, err := er.employeeService.RevokeKey(r.Context(), user.ID, id)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrKeyNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrKeyRevoked):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to revoke key: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseEmployeeKey(key))
+	}
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseCriterion struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Weight string    `json:"weight"`
+}
+
+func newResponseCriteria(criteria []*service.CriterionOutput) []ResponseCriterion {
+	if len(criteria) == 0 {
+		return nil
+	}
+
+	response := make([]ResponseCriterion, 0, len(criteria))
+	for _, c := range criteria {
+		response = append(response, ResponseCriterion{
+			ID:     c.ID,
+			Name:   c.Name,
+			Weight: c.Weight,
+		})
+	}
+	return response
+}
+
+type ResponseCriterionScore struct {
+	CriterionID   uuid.UUID `json:"criterionId"`
+	Name          string    `json:"name"`
+	Weight        string    `json:"weight"`
+	AverageScore  *string   `json:"averageScore"`
+	ReviewerCount int       `json:"reviewerCount"`
+}
+
+type ResponseBidRanking struct {
+	Rank       int                      `json:"rank,omitempty"`
+	Bid        ResponseBid              `json:"bid"`
+	TotalScore *string                  `json:"totalScore"`
+	Complete   bool                     `json:"complete"`
+	Criteria   []ResponseCriterionScore `json:"criteria"`
+}
+
+func newResponseBidRanking(ranking *service.BidRankingOutput) ResponseBidRanking {
+	response := ResponseBidRanking{
+		Rank:       ranking.Rank,
+		Bid:        newResponseBid(ranking.Bid),
+		TotalScore: ranking.TotalScore,
+		Complete:   ranking.Complete,
+		Criteria:   make([]ResponseCriterionScore, 0, len(ranking.Criteria)),
+	}
+	for _, c := range ranking.Criteria {
+		response.Criteria = append(response.Criteria, ResponseCriterionScore{
+			CriterionID:   c.CriterionID,
+			Name:          c.Name,
+			Weight:        c.Weight,
+			AverageScore:  c.AverageScore,
+			ReviewerCount: c.ReviewerCount,
+		})
+	}
+	return response
+}
+
+type ResponseTenderRanking struct {
+	TenderID uuid.UUID            `json:"tenderId"`
+	Complete bool                 `json:"complete"`
+	Bids     []ResponseBidRanking `json:"bids"`
+}
+
+func (br *bidRouter) scoreBidHandler(es service.Employee, evs service.Evaluation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		data, problems, err := decodeValid[model.BidScores](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
+			return
+		}
+
+		reviewer, err := es.GetByUsername(r.Context(), r.URL.Query().Get("username"))
+		if err != nil {
+			respondWithError(w, http.StatusUnauthorized, "Failed to retrieve user "+err.Error())
+			return
+		}
+
+		scores := make([]service.BidScoreInput, 0, len(data.Scores))
+		for _, s := range data.Scores {
+			scores = append(scores, service.BidScoreInput{CriterionID: s.CriterionID, Score: s.Score.String()})
+		}
+
+		ranking, err := evs.ScoreBid(r.Context(), &service.ScoreBidInput{
+			BidID:      bID,
+			ReviewerID: reviewer.ID,
+			Scores:     scores,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrBidNotFound), errors.Is(err, service.ErrCriterionNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrConflictOfInterest):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrBidSealed):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to score bid "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseBidRanking(ranking))
+	}
+}
+
+func (tr *tenderRouter) getCriteriaHandler(evs service.Evaluation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		criteria, err := evs.GetCriteria(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get criteria: "+err.Error())
+			return
+		}
+
+		response := newResponseCriteria(criteria)
+		if response == nil {
+			response = []ResponseCriterion{}
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (tr *tenderRouter) getRankingHandler(evs service.Evaluation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		ranking, err := evs.GetTenderRanking(r.Context(), tID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
+				respondWithError(w, http.StatusNotFound, "Tender not found: "+err.Error())
+			case errors.Is(err, service.ErrBidSealed):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to get ranking: "+err.Error())
+			}
+			return
+		}
+
+		response := ResponseTenderRanking{
+			TenderID: ranking.TenderID,
+			Complete: ranking.Complete,
+			Bids:     make([]ResponseBidRanking, 0, len(ranking.Bids)),
+		}
+		for _, b := range ranking.Bids {
+			response.Bids = append(response.Bids, newResponseBidRanking(b))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseInvitation struct {
+	ID             uuid.UUID  `json:"id"`
+	TenderID       uuid.UUID  `json:"tenderId"`
+	OrganizationID *uuid.UUID `json:"organizationId,omitempty"`
+	EmployeeID     *uuid.UUID `json:"employeeId,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func newResponseInvitations(invitations []*service.InvitationOutput) []ResponseInvitation {
+	response := make([]ResponseInvitation, 0, len(invitations))
+	for _, i := range invitations {
+		response = append(response, ResponseInvitation{
+			ID:             i.ID,
+			TenderID:       i.TenderID,
+			OrganizationID: i.OrganizationID,
+			EmployeeID:     i.EmployeeID,
+			CreatedAt:      i.CreatedAt,
+		})
+	}
+	return response
+}
+
+func (tr *tenderRouter) createInvitationsHandler(is service.Invitation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		data, problems, err := decodeValid[model.Invitations](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		invitations, err := is.CreateInvitations(r.Context(), &service.CreateInvitationsInput{
+			TenderID:        tID,
+			OrganizationIDs: data.OrganizationIDs,
+			EmployeeIDs:     data.EmployeeIDs,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound), errors.Is(err, service.ErrInviteeNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrTenderNotInvitationOnly), errors.Is(err, service.ErrInvitationAlreadyExists):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to invite: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseInvitations(invitations))
+	}
+}
+
+func (tr *tenderRouter) getInvitationsHandler(is service.Invitation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		invitations, err := is.GetInvitations(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get invitations: "+err.Error())
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseInvitations(invitations))
+	}
+}
+
+func (tr *tenderRouter) deleteInvitationHandler(is service.Invitation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+		iID, err := uuid.Parse(r.PathValue("invitationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid invitation ID format: "+err.Error())
+			return
+		}
+
+		if err = is.DeleteInvitation(r.Context(), tID, iID); err != nil {
+			if errors.Is(err, service.ErrInvitationNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+			} else {
+				respondWithError(w, http.StatusInternalServerError, "Failed to delete invitation: "+err.Error())
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
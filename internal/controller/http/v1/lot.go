@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseLot struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Budget      *string    `json:"budget,omitempty"`
+	Status      string     `json:"status"`
+	WinnerBidID *uuid.UUID `json:"winnerBidId,omitempty"`
+	DecidedAt   *time.Time `json:"decidedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func newResponseLot(lot *service.LotOutput) ResponseLot {
+	return ResponseLot{
+		ID:          lot.ID,
+		Name:        lot.Name,
+		Description: lot.Description,
+		Budget:      lot.Budget,
+		Status:      lot.Status,
+		WinnerBidID: lot.WinnerBidID,
+		DecidedAt:   lot.DecidedAt,
+		CreatedAt:   lot.CreatedAt,
+	}
+}
+
+func newResponseLots(lots []*service.LotOutput) []ResponseLot {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	response := make([]ResponseLot, 0, len(lots))
+	for _, lot := range lots {
+		response = append(response, newResponseLot(lot))
+	}
+	return response
+}
+
+func (tr *tenderRouter) getLotsHandler(ls service.Lot) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		lots, err := ls.GetLots(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get lots: "+err.Error())
+			return
+		}
+
+		response := newResponseLots(lots)
+		if response == nil {
+			response = []ResponseLot{}
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (tr *tenderRouter) decideLotHandler(es service.Employee, ls service.Lot) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		lID, err := uuid.Parse(r.PathValue("lotId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid lot ID format: "+err.Error())
+			return
+		}
+
+		decision := r.URL.Query().Get("decision")
+		if decision != entity.LotStatusAwarded && decision != entity.LotStatusCanceled {
+			respondWithError(w, http.StatusBadRequest, "Invalid decision value. Available values: 'Awarded', 'Canceled'")
+			return
+		}
+
+		var bID uuid.UUID
+		if decision == entity.LotStatusAwarded {
+			bID, err = uuid.Parse(r.URL.Query().Get("bidId"))
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+				return
+			}
+		}
+
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		lot, err := ls.DecideLot(r.Context(), &service.DecideLotInput{
+			TenderID:  tID,
+			LotID:     lID,
+			BidID:     bID,
+			DeciderID: user.ID,
+			Decision:  decision,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrLotNotFound), errors.Is(err, service.ErrBidNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrConflictOfInterest):
+				respondWithError(w, http.StatusForbidden, err.Error())
+			case errors.Is(err, service.ErrLotAlreadyDecided), errors.Is(err, service.ErrBidSealed),
+				errors.Is(err, service.ErrScoringIncomplete), errors.Is(err, service.ErrBidNeedsAcknowledgement),
+				errors.Is(err, service.ErrBidCanceled), errors.Is(err, service.ErrBidWithdrawn),
+				errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrTenderNotPublished):
+				respondWithError(w, http.StatusConflict, err.Error())
+			case errors.Is(err, service.ErrBidNotForLot):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to decide lot: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseLot(lot))
+	}
+}
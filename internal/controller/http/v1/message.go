@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseMessage struct {
+	ID         uuid.UUID  `json:"id"`
+	BidID      uuid.UUID  `json:"bidId"`
+	SenderID   uuid.UUID  `json:"senderId"`
+	FromAuthor bool       `json:"fromAuthor"`
+	Message    string     `json:"message"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReadAt     *time.Time `json:"readAt,omitempty"`
+}
+
+func newResponseMessage(m *service.MessageOutput) ResponseMessage {
+	return ResponseMessage{
+		ID:         m.ID,
+		BidID:      m.BidID,
+		SenderID:   m.SenderID,
+		FromAuthor: m.FromAuthor,
+		Message:    m.Body,
+		CreatedAt:  m.CreatedAt,
+		ReadAt:     m.ReadAt,
+	}
+}
+
+func respondWithMessageError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrBidNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotInThread):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (br *bidRouter) sendMessageHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.Message](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		message, err := services.Message.SendMessage(r.Context(), &service.SendMessageInput{
+			BidID:    bID,
+			SenderID: user.ID,
+			Body:     data.Message,
+		})
+		if err != nil {
+			respondWithMessageError(w, err, "Failed to send message: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseMessage(message))
+	}
+}
+
+func (br *bidRouter) getMessagesHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		limit := 5
+		offset := 0
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if l, err := strconv.Atoi(limitParam); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+		if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+			if o, err := strconv.Atoi(offsetParam); err == nil && o >= 0 {
+				offset = o
+			}
+		}
+
+		messages, err := services.Message.GetMessages(r.Context(), &service.GetMessagesInput{
+			BidID:    bID,
+			ReaderID: user.ID,
+			Limit:    limit,
+			Offset:   offset,
+		})
+		if err != nil {
+			respondWithMessageError(w, err, "Failed to get messages: ")
+			return
+		}
+
+		response := make([]ResponseMessage, 0, len(messages))
+		for _, m := range messages {
+			response = append(response, newResponseMessage(m))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 
+	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/model"
 	"git.codenrock.com/tender/internal/service"
 	"github.com/google/uuid"
@@ -53,7 +58,7 @@ func createTenderMiddleware(services *service.Services) func(http.Handler) http.
 				return
 			}
 
-			h.ServeHTTP(w, r)
+			h.ServeHTTP(w, r.WithContext(service.WithActor(r.Context(), employee.Username)))
 		})
 	}
 }
@@ -78,7 +83,7 @@ func getTenderStatusMiddleware(services *service.Services) func(http.Handler) ht
 				return
 			}
 
-			if tender.Status == "PUBLISHED" {
+			if tender.Status == entity.TenderStatusPublished && tender.AccessMode != entity.TenderAccessInvitationOnly {
 				h.ServeHTTP(w, r)
 				return
 			}
@@ -94,6 +99,22 @@ func getTenderStatusMiddleware(services *service.Services) func(http.Handler) ht
 				return
 			}
 
+			// Invited bidders see a published invitation-only tender, nobody else does.
+			if tender.Status == entity.TenderStatusPublished {
+				allowed, err := services.Invitation.CanAccessTender(r.Context(), tender.ID, user.ID)
+				if err != nil {
+					respondWithError(w, http.StatusInternalServerError, "Failed to check invitation: "+err.Error())
+					return
+				}
+				if !allowed {
+					respondWithError(w, http.StatusForbidden, "Tender is open to invited bidders only")
+					return
+				}
+
+				h.ServeHTTP(w, r)
+				return
+			}
+
 			_, err = services.Organization.GetOrganizationResponsible(r.Context(), &service.OrganizationResponsibleInput{
 				OrganizationID: tender.OrganizationID,
 				EmployeeID:     user.ID,
@@ -221,7 +242,7 @@ func createBidMiddleware(services *service.Services) func(http.Handler) http.Han
 
 			r.Body = io.NopCloser(bodyBuf)
 
-			tender, err := services.Tender.GetTenderByID(r.Context(), data.TenderID)
+			_, err := services.Tender.GetTenderByID(r.Context(), data.TenderID)
 			if err != nil {
 				if errors.Is(err, service.ErrTenderNotFound) {
 					respondWithError(w, http.StatusNotFound, err.Error())
@@ -231,6 +252,8 @@ func createBidMiddleware(services *service.Services) func(http.Handler) http.Han
 				return
 			}
 
+			// The author's ties to the buyer organization are screened by the
+			// conflict-of-interest checks of the bid service.
 			author, err := services.Employee.GetByID(r.Context(), data.AuthorID)
 			if err != nil {
 				if errors.Is(err, service.ErrEmployeeNotFound) {
@@ -241,22 +264,7 @@ func createBidMiddleware(services *service.Services) func(http.Handler) http.Han
 				return
 			}
 
-			orgResponsible, err := services.Organization.GetOrganizationResponsible(r.Context(), &service.OrganizationResponsibleInput{
-				OrganizationID: tender.OrganizationID,
-				EmployeeID:     author.ID,
-			})
-			if err != nil || orgResponsible.EmployeeID != author.ID {
-				respondWithError(w, http.StatusForbidden, "User is not responsible for this organization's tender")
-				return
-			}
-
-			existingBid, err := services.Bid.GetBidByTenderAndAuthor(r.Context(), tender.ID, author.ID)
-			if err == nil && existingBid != nil {
-				respondWithError(w, http.StatusConflict, "A bid for this tender already exists from this author")
-				return
-			}
-
-			h.ServeHTTP(w, r)
+			h.ServeHTTP(w, r.WithContext(service.WithActor(r.Context(), author.Username)))
 		})
 	}
 }
@@ -368,3 +376,188 @@ func accessMiddleware(services *service.Services) func(http.Handler) http.Handle
 		})
 	}
 }
+
+func tenderResponsibleMiddleware(services *service.Services) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tID, err := uuid.Parse(r.PathValue("tenderId"))
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+				return
+			}
+
+			username := r.URL.Query().Get("username")
+			if username == "" {
+				respondWithError(w, http.StatusBadRequest, "Username is required")
+				return
+			}
+
+			user, err := services.Employee.GetByUsername(r.Context(), username)
+			if err != nil {
+				if errors.Is(err, service.ErrEmployeeNotFound) {
+					respondWithError(w, http.StatusUnauthorized, "User does not exist")
+				} else {
+					respondWithError(w, http.StatusInternalServerError, "Failed to retrieve user: "+err.Error())
+				}
+				return
+			}
+
+			isResponsible, err := services.Organization.IsResponsibleForTender(r.Context(), user.ID, tID)
+			if err != nil {
+				respondWithError(w, http.StatusInternalServerError, "Failed to check responsibility: "+err.Error())
+				return
+			}
+
+			if !isResponsible {
+				respondWithError(w, http.StatusForbidden, "User is not responsible for this tender")
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+func adminMiddleware(services *service.Services) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username := r.URL.Query().Get("username")
+			if username == "" {
+				respondWithError(w, http.StatusBadRequest, "Username is required")
+				return
+			}
+
+			if _, err := services.Employee.GetByUsername(r.Context(), username); err != nil {
+				if errors.Is(err, service.ErrEmployeeNotFound) {
+					respondWithError(w, http.StatusUnauthorized, "User does not exist")
+				} else {
+					respondWithError(w, http.StatusInternalServerError, "Failed to retrieve user: "+err.Error())
+				}
+				return
+			}
+
+			if !services.Employee.IsAdmin(username) {
+				respondWithError(w, http.StatusForbidden, "User is not an administrator")
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxRequestID and maxClientIP are the lengths of the request_id and ip
+// columns of the audit trail; longer client-supplied values are cut.
+const (
+	maxRequestID = 100
+	maxClientIP  = 64
+)
+
+// TrustedProxies lists the networks of the proxies in front of the service.
+// Only the forwarding headers of requests coming from them are believed.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies reads a list of IP addresses and CIDR networks.
+func ParseTrustedProxies(entries []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(entries))
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("v1 - ParseTrustedProxies: %w", err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("v1 - ParseTrustedProxies: %w", err)
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// trusts reports whether ip is the address of a trusted proxy.
+func (tp TrustedProxies) trusts(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range tp {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestInfoMiddleware attaches the request ID, the client IP and the acting
+// username to the context, so that the mutations of the request are audited
+// with them. The request ID is taken from X-Request-ID when the client sends
+// one and is echoed back.
+func RequestInfoMiddleware(proxies TrustedProxies) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			requestID = truncate(requestID, maxRequestID)
+			w.Header().Set("X-Request-ID", requestID)
+
+			ctx := service.WithRequestInfo(r.Context(), service.RequestInfo{
+				ID:    requestID,
+				IP:    truncate(clientIP(r, proxies), maxClientIP),
+				Actor: r.URL.Query().Get("username"),
+			})
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP returns the address the request comes from. The forwarding
+// headers are only read when that address is a trusted proxy: the client is
+// then the right-most hop of X-Forwarded-For that is not a trusted proxy,
+// since every hop on its left may have been written by the client itself.
+func clientIP(r *http.Request, proxies TrustedProxies) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !proxies.trusts(remote) {
+		return remote
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !proxies.trusts(hops[i]) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+	return remote
+}
+
+// truncate cuts s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return strings.ToValidUTF8(s[:n], "")
+	}
+	return s
+}
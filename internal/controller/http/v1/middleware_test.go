@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:5000",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted peer cannot forward",
+			remoteAddr: "203.0.113.7:5000",
+			forwarded:  []string{"198.51.100.1"},
+			realIP:     "198.51.100.2",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed hops left of the client are ignored",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"1.2.3.4, 198.51.100.1, 192.168.1.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "hops split across headers",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"1.2.3.4", "198.51.100.1", "10.1.1.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "only trusted hops",
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  []string{"10.0.0.9, 10.0.0.8"},
+			want:       "10.0.0.9",
+		},
+		{
+			name:       "real ip from trusted proxy",
+			remoteAddr: "192.168.1.1:5000",
+			realIP:     "198.51.100.3",
+			want:       "198.51.100.3",
+		},
+		{
+			name:       "ipv4 mapped proxy address",
+			remoteAddr: "[::ffff:10.0.0.2]:5000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/ping", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			if got := clientIP(r, proxies); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesInvalid(t *testing.T) {
+	for _, entry := range []string{"10.0.0.0/33", "proxy.local", ""} {
+		if _, err := ParseTrustedProxies([]string{entry}); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) succeeded, want an error", entry)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseNegotiationRound struct {
+	ID                 uuid.UUID  `json:"id"`
+	BidID              uuid.UUID  `json:"bidId"`
+	Round              int        `json:"round"`
+	BidVersion         int        `json:"bidVersion"`
+	ProposedBy         uuid.UUID  `json:"proposedBy"`
+	TargetPrice        *string    `json:"targetPrice,omitempty"`
+	Comment            string     `json:"comment"`
+	Status             string     `json:"status"`
+	ResponseComment    *string    `json:"responseComment,omitempty"`
+	ResponseBidVersion *int       `json:"responseBidVersion,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	RespondedAt        *time.Time `json:"respondedAt,omitempty"`
+}
+
+func newResponseNegotiationRound(n *service.NegotiationRoundOutput) ResponseNegotiationRound {
+	return ResponseNegotiationRound{
+		ID:                 n.ID,
+		BidID:              n.BidID,
+		Round:              n.Round,
+		BidVersion:         n.BidVersion,
+		ProposedBy:         n.ProposedBy,
+		TargetPrice:        n.TargetPrice,
+		Comment:            n.Comment,
+		Status:             n.Status,
+		ResponseComment:    n.ResponseComment,
+		ResponseBidVersion: n.ResponseBidVersion,
+		CreatedAt:          n.CreatedAt,
+		RespondedAt:        n.RespondedAt,
+	}
+}
+
+func respondWithNegotiationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrBidNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotBidAuthor):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrBidCanceled), errors.Is(err, service.ErrBidWithdrawn), errors.Is(err, service.ErrBidSealed),
+		errors.Is(err, service.ErrNegotiationClosed), errors.Is(err, service.ErrNegotiationNotAvailable),
+		errors.Is(err, service.ErrNegotiationRoundOpen), errors.Is(err, service.ErrNoOpenNegotiationRound):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (br *bidRouter) proposeCounterOfferHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.CounterOffer](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		round, err := services.Negotiation.ProposeCounterOffer(r.Context(), &service.ProposeCounterOfferInput{
+			BidID:         bID,
+			ResponsibleID: user.ID,
+			TargetPrice:   data.TargetPrice,
+			Comment:       data.Comment,
+		})
+		if err != nil {
+			respondWithNegotiationError(w, err, "Failed to propose counter-offer: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseNegotiationRound(round))
+	}
+}
+
+func (br *bidRouter) respondCounterOfferHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.CounterOfferResponse](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		round, err := services.Negotiation.RespondCounterOffer(r.Context(), &service.RespondCounterOfferInput{
+			BidID:        bID,
+			AuthorID:     user.ID,
+			Response:     data.Response,
+			Comment:      data.Comment,
+			Name:         data.Name,
+			Description:  data.Description,
+			Price:        data.Price,
+			DeliveryDays: data.DeliveryDays,
+			ValidityDays: data.ValidityDays,
+		})
+		if err != nil {
+			respondWithNegotiationError(w, err, "Failed to respond to counter-offer: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseNegotiationRound(round))
+	}
+}
+
+func (br *bidRouter) getNegotiationRoundsHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bID, err := uuid.Parse(r.PathValue("bidId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid bid ID format")
+			return
+		}
+
+		rounds, err := services.Negotiation.GetNegotiationRounds(r.Context(), bID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get negotiation rounds: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseNegotiationRound, 0, len(rounds))
+		for _, n := range rounds {
+			response = append(response, newResponseNegotiationRound(n))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
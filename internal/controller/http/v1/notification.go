@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type notificationRouter struct {
+	notificationService service.Notification
+	employeeService     service.Employee
+}
+
+func newNotificationRouter(notificationService service.Notification, services *service.Services) http.Handler {
+	r := &notificationRouter{
+		notificationService: notificationService,
+		employeeService:     services.Employee,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /", r.getNotificationsHandler())
+	mux.Handle("PUT /{notificationId}/read", r.markNotificationReadHandler())
+
+	return http.StripPrefix("/api/notifications", mux)
+}
+
+type ResponseNotification struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type"`
+	TenderID  *uuid.UUID `json:"tenderId,omitempty"`
+	BidID     *uuid.UUID `json:"bidId,omitempty"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+}
+
+func newResponseNotification(n *service.NotificationOutput) ResponseNotification {
+	return ResponseNotification{
+		ID:        n.ID,
+		Type:      n.Type,
+		TenderID:  n.TenderID,
+		BidID:     n.BidID,
+		Message:   n.Message,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+}
+
+func (nr *notificationRouter) getNotificationsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, nr.employeeService)
+		if !ok {
+			return
+		}
+
+		limit := 5
+		offset := 0
+		if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+			if l, err := strconv.Atoi(limitParam); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+		if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+			if o, err := strconv.Atoi(offsetParam); err == nil && o >= 0 {
+				offset = o
+			}
+		}
+
+		notifications, err := nr.notificationService.GetNotifications(r.Context(), &service.GetNotificationsInput{
+			RecipientID: user.ID,
+			UnreadOnly:  r.URL.Query().Get("unread") == "true",
+			Limit:       limit,
+			Offset:      offset,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get notifications: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseNotification, 0, len(notifications))
+		for _, n := range notifications {
+			response = append(response, newResponseNotification(n))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (nr *notificationRouter) markNotificationReadHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nID, err := uuid.Parse(r.PathValue("notificationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid notification ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, nr.employeeService)
+		if !ok {
+			return
+		}
+
+		notification, err := nr.notificationService.MarkNotificationRead(r.Context(), nID, user.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrNotificationNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusInternalServerError, "Failed to mark notification as read: "+err.Error())
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseNotification(notification))
+	}
+}
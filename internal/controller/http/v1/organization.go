@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type organizationRouter struct {
+	contractService   service.Contract
+	reputationService service.Reputation
+	approvalService   service.Approval
+	employeeService   service.Employee
+	templateService   service.Template
+	tenderService     service.Tender
+	seriesService     service.Series
+}
+
+func newOrganizationRouter(services *service.Services) http.Handler {
+	r := &organizationRouter{
+		contractService:   services.Contract,
+		reputationService: services.Reputation,
+		approvalService:   services.Approval,
+		employeeService:   services.Employee,
+		templateService:   services.Template,
+		tenderService:     services.Tender,
+		seriesService:     services.Series,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /{organizationId}/supplier-rating", r.getSupplierRatingHandler())
+	mux.Handle("GET /{organizationId}/reputation", r.getReputationHandler())
+	mux.Handle("POST /{organizationId}/approval-policies", r.createApprovalPolicyHandler())
+	mux.Handle("GET /{organizationId}/approval-policies", r.getApprovalPoliciesHandler())
+	mux.Handle("DELETE /{organizationId}/approval-policies/{policyId}", r.deleteApprovalPolicyHandler())
+	mux.Handle("POST /{organizationId}/templates", r.createTemplateHandler())
+	mux.Handle("GET /{organizationId}/templates", r.getTemplatesHandler())
+	mux.Handle("DELETE /{organizationId}/templates/{templateId}", r.deleteTemplateHandler())
+	mux.Handle("POST /{organizationId}/templates/{templateId}/tenders", r.createTenderFromTemplateHandler())
+	mux.Handle("POST /{organizationId}/templates/{templateId}/series", r.createSeriesHandler())
+	mux.Handle("GET /{organizationId}/series", r.getSeriesHandler())
+	mux.Handle("PATCH /{organizationId}/series/{seriesId}", r.updateSeriesHandler())
+	mux.Handle("POST /{organizationId}/series/{seriesId}/pause", r.seriesTransitionHandler(r.seriesService.PauseSeries))
+	mux.Handle("POST /{organizationId}/series/{seriesId}/resume", r.seriesTransitionHandler(r.seriesService.ResumeSeries))
+	mux.Handle("GET /{organizationId}/series/{seriesId}/tenders", r.getSeriesTendersHandler())
+
+	return http.StripPrefix("/api/organizations", mux)
+}
+
+type ResponseSupplierRating struct {
+	OrganizationID      uuid.UUID `json:"organizationId"`
+	CompletedMilestones int       `json:"completedMilestones"`
+	OnTimeMilestones    int       `json:"onTimeMilestones"`
+	OverdueMilestones   int       `json:"overdueMilestones"`
+	OnTimeRate          *float64  `json:"onTimeRate"`
+}
+
+type ResponseReputation struct {
+	OrganizationID      uuid.UUID `json:"organizationId"`
+	Score               *float64  `json:"score"`
+	ApprovalRate        *float64  `json:"approvalRate"`
+	AverageRating       *float64  `json:"averageRating"`
+	OnTimeRate          *float64  `json:"onTimeRate"`
+	WithdrawalRate      *float64  `json:"withdrawalRate"`
+	BidsSubmitted       int       `json:"bidsSubmitted"`
+	BidsWithdrawn       int       `json:"bidsWithdrawn"`
+	BidsDecided         int       `json:"bidsDecided"`
+	BidsApproved        int       `json:"bidsApproved"`
+	Ratings             int       `json:"ratings"`
+	MilestonesCompleted int       `json:"milestonesCompleted"`
+	MilestonesOnTime    int       `json:"milestonesOnTime"`
+}
+
+func (or *organizationRouter) getSupplierRatingHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		rating, err := or.contractService.GetSupplierRating(r.Context(), oID)
+		if err != nil {
+			if errors.Is(err, service.ErrOrganizationNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusInternalServerError, "Failed to get supplier rating: "+err.Error())
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, ResponseSupplierRating{
+			OrganizationID:      rating.OrganizationID,
+			CompletedMilestones: rating.CompletedMilestones,
+			OnTimeMilestones:    rating.OnTimeMilestones,
+			OverdueMilestones:   rating.OverdueMilestones,
+			OnTimeRate:          rating.OnTimeRate,
+		})
+	}
+}
+
+func (or *organizationRouter) getReputationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		reputation, err := or.reputationService.GetOrganizationReputation(r.Context(), oID)
+		if err != nil {
+			if errors.Is(err, service.ErrOrganizationNotFound) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusInternalServerError, "Failed to get reputation: "+err.Error())
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, ResponseReputation{
+			OrganizationID:      reputation.SubjectID,
+			Score:               reputation.Score,
+			ApprovalRate:        reputation.ApprovalRate,
+			AverageRating:       reputation.AverageRating,
+			OnTimeRate:          reputation.OnTimeRate,
+			WithdrawalRate:      reputation.WithdrawalRate,
+			BidsSubmitted:       reputation.BidsSubmitted,
+			BidsWithdrawn:       reputation.BidsWithdrawn,
+			BidsDecided:         reputation.BidsDecided,
+			BidsApproved:        reputation.BidsApproved,
+			Ratings:             reputation.Ratings,
+			MilestonesCompleted: reputation.MilestonesCompleted,
+			MilestonesOnTime:    reputation.MilestonesOnTime,
+		})
+	}
+}
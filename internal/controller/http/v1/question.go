@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseQuestion struct {
+	ID            uuid.UUID  `json:"id"`
+	TenderID      uuid.UUID  `json:"tenderId"`
+	AuthorID      *uuid.UUID `json:"authorId,omitempty"`
+	Question      string     `json:"question"`
+	Answer        *string    `json:"answer,omitempty"`
+	Visibility    *string    `json:"visibility,omitempty"`
+	TenderVersion *int       `json:"tenderVersion,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	AnsweredAt    *time.Time `json:"answeredAt,omitempty"`
+}
+
+func newResponseQuestion(q *service.QuestionOutput) ResponseQuestion {
+	return ResponseQuestion{
+		ID:            q.ID,
+		TenderID:      q.TenderID,
+		AuthorID:      q.AuthorID,
+		Question:      q.Question,
+		Answer:        q.Answer,
+		Visibility:    q.Visibility,
+		TenderVersion: q.TenderVersion,
+		CreatedAt:     q.CreatedAt,
+		AnsweredAt:    q.AnsweredAt,
+	}
+}
+
+func respondWithQuestionError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrTenderNotFound), errors.Is(err, service.ErrQuestionNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrResponsibleCannotAsk), errors.Is(err, service.ErrNotInvited):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrTenderNotPublished), errors.Is(err, service.ErrSubmissionClosed),
+		errors.Is(err, service.ErrQuestionAlreadyAnswered):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (tr *tenderRouter) askQuestionHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		if r.URL.Query().Get("username") == "" {
+			respondWithError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.Question](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		question, err := services.Question.AskQuestion(r.Context(), &service.AskQuestionInput{
+			TenderID: tID,
+			AuthorID: user.ID,
+			Question: data.Question,
+		})
+		if err != nil {
+			respondWithQuestionError(w, err, "Failed to ask question: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseQuestion(question))
+	}
+}
+
+// getQuestionsHandler works without a username for published tenders; the
+// caller then only sees the public answers.
+func (tr *tenderRouter) getQuestionsHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		input := &service.GetQuestionsInput{TenderID: tID}
+		if r.URL.Query().Get("username") != "" {
+			user, ok := requestUser(w, r, services.Employee)
+			if !ok {
+				return
+			}
+			input.RequesterID = user.ID
+		}
+
+		questions, err := services.Question.GetQuestions(r.Context(), input)
+		if err != nil {
+			respondWithQuestionError(w, err, "Failed to get questions: ")
+			return
+		}
+
+		response := make([]ResponseQuestion, 0, len(questions))
+		for _, q := range questions {
+			response = append(response, newResponseQuestion(q))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (tr *tenderRouter) answerQuestionHandler(services *service.Services) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+		qID, err := uuid.Parse(r.PathValue("questionId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid question ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, services.Employee)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.QuestionAnswer](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		question, err := services.Question.AnswerQuestion(r.Context(), &service.AnswerQuestionInput{
+			TenderID:    tID,
+			QuestionID:  qID,
+			ResponderID: user.ID,
+			Answer:      data.Answer,
+			Visibility:  data.Visibility,
+		})
+		if err != nil {
+			respondWithQuestionError(w, err, "Failed to answer question: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseQuestion(question))
+	}
+}
@@ -13,8 +13,24 @@ func NewRouter(mux *http.ServeMux, services *service.Services) {
 
 	tenderRouter := newTenderRouter(services.Tender, services)
 	bidRouter := newbidRouter(services.Bid, services)
+	serviceTypeRouter := newServiceTypeRouter(services.ServiceType, services)
+	notificationRouter := newNotificationRouter(services.Notification, services)
+	contractRouter := newContractRouter(services.Contract, services)
+	organizationRouter := newOrganizationRouter(services)
+	debarmentRouter := newDebarmentRouter(services.Debarment, services)
+	conflictRouter := newConflictRouter(services.Conflict, services)
+	employeeRouter := newEmployeeRouter(services.Employee)
+	auditRouter := newAuditRouter(services.Audit, services.Chain, services)
 
 	mux.Handle("/api/tenders/", tenderRouter)
 	mux.Handle("/api/bids/", bidRouter)
+	mux.Handle("/api/service-types/", serviceTypeRouter)
+	mux.Handle("/api/notifications/", notificationRouter)
+	mux.Handle("/api/contracts/", contractRouter)
+	mux.Handle("/api/organizations/", organizationRouter)
+	mux.Handle("/api/debarments/", debarmentRouter)
+	mux.Handle("/api/conflicts/", conflictRouter)
+	mux.Handle("/api/employees/", employeeRouter)
+	mux.Handle("/api/audit/", auditRouter)
 
 }
@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseSeries struct {
+	ID             uuid.UUID         `json:"id"`
+	OrganizationID uuid.UUID         `json:"organizationId"`
+	TemplateID     uuid.UUID         `json:"templateId"`
+	Rule           string            `json:"rule"`
+	StartsAt       time.Time         `json:"startsAt"`
+	Values         map[string]string `json:"values"`
+	SubmissionDays *int              `json:"submissionDays,omitempty"`
+	AutoPublish    bool              `json:"autoPublish"`
+	Status         string            `json:"status"`
+	NextRunAt      *time.Time        `json:"nextRunAt,omitempty"`
+	LastError      *string           `json:"lastError,omitempty"`
+	CreatedBy      uuid.UUID         `json:"createdBy"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+type ResponseSeriesInstance struct {
+	TenderID    uuid.UUID `json:"tenderId"`
+	Occurrence  int       `json:"occurrence"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func newResponseSeries(s *service.SeriesOutput) ResponseSeries {
+	values := s.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+	return ResponseSeries{
+		ID:             s.ID,
+		OrganizationID: s.OrganizationID,
+		TemplateID:     s.TemplateID,
+		Rule:           s.Rule,
+		StartsAt:       s.StartsAt,
+		Values:         values,
+		SubmissionDays: s.SubmissionDays,
+		AutoPublish:    s.AutoPublish,
+		Status:         s.Status,
+		NextRunAt:      s.NextRunAt,
+		LastError:      s.LastError,
+		CreatedBy:      s.CreatedBy,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func respondWithSeriesError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrTemplateNotFound),
+		errors.Is(err, service.ErrSeriesNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotOrganizationResponsible):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrInvalidRecurrenceRule), errors.Is(err, service.ErrRecurrenceEnded),
+		errors.Is(err, service.ErrMissingPlaceholderValues), errors.Is(err, service.ErrSeriesDeadlineRequired),
+		errors.Is(err, service.ErrInvalidTender):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrSeriesNotActive), errors.Is(err, service.ErrSeriesNotPaused):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+// seriesPath parses the organization and series IDs of a series route.
+func seriesPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	oID, err := uuid.Parse(r.PathValue("organizationId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+	sID, err := uuid.Parse(r.PathValue("seriesId"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid series ID format: "+err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+	return oID, sID, true
+}
+
+// createSeriesHandler starts a recurring tender from a template. The tender
+// the template makes with the given values is validated up front, so that
+// the scheduler does not stumble on it later.
+func (or *organizationRouter) createSeriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+		tID, err := uuid.Parse(r.PathValue("templateId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid template ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.TenderSeries](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		template, err := or.templateService.RenderTemplate(r.Context(), &service.RenderTemplateInput{
+			OrganizationID: oID,
+			TemplateID:     tID,
+			EmployeeID:     user.ID,
+			Values:         data.Values,
+		})
+		if err != nil {
+			respondWithTemplateError(w, err, "Failed to render template: ")
+			return
+		}
+
+		var deadline *time.Time
+		if data.SubmissionDays != nil {
+			d := time.Now().AddDate(0, 0, *data.SubmissionDays)
+			deadline = &d
+		}
+		tender := templateTender(template.Content, oID, user.Username, deadline, nil, nil)
+		if problems := tender.Valid(r.Context()); len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+
+		series, err := or.seriesService.CreateSeries(r.Context(), &service.CreateSeriesInput{
+			OrganizationID: oID,
+			TemplateID:     tID,
+			Rule:           data.Rule,
+			StartsAt:       *data.StartsAt,
+			Values:         data.Values,
+			SubmissionDays: data.SubmissionDays,
+			AutoPublish:    data.AutoPublish,
+			CreatedBy:      user.ID,
+		})
+		if err != nil {
+			respondWithSeriesError(w, err, "Failed to create series: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseSeries(series))
+	}
+}
+
+func (or *organizationRouter) getSeriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		series, err := or.seriesService.GetSeries(r.Context(), oID, user.ID)
+		if err != nil {
+			respondWithSeriesError(w, err, "Failed to get series: ")
+			return
+		}
+
+		response := make([]ResponseSeries, 0, len(series))
+		for _, s := range series {
+			response = append(response, newResponseSeries(s))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (or *organizationRouter) getSeriesTendersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, sID, ok := seriesPath(w, r)
+		if !ok {
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		instances, err := or.seriesService.GetSeriesInstances(r.Context(), oID, sID, user.ID)
+		if err != nil {
+			respondWithSeriesError(w, err, "Failed to get series tenders: ")
+			return
+		}
+
+		response := make([]ResponseSeriesInstance, 0, len(instances))
+		for _, i := range instances {
+			response = append(response, ResponseSeriesInstance{
+				TenderID:    i.TenderID,
+				Occurrence:  i.Occurrence,
+				ScheduledAt: i.ScheduledAt,
+				CreatedAt:   i.CreatedAt,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (or *organizationRouter) updateSeriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, sID, ok := seriesPath(w, r)
+		if !ok {
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.SeriesUpdate](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		series, err := or.seriesService.UpdateSeries(r.Context(), &service.UpdateSeriesInput{
+			OrganizationID: oID,
+			SeriesID:       sID,
+			EmployeeID:     user.ID,
+			Rule:           data.Rule,
+			StartsAt:       data.StartsAt,
+			Values:         data.Values,
+			SubmissionDays: data.SubmissionDays,
+			AutoPublish:    data.AutoPublish,
+		})
+		if err != nil {
+			respondWithSeriesError(w, err, "Failed to update series: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseSeries(series))
+	}
+}
+
+type seriesTransition func(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) (*service.SeriesOutput, error)
+
+// seriesTransitionHandler pauses or resumes a series.
+func (or *organizationRouter) seriesTransitionHandler(transition seriesTransition) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, sID, ok := seriesPath(w, r)
+		if !ok {
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		series, err := transition(r.Context(), oID, sID, user.ID)
+		if err != nil {
+			respondWithSeriesError(w, err, "Failed to update series: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseSeries(series))
+	}
+}
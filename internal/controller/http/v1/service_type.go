@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type serviceTypeRouter struct {
+	serviceTypeService service.ServiceType
+}
+
+func newServiceTypeRouter(serviceTypeService service.ServiceType, services *service.Services) http.Handler {
+	r := &serviceTypeRouter{
+		serviceTypeService: serviceTypeService,
+	}
+
+	mux := http.NewServeMux()
+
+	adminMiddleware := adminMiddleware(services)
+
+	mux.Handle("GET /", r.getServiceTypesHandler())
+	mux.Handle("POST /new", adminMiddleware(http.HandlerFunc(r.createServiceTypeHandler())))
+	mux.Handle("PATCH /{serviceTypeId}/edit", adminMiddleware(http.HandlerFunc(r.updateServiceTypeHandler())))
+	mux.Handle("DELETE /{serviceTypeId}", adminMiddleware(http.HandlerFunc(r.deleteServiceTypeHandler())))
+
+	return http.StripPrefix("/api/service-types", mux)
+}
+
+type ResponseServiceType struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	Code      string            `json:"code"`
+	ParentID  *uuid.UUID        `json:"parentId,omitempty"`
+	Label     string            `json:"label"`
+	Labels    map[string]string `json:"labels"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func newResponseServiceType(st *service.ServiceTypeOutput) ResponseServiceType {
+	return ResponseServiceType{
+		ID:        st.ID,
+		Name:      st.Name,
+		Code:      st.Code,
+		ParentID:  st.ParentID,
+		Label:     st.Label,
+		Labels:    st.Labels,
+		Active:    st.Active,
+		CreatedAt: st.CreatedAt,
+	}
+}
+
+func respondWithServiceTypeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrServiceTypeNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrServiceTypeAlreadyExists), errors.Is(err, service.ErrServiceTypeInUse):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrServiceTypeCycle):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (sr *serviceTypeRouter) getServiceTypesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serviceTypes, err := sr.serviceTypeService.GetServiceTypes(r.Context(), r.URL.Query().Get("lang"))
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Unable to get service types: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseServiceType, 0, len(serviceTypes))
+		for _, st := range serviceTypes {
+			response = append(response, newResponseServiceType(st))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (sr *serviceTypeRouter) createServiceTypeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, problems, err := decodeValid[model.ServiceType](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		created, err := sr.serviceTypeService.CreateServiceType(r.Context(), &service.CreateServiceTypeInput{
+			Name:     data.Name,
+			Code:     data.Code,
+			ParentID: data.ParentID,
+			Labels:   data.Labels,
+		})
+		if err != nil {
+			respondWithServiceTypeError(w, err, "Failed to create service type: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseServiceType(created))
+	}
+}
+
+func (sr *serviceTypeRouter) updateServiceTypeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("serviceTypeId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid service type ID format: "+err.Error())
+			return
+		}
+
+		data, problems, err := decodeValid[model.ServiceTypeUpdate](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		updated, err := sr.serviceTypeService.UpdateServiceType(r.Context(), &service.UpdateServiceTypeInput{
+			ID:       id,
+			Name:     data.Name,
+			Code:     data.Code,
+			ParentID: data.ParentID,
+			Labels:   data.Labels,
+			Active:   data.Active,
+		})
+		if err != nil {
+			respondWithServiceTypeError(w, err, "Failed to update service type: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseServiceType(updated))
+	}
+}
+
+func (sr *serviceTypeRouter) deleteServiceTypeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("serviceTypeId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid service type ID format: "+err.Error())
+			return
+		}
+
+		if err = sr.serviceTypeService.DeleteServiceType(r.Context(), id); err != nil {
+			respondWithServiceTypeError(w, err, "Failed to delete service type: ")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
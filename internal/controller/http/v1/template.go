@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/model"
+	"git.codenrock.com/tender/internal/service"
+	"github.com/google/uuid"
+)
+
+type ResponseTemplate struct {
+	ID             uuid.UUID                    `json:"id"`
+	OrganizationID uuid.UUID                    `json:"organizationId"`
+	Name           string                       `json:"name"`
+	Tender         entity.TenderTemplateContent `json:"tender"`
+	Placeholders   []string                     `json:"placeholders"`
+	CreatedBy      uuid.UUID                    `json:"createdBy"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+}
+
+func newResponseTemplate(t *service.TemplateOutput) ResponseTemplate {
+	placeholders := t.Placeholders
+	if placeholders == nil {
+		placeholders = []string{}
+	}
+	return ResponseTemplate{
+		ID:             t.ID,
+		OrganizationID: t.OrganizationID,
+		Name:           t.Name,
+		Tender:         t.Content,
+		Placeholders:   placeholders,
+		CreatedBy:      t.CreatedBy,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+func respondWithTemplateError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrTemplateNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotOrganizationResponsible):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrTemplateAlreadyExists):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrMissingPlaceholderValues):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, message+err.Error())
+	}
+}
+
+func (or *organizationRouter) createTemplateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.TenderTemplate](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		content := entity.TenderTemplateContent{
+			Name:        data.Tender.Name,
+			Description: data.Tender.Description,
+			ServiceType: data.Tender.ServiceType,
+			Sealed:      data.Tender.Sealed,
+			Budget:      data.Tender.Budget,
+			Currency:    data.Tender.Currency,
+			AccessMode:  data.Tender.AccessMode,
+		}
+		for _, c := range data.Tender.Criteria {
+			content.Criteria = append(content.Criteria, entity.TemplateCriterion{Name: c.Name, Weight: c.Weight.String()})
+		}
+		for _, l := range data.Tender.Lots {
+			content.Lots = append(content.Lots, entity.TemplateLot{Name: l.Name, Description: l.Description, Budget: l.Budget})
+		}
+
+		template, err := or.templateService.CreateTemplate(r.Context(), &service.CreateTemplateInput{
+			OrganizationID: oID,
+			Name:           data.Name,
+			Content:        content,
+			CreatedBy:      user.ID,
+		})
+		if err != nil {
+			respondWithTemplateError(w, err, "Failed to create template: ")
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, newResponseTemplate(template))
+	}
+}
+
+func (or *organizationRouter) getTemplatesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		templates, err := or.templateService.GetTemplates(r.Context(), oID, user.ID)
+		if err != nil {
+			respondWithTemplateError(w, err, "Failed to get templates: ")
+			return
+		}
+
+		response := make([]ResponseTemplate, 0, len(templates))
+		for _, t := range templates {
+			response = append(response, newResponseTemplate(t))
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+func (or *organizationRouter) deleteTemplateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+		tID, err := uuid.Parse(r.PathValue("templateId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid template ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		if err = or.templateService.DeleteTemplate(r.Context(), oID, tID, user.ID); err != nil {
+			respondWithTemplateError(w, err, "Failed to delete template: ")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// createTenderFromTemplateHandler fills the placeholders of a template and
+// creates the result as a tender of the user, validated like a new tender.
+func (or *organizationRouter) createTenderFromTemplateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oID, err := uuid.Parse(r.PathValue("organizationId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid organization ID format: "+err.Error())
+			return
+		}
+		tID, err := uuid.Parse(r.PathValue("templateId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid template ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, or.employeeService)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.TemplateInstance](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		template, err := or.templateService.RenderTemplate(r.Context(), &service.RenderTemplateInput{
+			OrganizationID: oID,
+			TemplateID:     tID,
+			EmployeeID:     user.ID,
+			Values:         data.Values,
+		})
+		if err != nil {
+			respondWithTemplateError(w, err, "Failed to render template: ")
+			return
+		}
+
+		tender := templateTender(template.Content, oID, user.Username, data.SubmissionDeadline, data.AuctionStartsAt, data.AuctionEndsAt)
+		createTender(w, r, tender, or.tenderService.CreateTender)
+	}
+}
+
+// templateTender builds the tender request made from rendered template
+// content, to be validated like any new tender.
+func templateTender(content entity.TenderTemplateContent, organizationID uuid.UUID, creatorUsername string, submissionDeadline, auctionStartsAt, auctionEndsAt *time.Time) model.Tender {
+	tender := model.Tender{
+		Name:               content.Name,
+		Description:        content.Description,
+		ServiceType:        content.ServiceType,
+		OrganizationID:     organizationID.String(),
+		CreatorUsername:    creatorUsername,
+		Sealed:             content.Sealed,
+		SubmissionDeadline: submissionDeadline,
+		Budget:             content.Budget,
+		Currency:           content.Currency,
+		AuctionStartsAt:    auctionStartsAt,
+		AuctionEndsAt:      auctionEndsAt,
+		AccessMode:         content.AccessMode,
+	}
+	for _, c := range content.Criteria {
+		tender.Criteria = append(tender.Criteria, model.Criterion{Name: c.Name, Weight: json.Number(c.Weight)})
+	}
+	for _, l := range content.Lots {
+		tender.Lots = append(tender.Lots, model.Lot{Name: l.Name, Description: l.Description, Budget: l.Budget})
+	}
+	return tender
+}
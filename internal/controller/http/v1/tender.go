@@ -1,12 +1,15 @@
 package v1
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/model"
 	"git.codenrock.com/tender/internal/service"
 	"github.com/google/uuid"
@@ -27,72 +30,252 @@ func newTenderRouter(tenderService service.Tender, services *service.Services) h
 	getTenderStatusMiddleware := getTenderStatusMiddleware(services)
 	updateTenderStatusMiddleware := updateTenderStatusMiddleware(services)
 	UpdateTenderMiddleware := UpdateTenderMiddleware(services)
+	tenderResponsibleMiddleware := tenderResponsibleMiddleware(services)
 
 	mux.Handle("POST /new", createTenderMiddleware(http.HandlerFunc(r.createTenderHandler())))
-	mux.Handle("GET /", r.getTendersHandler())
+	mux.Handle("GET /", r.getTendersHandler(services.Employee))
 	mux.Handle("GET /my", r.getUserTendersHandler(services.Employee))
 	mux.Handle("GET /{tenderId}/status", getTenderStatusMiddleware(http.HandlerFunc(r.getTenderStatusHandler())))
-	mux.Handle("PUT /{tenderId}/status", updateTenderStatusMiddleware(http.HandlerFunc(r.updateTenderStatusHandler())))
+	mux.Handle("PUT /{tenderId}/status", updateTenderStatusMiddleware(http.HandlerFunc(r.updateTenderStatusHandler(services.Employee))))
+	mux.Handle("POST /{tenderId}/clone", tenderResponsibleMiddleware(http.HandlerFunc(r.cloneTenderHandler(services.Employee))))
+	mux.Handle("POST /{tenderId}/cancel", updateTenderStatusMiddleware(http.HandlerFunc(r.cancelTenderHandler())))
 	mux.Handle("PATCH /{tenderId}/edit", UpdateTenderMiddleware(http.HandlerFunc(r.updateTenderHandler())))
+	mux.Handle("GET /{tenderId}/amendments", getTenderStatusMiddleware(http.HandlerFunc(r.getTenderAmendmentsHandler())))
+	mux.Handle("GET /{tenderId}/comparison", tenderResponsibleMiddleware(http.HandlerFunc(r.getBidComparisonHandler(services.Bid))))
+	mux.Handle("GET /{tenderId}/criteria", getTenderStatusMiddleware(http.HandlerFunc(r.getCriteriaHandler(services.Evaluation))))
+	mux.Handle("GET /{tenderId}/lots", getTenderStatusMiddleware(http.HandlerFunc(r.getLotsHandler(services.Lot))))
+	mux.Handle("PUT /{tenderId}/lots/{lotId}/decision", tenderResponsibleMiddleware(http.HandlerFunc(r.decideLotHandler(services.Employee, services.Lot))))
+	mux.Handle("GET /{tenderId}/auction", r.auctionHandler(services))
+	mux.Handle("POST /{tenderId}/attachments", tenderResponsibleMiddleware(http.HandlerFunc(r.uploadTenderAttachmentHandler(services))))
+	mux.Handle("GET /{tenderId}/attachments", getTenderStatusMiddleware(http.HandlerFunc(r.getTenderAttachmentsHandler(services.Attachment))))
+	mux.Handle("GET /{tenderId}/attachments/{attachmentId}", getTenderStatusMiddleware(http.HandlerFunc(r.downloadTenderAttachmentHandler(services.Attachment))))
+	mux.Handle("POST /{tenderId}/questions", r.askQuestionHandler(services))
+	mux.Handle("GET /{tenderId}/questions", getTenderStatusMiddleware(http.HandlerFunc(r.getQuestionsHandler(services))))
+	mux.Handle("PUT /{tenderId}/questions/{questionId}/answer", tenderResponsibleMiddleware(http.HandlerFunc(r.answerQuestionHandler(services))))
+	mux.Handle("POST /{tenderId}/invitations", tenderResponsibleMiddleware(http.HandlerFunc(r.createInvitationsHandler(services.Invitation))))
+	mux.Handle("GET /{tenderId}/invitations", tenderResponsibleMiddleware(http.HandlerFunc(r.getInvitationsHandler(services.Invitation))))
+	mux.Handle("DELETE /{tenderId}/invitations/{invitationId}", tenderResponsibleMiddleware(http.HandlerFunc(r.deleteInvitationHandler(services.Invitation))))
+	mux.Handle("GET /{tenderId}/ranking", tenderResponsibleMiddleware(http.HandlerFunc(r.getRankingHandler(services.Evaluation))))
+	mux.Handle("POST /{tenderId}/approvals", tenderResponsibleMiddleware(http.HandlerFunc(r.decideApprovalHandler(services))))
+	mux.Handle("GET /{tenderId}/approvals", tenderResponsibleMiddleware(http.HandlerFunc(r.getApprovalsHandler(services.Approval))))
+	mux.Handle("GET /{tenderId}/conflicts", tenderResponsibleMiddleware(http.HandlerFunc(r.getTenderConflictsHandler(services.Conflict))))
 
 	return http.StripPrefix("/api/tenders", mux)
 }
 
 type ResponseTender struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	ServiceType string    `json:"serviceType"`
-	Version     int       `json:"version"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID                 uuid.UUID                   `json:"id"`
+	Name               string                      `json:"name"`
+	Description        string                      `json:"description"`
+	Status             string                      `json:"status"`
+	ServiceType        string                      `json:"serviceType"`
+	Version            int                         `json:"version"`
+	Sealed             bool                        `json:"sealed"`
+	SubmissionDeadline *time.Time                  `json:"submissionDeadline,omitempty"`
+	OpenedAt           *time.Time                  `json:"openedAt,omitempty"`
+	Budget             *string                     `json:"budget,omitempty"`
+	Currency           *string                     `json:"currency,omitempty"`
+	Criteria           []ResponseCriterion         `json:"criteria,omitempty"`
+	Lots               []ResponseLot               `json:"lots,omitempty"`
+	AuctionStartsAt    *time.Time                  `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt      *time.Time                  `json:"auctionEndsAt,omitempty"`
+	AccessMode         string                      `json:"accessMode"`
+	Cancellation       *ResponseTenderCancellation `json:"cancellation,omitempty"`
+	ApprovalRequestID  *uuid.UUID                  `json:"approvalRequestId,omitempty"`
+	CreatedAt          time.Time                   `json:"createdAt"`
+}
+
+type ResponseTenderCancellation struct {
+	Category   string     `json:"category"`
+	Reason     string     `json:"reason"`
+	CanceledAt *time.Time `json:"canceledAt,omitempty"`
+}
+
+func newResponseTender(tender *service.TenderOutput) ResponseTender {
+	var cancellation *ResponseTenderCancellation
+	if tender.CancellationCategory != nil {
+		cancellation = &ResponseTenderCancellation{
+			Category:   *tender.CancellationCategory,
+			CanceledAt: tender.CanceledAt,
+		}
+		if tender.CancellationReason != nil {
+			cancellation.Reason = *tender.CancellationReason
+		}
+	}
+
+	return ResponseTender{
+		ID:                 tender.ID,
+		Name:               tender.Name,
+		Description:        tender.Description,
+		Status:             tender.Status,
+		ServiceType:        tender.ServiceType,
+		Version:            tender.Version,
+		Sealed:             tender.Sealed,
+		SubmissionDeadline: tender.SubmissionDeadline,
+		OpenedAt:           tender.OpenedAt,
+		Budget:             tender.Budget,
+		Currency:           tender.Currency,
+		Criteria:           newResponseCriteria(tender.Criteria),
+		Lots:               newResponseLots(tender.Lots),
+		AuctionStartsAt:    tender.AuctionStartsAt,
+		AuctionEndsAt:      tender.AuctionEndsAt,
+		AccessMode:         tender.AccessMode,
+		Cancellation:       cancellation,
+		ApprovalRequestID:  tender.ApprovalRequestID,
+		CreatedAt:          tender.CreatedAt,
+	}
 }
 
 func (tr *tenderRouter) createTenderHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		tender, problems, err := decodeValid[model.Tender](r)
+		tender, err := decode[model.Tender](r)
 		if err != nil {
 			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
 			return
 		}
 
+		createTender(w, r, tender, tr.tenderService.CreateTender)
+	}
+}
+
+// createTender validates a new tender, creates it with create and responds
+// with it. Every path that makes a tender goes through it, so that none
+// skips the validation of the tender request.
+func createTender(w http.ResponseWriter, r *http.Request, tender model.Tender, create func(context.Context, *service.CreateTenderInput) (*service.TenderOutput, error)) {
+	if problems := tender.Valid(r.Context()); len(problems) > 0 {
+		respondWithValidationErrors(w, problems)
+		return
+	}
+
+	tID, err := uuid.Parse(tender.OrganizationID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	criteria := make([]service.CriterionInput, 0, len(tender.Criteria))
+	for _, c := range tender.Criteria {
+		criteria = append(criteria, service.CriterionInput{Name: c.Name, Weight: c.Weight.String()})
+	}
+
+	lots := make([]service.LotInput, 0, len(tender.Lots))
+	for _, l := range tender.Lots {
+		lots = append(lots, service.LotInput{Name: l.Name, Description: l.Description, Budget: l.Budget})
+	}
+
+	createdTender, err := create(r.Context(), &service.CreateTenderInput{
+		Name:               tender.Name,
+		Description:        tender.Description,
+		ServiceType:        tender.ServiceType,
+		OrganizationID:     tID,
+		CreatorUsername:    tender.CreatorUsername,
+		Sealed:             tender.Sealed,
+		SubmissionDeadline: tender.SubmissionDeadline,
+		Budget:             tender.Budget,
+		Currency:           tender.Currency,
+		Criteria:           criteria,
+		Lots:               lots,
+		AuctionStartsAt:    tender.AuctionStartsAt,
+		AuctionEndsAt:      tender.AuctionEndsAt,
+		AccessMode:         tender.AccessMode,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTender), errors.Is(err, service.ErrInvalidServiceType),
+			errors.Is(err, service.ErrAuctionServiceType):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrTenderAlreadyExists):
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response := newResponseTender(createdTender)
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// cloneTenderHandler copies a tender into a new draft created by the user.
+// The copy is validated like any new tender.
+func (tr *tenderRouter) cloneTenderHandler(es service.Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
+		data, problems, err := decodeValid[model.TenderClone](r)
 		if len(problems) > 0 {
 			respondWithValidationErrors(w, problems)
 			return
 		}
-
-		tID, err := uuid.Parse(tender.OrganizationID)
 		if err != nil {
-			respondWithError(w, http.StatusBadRequest, err.Error())
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
 			return
 		}
-		createdTender, err := tr.tenderService.CreateTender(r.Context(), &service.CreateTenderInput{
-			Name:            tender.Name,
-			Description:     tender.Description,
-			ServiceType:     tender.ServiceType,
-			OrganizationID:  tID,
-			CreatorUsername: tender.CreatorUsername,
+
+		draft, err := tr.tenderService.DraftClone(r.Context(), &service.CloneTenderInput{
+			TenderID:           tID,
+			CreatorUsername:    user.Username,
+			Name:               data.Name,
+			SubmissionDeadline: data.SubmissionDeadline,
+			AuctionStartsAt:    data.AuctionStartsAt,
+			AuctionEndsAt:      data.AuctionEndsAt,
 		})
 		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, err.Error())
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrCloneScheduleRequired):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to clone tender: "+err.Error())
+			}
 			return
 		}
 
-		response := ResponseTender{
-			ID:          createdTender.ID,
-			Name:        createdTender.Name,
-			Description: createdTender.Description,
-			Status:      createdTender.Status,
-			ServiceType: createdTender.ServiceType,
-			Version:     createdTender.Version,
-			CreatedAt:   createdTender.CreatedAt,
-		}
+		createTender(w, r, draftTender(draft), func(ctx context.Context, input *service.CreateTenderInput) (*service.TenderOutput, error) {
+			return tr.tenderService.CloneTender(ctx, tID, input)
+		})
+	}
+}
 
-		respondWithJSON(w, http.StatusOK, response)
+// draftTender turns the draft of a tender made by the service back into a
+// tender request, to be validated like one.
+func draftTender(draft *service.CreateTenderInput) model.Tender {
+	tender := model.Tender{
+		Name:               draft.Name,
+		Description:        draft.Description,
+		ServiceType:        draft.ServiceType,
+		OrganizationID:     draft.OrganizationID.String(),
+		CreatorUsername:    draft.CreatorUsername,
+		Sealed:             draft.Sealed,
+		SubmissionDeadline: draft.SubmissionDeadline,
+		Budget:             draft.Budget,
+		Currency:           draft.Currency,
+		AuctionStartsAt:    draft.AuctionStartsAt,
+		AuctionEndsAt:      draft.AuctionEndsAt,
+		AccessMode:         draft.AccessMode,
 	}
+	for _, c := range draft.Criteria {
+		tender.Criteria = append(tender.Criteria, model.Criterion{Name: c.Name, Weight: json.Number(c.Weight)})
+	}
+	for _, l := range draft.Lots {
+		tender.Lots = append(tender.Lots, model.Lot{Name: l.Name, Description: l.Description, Budget: l.Budget})
+	}
+	return tender
 }
 
-func (tr *tenderRouter) getTendersHandler() http.HandlerFunc {
+// getTendersHandler lists the open tenders, plus the invitation-only ones
+// visible to the user given by the optional username.
+func (tr *tenderRouter) getTendersHandler(es service.Employee) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		limit := 5
 		offset := 0
@@ -117,29 +300,30 @@ func (tr *tenderRouter) getTendersHandler() http.HandlerFunc {
 			}
 		}
 
-		tenders, err := tr.tenderService.GetTenders(r.Context(), &service.GetTendersInput{
+		input := &service.GetTendersInput{
 			Limit:        limit,
 			Offset:       offset,
 			ServiceTypes: serviceTypes,
-		})
+		}
+		if r.URL.Query().Get("username") != "" {
+			user, ok := requestUser(w, r, es)
+			if !ok {
+				return
+			}
+			input.ViewerID = user.ID
+		}
+
+		tenders, err := tr.tenderService.GetTenders(r.Context(), input)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Unable to get tenders: "+err.Error())
 			return
 		}
 
-		var tendersResponse []ResponseTender
+		tendersResponse := make([]ResponseTender, 0, len(tenders))
 		for _, tender := range tenders {
-			tendersResponse = append(tendersResponse, ResponseTender{
-				ID:          tender.ID,
-				Name:        tender.Name,
-				Description: tender.Description,
-				Status:      tender.Status,
-				ServiceType: tender.ServiceType,
-				Version:     tender.Version,
-				CreatedAt:   tender.CreatedAt,
-			})
-			respondWithJSON(w, http.StatusOK, tendersResponse)
+			tendersResponse = append(tendersResponse, newResponseTender(tender))
 		}
+		respondWithJSON(w, http.StatusOK, tendersResponse)
 	}
 }
 func (tr *tenderRouter) getUserTendersHandler(es service.Employee) http.HandlerFunc {
@@ -177,15 +361,7 @@ func (tr *tenderRouter) getUserTendersHandler(es service.Employee) http.HandlerF
 
 		var tendersResponse []ResponseTender
 		for _, tender := range tenders {
-			tendersResponse = append(tendersResponse, ResponseTender{
-				ID:          tender.ID,
-				Name:        tender.Name,
-				Description: tender.Description,
-				Status:      tender.Status,
-				ServiceType: tender.ServiceType,
-				Version:     tender.Version,
-				CreatedAt:   tender.CreatedAt,
-			})
+			tendersResponse = append(tendersResponse, newResponseTender(tender))
 		}
 		respondWithJSON(w, http.StatusOK, tendersResponse)
 	}
@@ -208,47 +384,94 @@ func (tr *tenderRouter) getTenderStatusHandler() http.HandlerFunc {
 		respondWithJSON(w, http.StatusOK, tender.Status)
 	}
 }
-func (tr *tenderRouter) updateTenderStatusHandler() http.HandlerFunc {
+func (tr *tenderRouter) updateTenderStatusHandler(es service.Employee) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		status := r.URL.Query().Get("status")
 		tenderID := r.PathValue("tenderId")
 		tID, _ := uuid.Parse(tenderID)
 
-		if status != "Opened" && status != "Closed" && status != "Published" {
+		if status != "Opened" && status != entity.TenderStatusClosed && status != entity.TenderStatusPublished {
 			respondWithError(w, http.StatusBadRequest, "Invalid status")
 			return
 		}
 
+		user, ok := requestUser(w, r, es)
+		if !ok {
+			return
+		}
+
 		tender, err := tr.tenderService.UpdateTenderStatus(r.Context(), &service.UpdateTenderStatusInput{
-			TenderID: tID,
-			Status:   status,
+			TenderID:    tID,
+			Status:      status,
+			RequesterID: user.ID,
 		})
 		if err != nil {
-			if errors.Is(err, service.ErrTenderNotFound) {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
 				respondWithError(w, http.StatusNotFound, "Tender not found "+err.Error())
-			} else {
+			case errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrApprovalPending):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
 				respondWithError(w, http.StatusInternalServerError, "Failed to update tender status "+err.Error())
 			}
 			return
 		}
 
-		response := ResponseTender{
-			ID:          tender.ID,
-			Name:        tender.Name,
-			Description: tender.Description,
-			Status:      tender.Status,
-			ServiceType: tender.ServiceType,
-			Version:     tender.Version,
-			CreatedAt:   tender.CreatedAt,
+		response := newResponseTender(tender)
+		if tender.ApprovalRequestID != nil {
+			respondWithJSON(w, http.StatusAccepted, response)
+			return
 		}
 		respondWithJSON(w, http.StatusOK, response)
 	}
 }
+
+func (tr *tenderRouter) cancelTenderHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		data, problems, err := decodeValid[model.TenderCancellation](r)
+		if len(problems) > 0 {
+			respondWithValidationErrors(w, problems)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request: "+err.Error())
+			return
+		}
+
+		tender, err := tr.tenderService.CancelTender(r.Context(), &service.CancelTenderInput{
+			TenderID: tID,
+			Category: data.Category,
+			Reason:   data.Reason,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
+				respondWithError(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrTenderAlreadyClosed):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to cancel tender: "+err.Error())
+			}
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, newResponseTender(tender))
+	}
+}
 func (tr *tenderRouter) updateTenderHandler() http.HandlerFunc {
 	type Request struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		ServiceType string `json:"serviceType"`
+		Budget      string `json:"budget"`
+		Currency    string `json:"currency"`
+		Reason      string `json:"reason"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -257,6 +480,14 @@ func (tr *tenderRouter) updateTenderHandler() http.HandlerFunc {
 			respondWithError(w, http.StatusBadRequest, "Invalid request format: "+err.Error())
 			return
 		}
+		if data.Budget != "" && !model.ValidAmount(data.Budget) {
+			respondWithError(w, http.StatusBadRequest, "Budget must be a positive decimal with at most 2 fraction digits")
+			return
+		}
+		if data.Currency != "" && !model.ValidCurrency(data.Currency) {
+			respondWithError(w, http.StatusBadRequest, "Currency must be a 3-letter ISO 4217 code")
+			return
+		}
 
 		tenderID := r.PathValue("tenderId")
 		tID, err := uuid.Parse(tenderID)
@@ -266,27 +497,135 @@ func (tr *tenderRouter) updateTenderHandler() http.HandlerFunc {
 		}
 
 		tender, err := tr.tenderService.UpdateTender(r.Context(), &service.UpdateTenderInput{
-			TenderID:    tID,
-			Name:        data.Name,
-			Description: data.Description,
-			ServiceType: data.ServiceType,
+			TenderID:       tID,
+			Name:           data.Name,
+			Description:    data.Description,
+			ServiceType:    data.ServiceType,
+			Budget:         data.Budget,
+			Currency:       data.Currency,
+			Reason:         data.Reason,
+			EditorUsername: r.URL.Query().Get("username"),
 		})
 		if err != nil {
-			if errors.Is(err, service.ErrTenderNotFound) {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
 				respondWithError(w, http.StatusNotFound, "Tender not found: "+err.Error())
-			} else {
+			case errors.Is(err, service.ErrInvalidServiceType), errors.Is(err, service.ErrAuctionServiceType),
+				errors.Is(err, service.ErrAmendmentReasonRequired):
+				respondWithError(w, http.StatusBadRequest, err.Error())
+			case errors.Is(err, service.ErrTenderCanceled), errors.Is(err, service.ErrApprovalPending),
+				errors.Is(err, service.ErrBudgetNeedsApproval):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
 				respondWithError(w, http.StatusInternalServerError, "Failed to update tender: "+err.Error())
 			}
 			return
 		}
-		response := ResponseTender{
-			ID:          tender.ID,
-			Name:        tender.Name,
-			Description: tender.Description,
-			Status:      tender.Status,
-			ServiceType: tender.ServiceType,
-			Version:     tender.Version,
-			CreatedAt:   tender.CreatedAt,
+		response := newResponseTender(tender)
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+type ResponseTenderAmendment struct {
+	ID        uuid.UUID                         `json:"id"`
+	Version   int                               `json:"version"`
+	Reason    string                            `json:"reason"`
+	Changes   map[string]entity.AmendmentChange `json:"changes"`
+	CreatedBy string                            `json:"createdBy"`
+	CreatedAt time.Time                         `json:"createdAt"`
+}
+
+func (tr *tenderRouter) getTenderAmendmentsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		amendments, err := tr.tenderService.GetTenderAmendments(r.Context(), tID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get amendments: "+err.Error())
+			return
+		}
+
+		response := make([]ResponseTenderAmendment, 0, len(amendments))
+		for _, a := range amendments {
+			response = append(response, ResponseTenderAmendment{
+				ID:        a.ID,
+				Version:   a.Version,
+				Reason:    a.Reason,
+				Changes:   a.Changes,
+				CreatedBy: a.CreatedBy,
+				CreatedAt: a.CreatedAt,
+			})
+		}
+		respondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+type ResponseBidComparisonItem struct {
+	BidID            uuid.UUID `json:"bidId"`
+	Name             string    `json:"name"`
+	Status           string    `json:"status"`
+	AuthorType       string    `json:"authorType"`
+	AuthorID         uuid.UUID `json:"authorId"`
+	Price            *string   `json:"price"`
+	Currency         *string   `json:"currency"`
+	DeliveryDays     *int      `json:"deliveryDays"`
+	ValidityDays     *int      `json:"validityDays"`
+	Deviation        *string   `json:"deviation,omitempty"`
+	DeviationPercent *string   `json:"deviationPercent,omitempty"`
+}
+
+type ResponseBidComparison struct {
+	TenderID uuid.UUID                   `json:"tenderId"`
+	Budget   *string                     `json:"budget,omitempty"`
+	Currency *string                     `json:"currency,omitempty"`
+	Bids     []ResponseBidComparisonItem `json:"bids"`
+}
+
+func (tr *tenderRouter) getBidComparisonHandler(bs service.Bid) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tID, err := uuid.Parse(r.PathValue("tenderId"))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid tender ID format: "+err.Error())
+			return
+		}
+
+		comparison, err := bs.GetBidComparison(r.Context(), tID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrTenderNotFound):
+				respondWithError(w, http.StatusNotFound, "Tender not found: "+err.Error())
+			case errors.Is(err, service.ErrBidSealed):
+				respondWithError(w, http.StatusConflict, err.Error())
+			default:
+				respondWithError(w, http.StatusInternalServerError, "Failed to compare bids: "+err.Error())
+			}
+			return
+		}
+
+		response := ResponseBidComparison{
+			TenderID: comparison.TenderID,
+			Budget:   comparison.Budget,
+			Currency: comparison.Currency,
+			Bids:     make([]ResponseBidComparisonItem, 0, len(comparison.Bids)),
+		}
+		for _, item := range comparison.Bids {
+			response.Bids = append(response.Bids, ResponseBidComparisonItem{
+				BidID:            item.BidID,
+				Name:             item.Name,
+				Status:           item.Status,
+				AuthorType:       item.AuthorType,
+				AuthorID:         item.AuthorID,
+				Price:            item.Price,
+				Currency:         item.Currency,
+				DeliveryDays:     item.DeliveryDays,
+				ValidityDays:     item.ValidityDays,
+				Deviation:        item.Deviation,
+				DeviationPercent: item.DeviationPercent,
+			})
 		}
 		respondWithJSON(w, http.StatusOK, response)
 	}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ApprovalPending  = "Pending"
+	ApprovalApproved = "Approved"
+	ApprovalRejected = "Rejected"
+)
+
+// ApprovalPolicy requires the publication of the tenders of an organization
+// to be signed off by a number of its responsibles. A policy with a minimum
+// budget applies only to the tenders with at least that budget.
+type ApprovalPolicy struct {
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	Name              string
+	MinBudget         *string
+	RequiredApprovals int
+	CreatedBy         uuid.UUID
+	CreatedAt         time.Time
+}
+
+// ApprovalRequest is the approval chain of the publication of a tender.
+type ApprovalRequest struct {
+	ID                uuid.UUID
+	TenderID          uuid.UUID
+	PolicyID          *uuid.UUID
+	RequiredApprovals int
+	RequestedBy       uuid.UUID
+	Status            string
+	CreatedAt         time.Time
+	DecidedAt         *time.Time
+}
+
+// ApprovalStep is the sign-off or the rejection of an approver.
+type ApprovalStep struct {
+	ID         uuid.UUID
+	RequestID  uuid.UUID
+	ApproverID uuid.UUID
+	Decision   string
+	Comment    string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment describes a file attached to either a tender or a bid. The
+// content itself lives in the blob store under StorageKey.
+type Attachment struct {
+	ID          uuid.UUID
+	TenderID    *uuid.UUID
+	BidID       *uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string
+	UploadedBy  uuid.UUID
+	CreatedAt   time.Time
+}
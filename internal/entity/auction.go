@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuctionOffer is a price a bidder lowered their bid to during a reverse auction.
+type AuctionOffer struct {
+	ID        uuid.UUID
+	TenderID  uuid.UUID
+	BidID     uuid.UUID
+	Price     string
+	CreatedAt time.Time
+}
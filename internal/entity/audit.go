@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited entity types.
+const (
+	AuditTender         = "Tender"
+	AuditBid            = "Bid"
+	AuditLot            = "Lot"
+	AuditEvaluation     = "Evaluation"
+	AuditAttachment     = "Attachment"
+	AuditQuestion       = "Question"
+	AuditMessage        = "Message"
+	AuditInvitation     = "Invitation"
+	AuditNegotiation    = "Negotiation"
+	AuditServiceType    = "ServiceType"
+	AuditDebarment      = "Debarment"
+	AuditContract       = "Contract"
+	AuditConflict       = "ConflictDeclaration"
+	AuditApproval       = "Approval"
+	AuditApprovalPolicy = "ApprovalPolicy"
+	AuditTemplate       = "Template"
+	AuditSeries         = "Series"
+	AuditNotification   = "Notification"
+	AuditEmployeeKey    = "EmployeeKey"
+)
+
+// AuditEvent records a committed mutation: who made it, from which request,
+// and the state of the entity before and after it as JSON. Seq is nil for
+// the events recorded before the audit chain existed.
+type AuditEvent struct {
+	ID         uuid.UUID
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	IP         string
+	CreatedAt  time.Time
+	Seq        *int64
+	PrevHash   string
+	Hash       string
+}
+
+// ChainContent returns the bytes of the event covered by its chain hash.
+func (e *AuditEvent) ChainContent() ([]byte, error) {
+	before, err := canonicalJSON(e.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := canonicalJSON(e.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		ID         uuid.UUID       `json:"id"`
+		Actor      string          `json:"actor"`
+		Action     string          `json:"action"`
+		EntityType string          `json:"entityType"`
+		EntityID   uuid.UUID       `json:"entityId"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+		RequestID  string          `json:"requestId"`
+		IP         string          `json:"ip"`
+		CreatedAt  string          `json:"createdAt"`
+	}{
+		ID:         e.ID,
+		Actor:      e.Actor,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Before:     before,
+		After:      after,
+		RequestID:  e.RequestID,
+		IP:         e.IP,
+		CreatedAt:  ChainTime(e.CreatedAt).Format(time.RFC3339Nano),
+	})
+}
+
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   *uuid.UUID
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
@@ -1,21 +1,143 @@
 package entity
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	BidStatusCreated   = "Created"
+	BidStatusCanceled  = "Canceled"
+	BidStatusWithdrawn = "Withdrawn"
+)
+
+// InactiveBidStatuses lists the statuses of bids that no longer compete.
+var InactiveBidStatuses = []string{BidStatusCanceled, BidStatusWithdrawn}
+
 type Bid struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	Status      string
-	TenderID    uuid.UUID
-	AuthorType  string
-	AuthorID    uuid.UUID
-	Version     int
-	Decision    string
-	Feedback    string
-	CreatedAt   time.Time
+	ID             uuid.UUID
+	Name           string
+	Description    string
+	Status         string
+	TenderID       uuid.UUID
+	AuthorType     string
+	AuthorID       uuid.UUID
+	Version        int
+	Decision       string
+	Feedback       string
+	FeedbackRating *int
+	Price          *string
+	Currency       *string
+	DeliveryDays   *int
+	ValidityDays   *int
+	TotalScore     *string
+	SealedPayload  []byte
+	NeedsAck       bool
+	CreatedAt      time.Time
+}
+
+// IsSealed reports whether the bid contents are stored encrypted.
+func (b *Bid) IsSealed() bool {
+	return len(b.SealedPayload) > 0
+}
+
+// IsActive reports whether the bid still competes for the tender.
+func (b *Bid) IsActive() bool {
+	return b.Status != BidStatusCanceled && b.Status != BidStatusWithdrawn
+}
+
+// BidVersion is a snapshot of a bid kept in its history, such as the offer
+// in force when the bid was withdrawn.
+type BidVersion struct {
+	ID            uuid.UUID
+	BidID         uuid.UUID
+	Version       int
+	Status        string
+	Name          string
+	Description   string
+	Price         *string
+	Currency      *string
+	DeliveryDays  *int
+	ValidityDays  *int
+	SealedPayload []byte
+	CreatedAt     time.Time
+}
+
+// IsSealed reports whether the snapshot contents are stored encrypted.
+func (v *BidVersion) IsSealed() bool {
+	return len(v.SealedPayload) > 0
+}
+
+// BidSubmission is the record of a bid version as it was submitted, linked
+// into the bid chain. Sealed contents are recorded, and hashed, encrypted.
+type BidSubmission struct {
+	BidID         uuid.UUID
+	TenderID      uuid.UUID
+	AuthorID      uuid.UUID
+	Version       int
+	Status        string
+	Name          string
+	Description   string
+	Price         *string
+	Currency      *string
+	DeliveryDays  *int
+	ValidityDays  *int
+	SealedPayload []byte
+	CreatedAt     time.Time
+	ChainLink
+}
+
+// NewBidSubmission snapshots the bid as it is now.
+func NewBidSubmission(bid *Bid, now time.Time) *BidSubmission {
+	return &BidSubmission{
+		BidID:         bid.ID,
+		TenderID:      bid.TenderID,
+		AuthorID:      bid.AuthorID,
+		Version:       bid.Version,
+		Status:        bid.Status,
+		Name:          bid.Name,
+		Description:   bid.Description,
+		Price:         bid.Price,
+		Currency:      bid.Currency,
+		DeliveryDays:  bid.DeliveryDays,
+		ValidityDays:  bid.ValidityDays,
+		SealedPayload: bid.SealedPayload,
+		CreatedAt:     ChainTime(now),
+	}
+}
+
+// ChainContent returns the bytes of the submission covered by its chain
+// hash.
+func (s *BidSubmission) ChainContent() ([]byte, error) {
+	return json.Marshal(struct {
+		BidID         uuid.UUID `json:"bidId"`
+		TenderID      uuid.UUID `json:"tenderId"`
+		AuthorID      uuid.UUID `json:"authorId"`
+		Version       int       `json:"version"`
+		Status        string    `json:"status"`
+		Name          string    `json:"name"`
+		Description   string    `json:"description"`
+		Price         *string   `json:"price"`
+		Currency      *string   `json:"currency"`
+		DeliveryDays  *int      `json:"deliveryDays"`
+		ValidityDays  *int      `json:"validityDays"`
+		SealedPayload []byte    `json:"sealedPayload"`
+		CreatedAt     string    `json:"createdAt"`
+	}{
+		BidID:         s.BidID,
+		TenderID:      s.TenderID,
+		AuthorID:      s.AuthorID,
+		Version:       s.Version,
+		Status:        s.Status,
+		Name:          s.Name,
+		Description:   s.Description,
+		Price:         s.Price,
+		Currency:      s.Currency,
+		DeliveryDays:  s.DeliveryDays,
+		ValidityDays:  s.ValidityDays,
+		SealedPayload: s.SealedPayload,
+		CreatedAt:     ChainTime(s.CreatedAt).Format(time.RFC3339Nano),
+	})
 }
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Hash chains. Every audit event and every submitted bid version is linked
+// to the previous entry of its chain by a hash.
+const (
+	ChainAudit = "audit"
+	ChainBid   = "bid"
+)
+
+var Chains = []string{ChainAudit, ChainBid}
+
+// ChainLink places an entry in its chain. Hash covers the hash of the
+// previous entry and the content of the entry, so that changing, removing or
+// reordering an entry breaks every following link.
+type ChainLink struct {
+	Seq      int64
+	PrevHash string
+	Hash     string
+}
+
+// ChainHead is the last link of a chain. The first entry of a chain has
+// sequence number 1 and an empty previous hash.
+type ChainHead struct {
+	Chain     string
+	Seq       int64
+	Hash      string
+	UpdatedAt time.Time
+}
+
+// Next links content after the head.
+func (h *ChainHead) Next(content []byte) ChainLink {
+	return ChainLink{
+		Seq:      h.Seq + 1,
+		PrevHash: h.Hash,
+		Hash:     ChainHash(h.Hash, content),
+	}
+}
+
+// ChainHash returns the hex encoded SHA-256 of the previous hash followed by
+// the content.
+func ChainHash(prevHash string, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChainTime truncates t to the precision of the database, so that the time
+// hashed when an entry is written is the one read back when it is verified.
+func ChainTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// canonicalJSON re-encodes a JSON document with sorted object keys and
+// without insignificant whitespace. JSONB columns do not keep the text they
+// were given, so documents are hashed in this form.
+func canonicalJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("canonical json: %w", err)
+	}
+	return json.Marshal(v)
+}
+
+// ChainCheckpoint is a signed statement of the head of a chain at some
+// point in time. An entry covered by a checkpoint cannot be rewritten, even
+// together with every following link, without the signing key.
+type ChainCheckpoint struct {
+	ID        uuid.UUID
+	Chain     string
+	Seq       int64
+	Hash      string
+	PublicKey []byte
+	Signature []byte
+	CreatedAt time.Time
+}
+
+// Message returns the bytes the checkpoint signature is made over.
+func (c *ChainCheckpoint) Message() []byte {
+	return []byte(fmt.Sprintf("tender-chain-checkpoint\n%s\n%d\n%s\n%s", c.Chain, c.Seq, c.Hash, ChainTime(c.CreatedAt).Format(time.RFC3339Nano)))
+}
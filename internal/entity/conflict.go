@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RelationshipFormerEmployer = "FormerEmployer"
+	RelationshipShareholder    = "Shareholder"
+	RelationshipFamily         = "Family"
+	RelationshipOther          = "Other"
+)
+
+// Stages at which bids are screened for conflicts of interest.
+const (
+	ConflictStageBidCreation = "BidCreation"
+	ConflictStageDecision    = "Decision"
+	ConflictStageReview      = "Review"
+)
+
+// Conflict-of-interest rules.
+const (
+	// ConflictRuleMembership is a responsible of an organization on both
+	// sides of a bid, or a reviewer of their own bid.
+	ConflictRuleMembership = "Membership"
+	// ConflictRuleDeclared is a declared relationship with an organization
+	// on the other side of a bid.
+	ConflictRuleDeclared = "DeclaredRelationship"
+)
+
+// ConflictDeclaration is a relationship an employee declares with an
+// organization, such as a former employer.
+type ConflictDeclaration struct {
+	ID             uuid.UUID
+	EmployeeID     uuid.UUID
+	OrganizationID uuid.UUID
+	Relationship   string
+	Description    string
+	CreatedAt      time.Time
+}
+
+// ConflictFlag records a conflict of interest that the policy let through
+// for review.
+type ConflictFlag struct {
+	ID             uuid.UUID
+	TenderID       uuid.UUID
+	BidID          uuid.UUID
+	EmployeeID     uuid.UUID
+	OrganizationID *uuid.UUID
+	Stage          string
+	Rule           string
+	Detail         string
+	CreatedAt      time.Time
+}
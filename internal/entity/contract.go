@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ContractActive    = "Active"
+	ContractCompleted = "Completed"
+)
+
+const (
+	ContractSideSupplier = "Supplier"
+	ContractSideBuyer    = "Buyer"
+)
+
+// Contract is created when a bid is awarded, for the whole tender or for one
+// of its lots. The supplier organization is unknown for bids made by a user.
+type Contract struct {
+	ID                     uuid.UUID
+	TenderID               uuid.UUID
+	BidID                  uuid.UUID
+	LotID                  *uuid.UUID
+	BidVersion             int
+	BuyerOrganizationID    uuid.UUID
+	SupplierOrganizationID *uuid.UUID
+	SupplierID             uuid.UUID
+	Price                  *string
+	Currency               *string
+	Status                 string
+	CreatedAt              time.Time
+	CompletedAt            *time.Time
+}
+
+// Milestone is a delivery of a contract. It is completed once the supplier
+// has confirmed the delivery and the buyer its acceptance.
+type Milestone struct {
+	ID                  uuid.UUID
+	ContractID          uuid.UUID
+	Name                string
+	Description         string
+	DueDate             time.Time
+	SupplierConfirmedAt *time.Time
+	BuyerConfirmedAt    *time.Time
+	CompletedAt         *time.Time
+	CreatedAt           time.Time
+}
+
+// IsOverdue reports whether the milestone is still open past its due date.
+func (m *Milestone) IsOverdue(now time.Time) bool {
+	return m.CompletedAt == nil && now.After(m.DueDate)
+}
+
+// CompletedOnTime reports whether the milestone was completed by its due date.
+func (m *Milestone) CompletedOnTime() bool {
+	return m.CompletedAt != nil && !m.CompletedAt.After(m.DueDate)
+}
+
+// SupplierRecord sums up how a supplier organization meets its milestones.
+type SupplierRecord struct {
+	Completed int
+	OnTime    int
+	Overdue   int
+}
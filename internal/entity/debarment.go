@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Debarment excludes an organization or a single employee from bidding,
+// on every tender or only on those of one buyer organization. It stays in
+// force until it expires or is lifted.
+type Debarment struct {
+	ID                  uuid.UUID
+	OrganizationID      *uuid.UUID
+	EmployeeID          *uuid.UUID
+	BuyerOrganizationID *uuid.UUID
+	Reason              string
+	ExpiresAt           *time.Time
+	CreatedBy           uuid.UUID
+	CreatedAt           time.Time
+	LiftedAt            *time.Time
+}
+
+// IsActive reports whether the debarment is in force at the given time.
+func (d *Debarment) IsActive(now time.Time) bool {
+	return d.LiftedAt == nil && (d.ExpiresAt == nil || d.ExpiresAt.After(now))
+}
+
+// DebarmentBlock records a bid that a debarment prevented.
+type DebarmentBlock struct {
+	ID          uuid.UUID
+	DebarmentID uuid.UUID
+	TenderID    uuid.UUID
+	EmployeeID  uuid.UUID
+	AttemptedAt time.Time
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Criterion is a weighted evaluation criterion of a tender. Weights of all
+// criteria of a tender add up to 100.
+type Criterion struct {
+	ID       uuid.UUID
+	TenderID uuid.UUID
+	Name     string
+	Weight   string
+}
+
+type BidScore struct {
+	ID          uuid.UUID
+	BidID       uuid.UUID
+	CriterionID uuid.UUID
+	ReviewerID  uuid.UUID
+	Score       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types.
+const (
+	EventTenderCreated       = "TenderCreated"
+	EventTenderUpdated       = "TenderUpdated"
+	EventTenderAmended       = "TenderAmended"
+	EventTenderPublished     = "TenderPublished"
+	EventTenderClosed        = "TenderClosed"
+	EventTenderStatusChanged = "TenderStatusChanged"
+	EventTenderCanceled      = "TenderCanceled"
+	EventBidSubmitted        = "BidSubmitted"
+	EventBidRevised          = "BidRevised"
+	EventBidStatusChanged    = "BidStatusChanged"
+	EventBidWithdrawn        = "BidWithdrawn"
+	EventBidDecided          = "BidDecided"
+	EventLotDecided          = "LotDecided"
+	EventContractAwarded     = "ContractAwarded"
+)
+
+// Aggregates the domain events are about.
+const (
+	AggregateTender   = "Tender"
+	AggregateBid      = "Bid"
+	AggregateLot      = "Lot"
+	AggregateContract = "Contract"
+)
+
+// TenderStatusEvent returns the event of a tender moving to status.
+func TenderStatusEvent(status string) string {
+	switch status {
+	case TenderStatusPublished:
+		return EventTenderPublished
+	case TenderStatusClosed:
+		return EventTenderClosed
+	default:
+		return EventTenderStatusChanged
+	}
+}
+
+// OutboxEvent is a domain event waiting in the outbox to be delivered, or
+// delivered already. Events are stored in the transaction of the change they
+// describe, and Seq orders them as they were stored.
+type OutboxEvent struct {
+	ID            uuid.UUID
+	Seq           int64
+	Type          string
+	AggregateType string
+	AggregateID   uuid.UUID
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   *time.Time
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation admits an organization, through its responsibles, or a single
+// employee to an invitation-only tender.
+type Invitation struct {
+	ID             uuid.UUID
+	TenderID       uuid.UUID
+	OrganizationID *uuid.UUID
+	EmployeeID     *uuid.UUID
+	CreatedAt      time.Time
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LotStatusOpen     = "Open"
+	LotStatusAwarded  = "Awarded"
+	LotStatusCanceled = "Canceled"
+)
+
+// Lot is an independently awarded part of a tender.
+type Lot struct {
+	ID          uuid.UUID
+	TenderID    uuid.UUID
+	Name        string
+	Description string
+	Budget      *string
+	Status      string
+	WinnerBidID *uuid.UUID
+	DecidedAt   *time.Time
+	CreatedAt   time.Time
+}
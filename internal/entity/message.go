@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message belongs to the thread between a bid author and the responsibles of
+// the tender organization. ReadAt is set once the other side has read it.
+type Message struct {
+	ID        uuid.UUID
+	BidID     uuid.UUID
+	SenderID  uuid.UUID
+	Body      string
+	CreatedAt time.Time
+	ReadAt    *time.Time
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NegotiationOpen     = "Open"
+	NegotiationAccepted = "Accepted"
+	NegotiationRejected = "Rejected"
+	NegotiationRevised  = "Revised"
+)
+
+// NegotiationRound is a counter-proposal of the tender organization on a bid
+// and the bidder's response. BidVersion is the version the proposal was made
+// on, ResponseBidVersion the version the bid had after the response.
+type NegotiationRound struct {
+	ID                 uuid.UUID
+	BidID              uuid.UUID
+	Round              int
+	BidVersion         int
+	ProposedBy         uuid.UUID
+	TargetPrice        *string
+	Comment            string
+	Status             string
+	ResponseComment    *string
+	ResponseBidVersion *int
+	CreatedAt          time.Time
+	RespondedAt        *time.Time
+}
+
+// IsOpen reports whether the round still waits for the bidder.
+func (n *NegotiationRound) IsOpen() bool {
+	return n.Status == NegotiationOpen
+}
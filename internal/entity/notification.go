@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationTenderAmended  = "TenderAmended"
+	NotificationTenderCanceled = "TenderCanceled"
+	NotificationCounterOffer   = "CounterOffer"
+	NotificationCounterReply   = "CounterOfferAnswered"
+)
+
+// Notification tells an employee about an event concerning one of their
+// tenders or bids.
+type Notification struct {
+	ID          uuid.UUID
+	RecipientID uuid.UUID
+	Type        string
+	TenderID    *uuid.UUID
+	BidID       *uuid.UUID
+	Message     string
+	CreatedAt   time.Time
+	ReadAt      *time.Time
+}
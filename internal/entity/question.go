@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Question is a clarification request about a tender. Once answered publicly
+// it becomes part of the tender record at TenderVersion.
+type Question struct {
+	ID            uuid.UUID
+	TenderID      uuid.UUID
+	AuthorID      uuid.UUID
+	Question      string
+	Answer        *string
+	AnsweredBy    *uuid.UUID
+	Visibility    *string
+	TenderVersion *int
+	CreatedAt     time.Time
+	AnsweredAt    *time.Time
+}
+
+// IsPublic reports whether the answer is published to every bidder.
+func (q *Question) IsPublic() bool {
+	return q.Visibility != nil && *q.Visibility == "Public"
+}
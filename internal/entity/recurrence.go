@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SeriesStatusActive = "Active"
+	SeriesStatusPaused = "Paused"
+	SeriesStatusEnded  = "Ended"
+)
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE: FREQ with an
+// optional INTERVAL, ended by COUNT or UNTIL, e.g. "FREQ=MONTHLY;INTERVAL=3".
+type RecurrenceRule struct {
+	Frequency string
+	Interval  int
+	Count     int
+	Until     *time.Time
+}
+
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	r := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, errors.New("INTERVAL must be a positive integer")
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, errors.New("COUNT must be a positive integer")
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, errors.New("UNTIL must be a date like 20251231 or 20251231T235959Z")
+			}
+			r.Until = &until
+		default:
+			return nil, fmt.Errorf("%s is not supported", key)
+		}
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	case "":
+		return nil, errors.New("FREQ is required")
+	default:
+		return nil, errors.New("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be combined")
+	}
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// A date includes its whole day.
+	return until.Add(24*time.Hour - time.Second), nil
+}
+
+// Occurrence returns the n-th occurrence of the rule, counted from zero at
+// start, and false once the rule has ended. Monthly and yearly occurrences
+// fall on the last day of shorter months rather than overflowing into the
+// next one.
+func (r *RecurrenceRule) Occurrence(start time.Time, n int) (time.Time, bool) {
+	if r.Count > 0 && n >= r.Count {
+		return time.Time{}, false
+	}
+
+	var t time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		t = start.AddDate(0, 0, n*r.Interval)
+	case FrequencyWeekly:
+		t = start.AddDate(0, 0, 7*n*r.Interval)
+	case FrequencyMonthly:
+		t = addMonths(start, n*r.Interval)
+	case FrequencyYearly:
+		t = addMonths(start, 12*n*r.Interval)
+	}
+
+	if r.Until != nil && t.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// After returns the index and time of the first occurrence after t, and
+// false when the rule ends before it.
+func (r *RecurrenceRule) After(start, t time.Time) (int, time.Time, bool) {
+	for n := 0; ; n++ {
+		occurrence, ok := r.Occurrence(start, n)
+		if !ok {
+			return 0, time.Time{}, false
+		}
+		if occurrence.After(t) {
+			return n, occurrence, true
+		}
+	}
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// TenderSeries creates a tender from a template of the organization on every
+// occurrence of its recurrence rule, and publishes it when AutoPublish is set.
+type TenderSeries struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	TemplateID     uuid.UUID
+	Rule           string
+	StartsAt       time.Time
+	Values         map[string]string
+	SubmissionDays *int
+	AutoPublish    bool
+	Status         string
+	NextOccurrence int
+	NextRunAt      *time.Time
+	LastError      *string
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SeriesInstance links a tender to the series occurrence it was created for.
+type SeriesInstance struct {
+	SeriesID    uuid.UUID
+	TenderID    uuid.UUID
+	Occurrence  int
+	ScheduledAt time.Time
+	CreatedAt   time.Time
+}
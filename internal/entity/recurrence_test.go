@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	until := func(s string) *time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		want    RecurrenceRule
+		wantErr bool
+	}{
+		{
+			name: "frequency only",
+			rule: "FREQ=WEEKLY",
+			want: RecurrenceRule{Frequency: FrequencyWeekly, Interval: 1},
+		},
+		{
+			name: "prefix, case and spaces",
+			rule: " RRULE:freq=monthly;interval=3 ",
+			want: RecurrenceRule{Frequency: FrequencyMonthly, Interval: 3},
+		},
+		{
+			name: "count",
+			rule: "FREQ=DAILY;COUNT=10",
+			want: RecurrenceRule{Frequency: FrequencyDaily, Interval: 1, Count: 10},
+		},
+		{
+			name: "until timestamp",
+			rule: "FREQ=YEARLY;UNTIL=20251231T120000Z",
+			want: RecurrenceRule{Frequency: FrequencyYearly, Interval: 1, Until: until("2025-12-31T12:00:00Z")},
+		},
+		{
+			name: "until date includes the whole day",
+			rule: "FREQ=DAILY;UNTIL=20251231",
+			want: RecurrenceRule{Frequency: FrequencyDaily, Interval: 1, Until: until("2025-12-31T23:59:59Z")},
+		},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "missing frequency", rule: "INTERVAL=2", wantErr: true},
+		{name: "unknown frequency", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "malformed part", rule: "FREQ=DAILY;COUNT", wantErr: true},
+		{name: "trailing separator", rule: "FREQ=DAILY;", wantErr: true},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "negative count", rule: "FREQ=DAILY;COUNT=-1", wantErr: true},
+		{name: "bad until", rule: "FREQ=DAILY;UNTIL=2025-12-31", wantErr: true},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20251231", wantErr: true},
+		{name: "unsupported part", rule: "FREQ=WEEKLY;BYDAY=MO", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.rule)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRecurrenceRule(%q) = %+v, want an error", tt.rule, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q) error = %v", tt.rule, err)
+			}
+
+			if got.Frequency != tt.want.Frequency || got.Interval != tt.want.Interval || got.Count != tt.want.Count ||
+				(got.Until == nil) != (tt.want.Until == nil) || got.Until != nil && !got.Until.Equal(*tt.want.Until) {
+				t.Errorf("ParseRecurrenceRule(%q) = %+v, want %+v", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleOccurrence(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	until := date(2024, time.March, 31)
+
+	tests := []struct {
+		name   string
+		rule   RecurrenceRule
+		start  time.Time
+		n      int
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "first occurrence is the start",
+			rule:   RecurrenceRule{Frequency: FrequencyDaily, Interval: 1},
+			start:  date(2024, time.January, 10),
+			want:   date(2024, time.January, 10),
+			wantOK: true,
+		},
+		{
+			name:   "daily with interval",
+			rule:   RecurrenceRule{Frequency: FrequencyDaily, Interval: 2},
+			start:  date(2024, time.January, 30),
+			n:      3,
+			want:   date(2024, time.February, 5),
+			wantOK: true,
+		},
+		{
+			name:   "weekly",
+			rule:   RecurrenceRule{Frequency: FrequencyWeekly, Interval: 1},
+			start:  date(2024, time.February, 26),
+			n:      1,
+			want:   date(2024, time.March, 4),
+			wantOK: true,
+		},
+		{
+			name:   "monthly clamps to the last day",
+			rule:   RecurrenceRule{Frequency: FrequencyMonthly, Interval: 1},
+			start:  date(2024, time.January, 31),
+			n:      1,
+			want:   date(2024, time.February, 29),
+			wantOK: true,
+		},
+		{
+			name:   "monthly does not drift after clamping",
+			rule:   RecurrenceRule{Frequency: FrequencyMonthly, Interval: 1},
+			start:  date(2024, time.January, 31),
+			n:      2,
+			want:   date(2024, time.March, 31),
+			wantOK: true,
+		},
+		{
+			name:   "quarterly across a year",
+			rule:   RecurrenceRule{Frequency: FrequencyMonthly, Interval: 3},
+			start:  date(2024, time.November, 15),
+			n:      1,
+			want:   date(2025, time.February, 15),
+			wantOK: true,
+		},
+		{
+			name:   "yearly from a leap day",
+			rule:   RecurrenceRule{Frequency: FrequencyYearly, Interval: 1},
+			start:  date(2024, time.February, 29),
+			n:      1,
+			want:   date(2025, time.February, 28),
+			wantOK: true,
+		},
+		{
+			name:   "last counted occurrence",
+			rule:   RecurrenceRule{Frequency: FrequencyDaily, Interval: 1, Count: 3},
+			start:  date(2024, time.January, 1),
+			n:      2,
+			want:   date(2024, time.January, 3),
+			wantOK: true,
+		},
+		{
+			name:  "past the count",
+			rule:  RecurrenceRule{Frequency: FrequencyDaily, Interval: 1, Count: 3},
+			start: date(2024, time.January, 1),
+			n:     3,
+		},
+		{
+			name:   "on until",
+			rule:   RecurrenceRule{Frequency: FrequencyMonthly, Interval: 1, Until: &until},
+			start:  date(2024, time.January, 31),
+			n:      2,
+			want:   date(2024, time.March, 31),
+			wantOK: true,
+		},
+		{
+			name:  "past until",
+			rule:  RecurrenceRule{Frequency: FrequencyMonthly, Interval: 1, Until: &until},
+			start: date(2024, time.January, 31),
+			n:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.Occurrence(tt.start, tt.n)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Occurrence(%d) = %v, %v, want %v, %v", tt.n, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleAfter(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	rule := RecurrenceRule{Frequency: FrequencyWeekly, Interval: 1, Count: 4}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		wantN  int
+		wantOK bool
+	}{
+		{name: "before the start", t: start.Add(-time.Hour), wantN: 0, wantOK: true},
+		{name: "on an occurrence", t: start, wantN: 1, wantOK: true},
+		{name: "between occurrences", t: start.AddDate(0, 0, 10), wantN: 2, wantOK: true},
+		{name: "after the last occurrence", t: start.AddDate(0, 0, 21)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, next, ok := rule.After(start, tt.t)
+			if ok != tt.wantOK || n != tt.wantN {
+				t.Fatalf("After() = %d, %v, %v, want %d, %v", n, next, ok, tt.wantN, tt.wantOK)
+			}
+			if ok && !next.Equal(start.AddDate(0, 0, 7*tt.wantN)) {
+				t.Errorf("After() = %v, want occurrence %d", next, tt.wantN)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReputationEmployee     = "Employee"
+	ReputationOrganization = "Organization"
+)
+
+// Reputation holds the running counters an employee or an organization is
+// scored on. The counters are updated with deltas as events happen.
+type Reputation struct {
+	SubjectType         string
+	SubjectID           uuid.UUID
+	BidsSubmitted       int
+	BidsWithdrawn       int
+	BidsDecided         int
+	BidsApproved        int
+	RatingsCount        int
+	RatingsSum          int
+	MilestonesCompleted int
+	MilestonesOnTime    int
+	Score               *float64
+	UpdatedAt           time.Time
+}
+
+func ratio(part, total int) *float64 {
+	if total <= 0 {
+		return nil
+	}
+	r := float64(part) / float64(total)
+	return &r
+}
+
+// ApprovalRate is the share of decided bids that were approved.
+func (r *Reputation) ApprovalRate() *float64 {
+	return ratio(r.BidsApproved, r.BidsDecided)
+}
+
+// AverageRating is the mean star rating, from 1 to 5, of the reviews.
+func (r *Reputation) AverageRating() *float64 {
+	return ratio(r.RatingsSum, r.RatingsCount)
+}
+
+// OnTimeRate is the share of completed milestones delivered by their due
+// date.
+func (r *Reputation) OnTimeRate() *float64 {
+	return ratio(r.MilestonesOnTime, r.MilestonesCompleted)
+}
+
+// WithdrawalRate is the number of withdrawals per submitted bid.
+func (r *Reputation) WithdrawalRate() *float64 {
+	return ratio(r.BidsWithdrawn, r.BidsSubmitted)
+}
+
+// ComputeScore weighs the rates known so far into a score from 0 to 100.
+// Approvals, reviews and on-time delivery weigh three times as much as
+// withdrawals. There is no score until one of the rates is known.
+func (r *Reputation) ComputeScore() *float64 {
+	var sum, weights float64
+	add := func(value, weight float64) {
+		sum += weight * value
+		weights += weight
+	}
+
+	if approval := r.ApprovalRate(); approval != nil {
+		add(*approval, 3)
+	}
+	if rating := r.AverageRating(); rating != nil {
+		add((*rating-1)/4, 3)
+	}
+	if onTime := r.OnTimeRate(); onTime != nil {
+		add(*onTime, 3)
+	}
+	if withdrawals := r.WithdrawalRate(); withdrawals != nil {
+		add(1-math.Min(*withdrawals, 1), 1)
+	}
+
+	if weights == 0 {
+		return nil
+	}
+	score := math.Round(100*sum/weights*100) / 100
+	return &score
+}
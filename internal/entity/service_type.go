@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceType is a node of the service type taxonomy. Tenders reference it by
+// name; Code holds the matching CPV or OKPD2 classification code.
+type ServiceType struct {
+	ID        uuid.UUID
+	Name      string
+	Code      string
+	ParentID  *uuid.UUID
+	Labels    map[string]string
+	Active    bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmployeeKey is a public key an employee signs bids with. A revoked key no
+// longer signs new bids, while the signatures it made before stay valid.
+type EmployeeKey struct {
+	ID          uuid.UUID
+	EmployeeID  uuid.UUID
+	Algorithm   string
+	PublicKey   []byte
+	Fingerprint string
+	CreatedAt   time.Time
+	RevokedAt   *time.Time
+}
+
+func (k *EmployeeKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// BidDocument is the content of a bid version a bidder signs.
+type BidDocument struct {
+	AuthorID     uuid.UUID `json:"authorId"`
+	Currency     string    `json:"currency"`
+	DeliveryDays int       `json:"deliveryDays"`
+	Description  string    `json:"description"`
+	Name         string    `json:"name"`
+	Price        string    `json:"price"`
+	TenderID     uuid.UUID `json:"tenderId"`
+	ValidityDays int       `json:"validityDays"`
+	Version      int       `json:"version"`
+}
+
+// Canonical returns the bytes a bid signature is made over: the document as
+// JSON with its keys in alphabetical order, no whitespace and no escaping
+// beyond what JSON requires.
+func (d *BidDocument) Canonical() ([]byte, error) {
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(d); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// BidSignature is a detached signature of a bid version made with a key of
+// the employee who submitted it. Document holds the canonical document as it
+// was signed, encrypted when the bid is sealed.
+type BidSignature struct {
+	ID        uuid.UUID
+	BidID     uuid.UUID
+	Version   int
+	KeyID     uuid.UUID
+	Document  []byte
+	Sealed    bool
+	Signature []byte
+	CreatedAt time.Time
+}
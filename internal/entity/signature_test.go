@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBidDocumentCanonical(t *testing.T) {
+	doc := &BidDocument{
+		AuthorID:     uuid.MustParse("6f9c2a1e-0d4b-4c55-9a0e-1b2c3d4e5f60"),
+		Currency:     "RUB",
+		DeliveryDays: 14,
+		Description:  "Pipes <50mm> & \"fittings\"\nщ",
+		Name:         "Supply",
+		Price:        "1000.50",
+		TenderID:     uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3"),
+		ValidityDays: 30,
+		Version:      2,
+	}
+
+	want := `{"authorId":"6f9c2a1e-0d4b-4c55-9a0e-1b2c3d4e5f60","currency":"RUB","deliveryDays":14,` +
+		`"description":"Pipes <50mm> & \"fittings\"\nщ","name":"Supply","price":"1000.50",` +
+		`"tenderId":"0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3","validityDays":30,"version":2}`
+
+	got, err := doc.Canonical()
+	if err != nil {
+		t.Fatalf("Canonical() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Canonical() =\n%s\nwant\n%s", got, want)
+	}
+}
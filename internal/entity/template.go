@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// placeholderPattern matches the {{name}} placeholders of a template.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// TenderTemplate is a reusable tender of an organization. The texts of its
+// content may hold {{name}} placeholders that are filled in when a tender is
+// created from it.
+type TenderTemplate struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Content        TenderTemplateContent
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+}
+
+type TenderTemplateContent struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	ServiceType string              `json:"serviceType"`
+	Sealed      bool                `json:"sealed"`
+	Budget      string              `json:"budget,omitempty"`
+	Currency    string              `json:"currency,omitempty"`
+	AccessMode  string              `json:"accessMode,omitempty"`
+	Criteria    []TemplateCriterion `json:"criteria,omitempty"`
+	Lots        []TemplateLot       `json:"lots,omitempty"`
+}
+
+type TemplateCriterion struct {
+	Name   string `json:"name"`
+	Weight string `json:"weight"`
+}
+
+type TemplateLot struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Budget      string `json:"budget,omitempty"`
+}
+
+// texts returns the fields of the content that may hold placeholders.
+func (c *TenderTemplateContent) texts() []*string {
+	texts := []*string{&c.Name, &c.Description}
+	for i := range c.Criteria {
+		texts = append(texts, &c.Criteria[i].Name)
+	}
+	for i := range c.Lots {
+		texts = append(texts, &c.Lots[i].Name, &c.Lots[i].Description)
+	}
+	return texts
+}
+
+// Placeholders lists the names of the placeholders of the content.
+func (c TenderTemplateContent) Placeholders() []string {
+	var names []string
+	for _, text := range c.texts() {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(*text, -1) {
+			if !slices.Contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
+// Fill returns the content with its placeholders replaced by the values,
+// together with the names of the placeholders that have no value.
+func (c TenderTemplateContent) Fill(values map[string]string) (TenderTemplateContent, []string) {
+	filled := c
+	filled.Criteria = slices.Clone(c.Criteria)
+	filled.Lots = slices.Clone(c.Lots)
+
+	var missing []string
+	for _, text := range filled.texts() {
+		*text = placeholderPattern.ReplaceAllStringFunc(*text, func(placeholder string) string {
+			name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+			value, ok := values[name]
+			if !ok {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+				return placeholder
+			}
+			return value
+		})
+	}
+	return filled, missing
+}
@@ -6,14 +6,113 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	TenderStatusPublished = "Published"
+	TenderStatusClosed    = "Closed"
+	TenderStatusCanceled  = "Canceled"
+)
+
+const (
+	TenderAccessOpen           = "Open"
+	TenderAccessInvitationOnly = "InvitationOnly"
+)
+
+// Reason categories of a tender cancellation.
+const (
+	CancellationNoSuitableBids  = "NoSuitableBids"
+	CancellationBudgetWithdrawn = "BudgetWithdrawn"
+	CancellationNeedChanged     = "NeedChanged"
+	CancellationProcedureFlawed = "ProcedureFlawed"
+	CancellationOther           = "Other"
+)
+
+var CancellationCategories = []string{
+	CancellationNoSuitableBids,
+	CancellationBudgetWithdrawn,
+	CancellationNeedChanged,
+	CancellationProcedureFlawed,
+	CancellationOther,
+}
+
 type Tender struct {
-	ID              uuid.UUID
-	Name            string
-	Description     string
-	ServiceType     string
-	Status          string
-	Version         int
-	OrganizationID  uuid.UUID
-	CreatorUsername string
-	CreatedAt       time.Time
+	ID                   uuid.UUID
+	Name                 string
+	Description          string
+	ServiceType          string
+	Status               string
+	Version              int
+	OrganizationID       uuid.UUID
+	CreatorUsername      string
+	Sealed               bool
+	SubmissionDeadline   *time.Time
+	OpenedAt             *time.Time
+	Budget               *string
+	Currency             *string
+	AuctionStartsAt      *time.Time
+	AuctionEndsAt        *time.Time
+	AccessMode           string
+	CancellationCategory *string
+	CancellationReason   *string
+	CanceledAt           *time.Time
+	CreatedAt            time.Time
+}
+
+// IsCanceled reports whether the procurement was called off.
+func (t *Tender) IsCanceled() bool {
+	return t.Status == TenderStatusCanceled
+}
+
+// IsInvitationOnly reports whether only invited bidders may see and bid on the tender.
+func (t *Tender) IsInvitationOnly() bool {
+	return t.AccessMode == TenderAccessInvitationOnly
+}
+
+// IsSealed reports whether bid contents must still be hidden.
+func (t *Tender) IsSealed() bool {
+	return t.Sealed && t.OpenedAt == nil
+}
+
+// SubmissionClosed reports whether the submission deadline has passed. The
+// end of a reverse auction closes submission as well.
+func (t *Tender) SubmissionClosed(now time.Time) bool {
+	return (t.SubmissionDeadline != nil && !now.Before(*t.SubmissionDeadline)) || t.AuctionFinished(now)
+}
+
+// IsAuction reports whether the tender is a reverse auction.
+func (t *Tender) IsAuction() bool {
+	return t.AuctionStartsAt != nil && t.AuctionEndsAt != nil
+}
+
+// AuctionRunning reports whether bidders may lower their prices right now.
+func (t *Tender) AuctionRunning(now time.Time) bool {
+	return t.IsAuction() && !now.Before(*t.AuctionStartsAt) && now.Before(*t.AuctionEndsAt)
+}
+
+// AuctionFinished reports whether the auction window, extensions included, is over.
+func (t *Tender) AuctionFinished(now time.Time) bool {
+	return t.IsAuction() && !now.Before(*t.AuctionEndsAt)
+}
+
+// TenderAmendment records an edit of a published tender. Changes maps each
+// edited column to its old and new value.
+type TenderAmendment struct {
+	ID        uuid.UUID
+	TenderID  uuid.UUID
+	Version   int
+	Reason    string
+	Changes   map[string]AmendmentChange
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+type AmendmentChange struct {
+	Old *string `json:"old"`
+	New *string `json:"new"`
+}
+
+type TenderOpening struct {
+	ID       uuid.UUID
+	TenderID uuid.UUID
+	BidCount int
+	OpenedAt time.Time
 }
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"context"
+	"strings"
+)
+
+// maxRequiredApprovals bounds the length of an approval chain.
+const maxRequiredApprovals = 10
+
+type ApprovalPolicy struct {
+	Name              string `json:"name"`
+	MinBudget         string `json:"minBudget,omitempty"`
+	RequiredApprovals int    `json:"requiredApprovals"`
+}
+
+type ApprovalDecision struct {
+	Decision string `json:"decision"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+func (p ApprovalPolicy) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(p.Name) == "" {
+		problems["name"] = "Name is required"
+	} else if len([]rune(p.Name)) > 100 {
+		problems["name"] = "Name cannot be longer than 100 characters"
+	}
+
+	if p.MinBudget != "" && !ValidAmount(p.MinBudget) {
+		problems["minBudget"] = "Minimum budget must be a positive decimal with at most 2 fraction digits"
+	}
+
+	if p.RequiredApprovals < 1 || p.RequiredApprovals > maxRequiredApprovals {
+		problems["requiredApprovals"] = "Required approvals must be between 1 and 10"
+	}
+
+	return problems
+}
+
+func (d ApprovalDecision) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if d.Decision != "Approved" && d.Decision != "Rejected" {
+		problems["decision"] = "Decision must be Approved or Rejected"
+	}
+	if len([]rune(d.Comment)) > 1000 {
+		problems["comment"] = "Comment cannot be longer than 1000 characters"
+	}
+
+	return problems
+}
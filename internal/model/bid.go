@@ -1,11 +1,43 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 type Bid struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	TenderID    uuid.UUID `json:"tenderId"`
-	AuthorType  string    `json:"authorType"`
-	AuthorID    uuid.UUID `json:"authorId"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	TenderID     uuid.UUID   `json:"tenderId"`
+	AuthorType   string      `json:"authorType"`
+	AuthorID     uuid.UUID   `json:"authorId"`
+	Price        string      `json:"price"`
+	Currency     string      `json:"currency"`
+	DeliveryDays int         `json:"deliveryDays"`
+	ValidityDays int         `json:"validityDays"`
+	LotIDs       []uuid.UUID `json:"lotIds,omitempty"`
+	// Signature optionally signs the canonical document of the bid.
+	Signature *BidSignature `json:"signature,omitempty"`
+}
+
+// BidSignature is a detached signature, base64 encoded, made with a key the
+// submitting employee registered.
+type BidSignature struct {
+	KeyID     uuid.UUID `json:"keyId"`
+	Signature []byte    `json:"signature"`
+}
+
+func (s *BidSignature) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if s.KeyID == uuid.Nil {
+		problems["signature.keyId"] = "Key ID is required"
+	}
+
+	if len(s.Signature) == 0 {
+		problems["signature.signature"] = "Signature is required"
+	}
+
+	return problems
 }
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const maxDeclarationDescriptionLength = 1000
+
+var relationships = []string{"FormerEmployer", "Shareholder", "Family", "Other"}
+
+type ConflictDeclaration struct {
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Relationship   string    `json:"relationship"`
+	Description    string    `json:"description,omitempty"`
+}
+
+func (d ConflictDeclaration) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if d.OrganizationID == uuid.Nil {
+		problems["organizationId"] = "Organization ID is required"
+	}
+
+	if d.Relationship == "" {
+		problems["relationship"] = "Relationship is required"
+	} else if !slices.Contains(relationships, d.Relationship) {
+		problems["relationship"] = "Relationship must be one of FormerEmployer, Shareholder, Family, Other"
+	}
+
+	if len([]rune(d.Description)) > maxDeclarationDescriptionLength {
+		problems["description"] = "Description cannot be longer than 1000 characters"
+	}
+
+	return problems
+}
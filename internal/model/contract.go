@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type Milestone struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	DueDate     *time.Time `json:"dueDate"`
+}
+
+type MilestoneConfirmation struct {
+	Side string `json:"side"`
+}
+
+func (m Milestone) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(m.Name) == "" {
+		problems["name"] = "Name is required"
+	} else if len([]rune(m.Name)) > 100 {
+		problems["name"] = "Name cannot be longer than 100 characters"
+	}
+	if len([]rune(m.Description)) > 500 {
+		problems["description"] = "Description cannot be longer than 500 characters"
+	}
+	if m.DueDate == nil {
+		problems["dueDate"] = "Due date is required"
+	}
+
+	return problems
+}
+
+func (c MilestoneConfirmation) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if c.Side != "Supplier" && c.Side != "Buyer" {
+		problems["side"] = "Side must be Supplier or Buyer"
+	}
+
+	return problems
+}
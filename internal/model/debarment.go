@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxDebarmentReasonLength bounds the recorded reason of a debarment.
+const maxDebarmentReasonLength = 2000
+
+type Debarment struct {
+	OrganizationID      *uuid.UUID `json:"organizationId,omitempty"`
+	EmployeeID          *uuid.UUID `json:"employeeId,omitempty"`
+	BuyerOrganizationID *uuid.UUID `json:"buyerOrganizationId,omitempty"`
+	Reason              string     `json:"reason"`
+	ExpiresAt           *time.Time `json:"expiresAt,omitempty"`
+}
+
+type DebarmentUpdate struct {
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (d Debarment) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if (d.OrganizationID == nil) == (d.EmployeeID == nil) {
+		problems["organizationId"] = "Either an organization or an employee must be debarred"
+	}
+
+	if strings.TrimSpace(d.Reason) == "" {
+		problems["reason"] = "Reason is required"
+	} else if len([]rune(d.Reason)) > maxDebarmentReasonLength {
+		problems["reason"] = "Reason cannot be longer than 2000 characters"
+	}
+
+	if d.ExpiresAt != nil && !d.ExpiresAt.After(time.Now()) {
+		problems["expiresAt"] = "Expiry date must be in the future"
+	}
+
+	return problems
+}
+
+func (d DebarmentUpdate) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if d.Reason == "" && d.ExpiresAt == nil {
+		problems["reason"] = "Reason or expiry date must be changed"
+	}
+	if len([]rune(d.Reason)) > maxDebarmentReasonLength {
+		problems["reason"] = "Reason cannot be longer than 2000 characters"
+	}
+	if d.ExpiresAt != nil && !d.ExpiresAt.After(time.Now()) {
+		problems["expiresAt"] = "Expiry date must be in the future"
+	}
+
+	return problems
+}
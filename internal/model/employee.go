@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+)
+
+// EmployeeKey is a public key to register, PEM encoded or the standard
+// base64 encoding of its DER form.
+type EmployeeKey struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// Bytes returns the PEM text as is, or the decoded DER form.
+func (k EmployeeKey) Bytes() ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(k.PublicKey), "-----BEGIN") {
+		return []byte(k.PublicKey), nil
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(k.PublicKey))
+}
+
+func (k EmployeeKey) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if k.PublicKey == "" {
+		problems["publicKey"] = "Public key is required"
+	} else if _, err := k.Bytes(); err != nil {
+		problems["publicKey"] = "Public key must be PEM or base64 encoded"
+	}
+
+	return problems
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+type BidScore struct {
+	CriterionID uuid.UUID   `json:"criterionId"`
+	Score       json.Number `json:"score"`
+}
+
+type BidScores struct {
+	Scores []BidScore `json:"scores"`
+}
+
+func (s BidScores) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if len(s.Scores) == 0 {
+		problems["scores"] = "At least one score is required"
+	}
+
+	seen := make(map[uuid.UUID]bool, len(s.Scores))
+	for _, score := range s.Scores {
+		if score.CriterionID == uuid.Nil {
+			problems["criterionId"] = "Criterion ID is required"
+		} else if seen[score.CriterionID] {
+			problems["criterionId"] = "Each criterion can be scored only once per request"
+		}
+		seen[score.CriterionID] = true
+
+		if !ValidPercent(score.Score.String(), true) {
+			problems["score"] = "Score must be a decimal between 0 and 100"
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"context"
+	"strings"
+)
+
+const maxMessageLength = 5000
+
+type Message struct {
+	Message string `json:"message"`
+}
+
+func (m Message) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(m.Message) == "" {
+		problems["message"] = "Message is required"
+	} else if len([]rune(m.Message)) > maxMessageLength {
+		problems["message"] = "Message cannot be longer than 5000 characters"
+	}
+
+	return problems
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"context"
+	"strings"
+)
+
+// maxNegotiationCommentLength bounds counter-offer comments and responses.
+const maxNegotiationCommentLength = 2000
+
+type CounterOffer struct {
+	TargetPrice string `json:"targetPrice,omitempty"`
+	Comment     string `json:"comment"`
+}
+
+type CounterOfferResponse struct {
+	Response     string `json:"response"`
+	Comment      string `json:"comment,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Price        string `json:"price,omitempty"`
+	DeliveryDays int    `json:"deliveryDays,omitempty"`
+	ValidityDays int    `json:"validityDays,omitempty"`
+}
+
+func (c CounterOffer) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if c.TargetPrice != "" && !ValidAmount(c.TargetPrice) {
+		problems["targetPrice"] = "Target price must be a positive decimal with at most 2 fraction digits"
+	}
+
+	if strings.TrimSpace(c.Comment) == "" {
+		problems["comment"] = "Comment is required"
+	} else if len([]rune(c.Comment)) > maxNegotiationCommentLength {
+		problems["comment"] = "Comment cannot be longer than 2000 characters"
+	}
+
+	return problems
+}
+
+func (r CounterOfferResponse) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	switch r.Response {
+	case "Accepted", "Rejected":
+	case "Revised":
+		if r.Name == "" && r.Description == "" && r.Price == "" && r.DeliveryDays == 0 && r.ValidityDays == 0 {
+			problems["response"] = "A revision must change the name, description, price, delivery time or validity period"
+		}
+	default:
+		problems["response"] = "Response must be Accepted, Rejected or Revised"
+	}
+
+	if len([]rune(r.Comment)) > maxNegotiationCommentLength {
+		problems["comment"] = "Comment cannot be longer than 2000 characters"
+	}
+	if len([]rune(r.Name)) > 50 {
+		problems["name"] = "Name cannot be longer than 50 characters"
+	}
+	if r.Price != "" && !ValidAmount(r.Price) {
+		problems["price"] = "Price must be a positive decimal with at most 2 fraction digits"
+	}
+	if r.DeliveryDays < 0 || r.ValidityDays < 0 {
+		problems["deliveryDays"] = "Delivery time and validity period must be positive"
+	}
+
+	return problems
+}
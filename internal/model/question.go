@@ -0,0 +1,46 @@
+package model
+
+import (
+	"context"
+	"strings"
+)
+
+// maxQuestionLength bounds questions and answers alike.
+const maxQuestionLength = 5000
+
+type Question struct {
+	Question string `json:"question"`
+}
+
+type QuestionAnswer struct {
+	Answer     string `json:"answer"`
+	Visibility string `json:"visibility"`
+}
+
+func (q Question) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(q.Question) == "" {
+		problems["question"] = "Question is required"
+	} else if len([]rune(q.Question)) > maxQuestionLength {
+		problems["question"] = "Question cannot be longer than 5000 characters"
+	}
+
+	return problems
+}
+
+func (a QuestionAnswer) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(a.Answer) == "" {
+		problems["answer"] = "Answer is required"
+	} else if len([]rune(a.Answer)) > maxQuestionLength {
+		problems["answer"] = "Answer cannot be longer than 5000 characters"
+	}
+
+	if a.Visibility != "Public" && a.Visibility != "Private" {
+		problems["visibility"] = "Visibility must be Public or Private"
+	}
+
+	return problems
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// maxSubmissionDays bounds the submission period of the tenders of a series.
+const maxSubmissionDays = 365
+
+// TenderSeries starts a recurring tender from a template. Rule is an RRULE
+// subset such as "FREQ=MONTHLY;INTERVAL=3;COUNT=8".
+type TenderSeries struct {
+	Rule           string            `json:"rule"`
+	StartsAt       *time.Time        `json:"startsAt"`
+	Values         map[string]string `json:"values,omitempty"`
+	SubmissionDays *int              `json:"submissionDays,omitempty"`
+	AutoPublish    bool              `json:"autoPublish"`
+}
+
+type SeriesUpdate struct {
+	Rule           *string           `json:"rule,omitempty"`
+	StartsAt       *time.Time        `json:"startsAt,omitempty"`
+	Values         map[string]string `json:"values,omitempty"`
+	SubmissionDays *int              `json:"submissionDays,omitempty"`
+	AutoPublish    *bool             `json:"autoPublish,omitempty"`
+}
+
+func (s TenderSeries) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	validateRule(s.Rule, problems)
+	if s.StartsAt == nil {
+		problems["startsAt"] = "Start is required"
+	}
+	validateSubmissionDays(s.SubmissionDays, problems)
+
+	return problems
+}
+
+func (s SeriesUpdate) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if s.Rule != nil {
+		validateRule(*s.Rule, problems)
+	}
+	validateSubmissionDays(s.SubmissionDays, problems)
+
+	return problems
+}
+
+func validateRule(rule string, problems map[string]string) {
+	if strings.TrimSpace(rule) == "" {
+		problems["rule"] = "Rule is required"
+	} else if len(rule) > 200 {
+		problems["rule"] = "Rule cannot be longer than 200 characters"
+	}
+}
+
+func validateSubmissionDays(days *int, problems map[string]string) {
+	if days != nil && (*days < 1 || *days > maxSubmissionDays) {
+		problems["submissionDays"] = "Submission days must be between 1 and 365"
+	}
+}
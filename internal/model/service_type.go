@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// serviceTypeCodePattern accepts CPV codes (45000000-7) and OKPD2 codes (49.41.1).
+	serviceTypeCodePattern = regexp.MustCompile(`^(\d{8}-\d|\d{2}(\.\d{1,3}){0,3})$`)
+	languagePattern        = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+type ServiceType struct {
+	Name     string            `json:"name"`
+	Code     string            `json:"code"`
+	ParentID *uuid.UUID        `json:"parentId,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+type ServiceTypeUpdate struct {
+	Name     string            `json:"name"`
+	Code     string            `json:"code"`
+	ParentID *uuid.UUID        `json:"parentId,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Active   *bool             `json:"active,omitempty"`
+}
+
+func (s ServiceType) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if s.Name == "" {
+		problems["name"] = "Name is required"
+	} else if len([]rune(s.Name)) > 50 {
+		problems["name"] = "Name cannot be longer than 50 characters"
+	}
+
+	if s.Code == "" {
+		problems["code"] = "Code is required"
+	} else if !serviceTypeCodePattern.MatchString(s.Code) {
+		problems["code"] = "Code must be a CPV (45000000-7) or OKPD2 (49.41.1) code"
+	}
+
+	validateLabels(s.Labels, problems)
+
+	return problems
+}
+
+func (s ServiceTypeUpdate) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if len([]rune(s.Name)) > 50 {
+		problems["name"] = "Name cannot be longer than 50 characters"
+	}
+
+	if s.Code != "" && !serviceTypeCodePattern.MatchString(s.Code) {
+		problems["code"] = "Code must be a CPV (45000000-7) or OKPD2 (49.41.1) code"
+	}
+
+	validateLabels(s.Labels, problems)
+
+	return problems
+}
+
+func validateLabels(labels map[string]string, problems map[string]string) {
+	for lang, label := range labels {
+		if !languagePattern.MatchString(lang) {
+			problems["labels"] = "Label languages must be 2-letter ISO 639-1 codes"
+			return
+		}
+		if label == "" || len([]rune(label)) > 100 {
+			problems["labels"] = "Labels must be between 1 and 100 characters long"
+			return
+		}
+	}
+}
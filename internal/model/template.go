@@ -0,0 +1,134 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// TenderTemplate is a reusable tender of an organization. Its texts may hold
+// {{name}} placeholders, so the limits on their length are only checked once
+// a tender is created from it.
+type TenderTemplate struct {
+	Name   string         `json:"name"`
+	Tender TemplateTender `json:"tender"`
+}
+
+type TemplateTender struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	ServiceType string      `json:"serviceType"`
+	Sealed      bool        `json:"sealed"`
+	Budget      string      `json:"budget,omitempty"`
+	Currency    string      `json:"currency,omitempty"`
+	AccessMode  string      `json:"accessMode,omitempty"`
+	Criteria    []Criterion `json:"criteria,omitempty"`
+	Lots        []Lot       `json:"lots,omitempty"`
+}
+
+// TemplateInstance gives the placeholder values and the schedule of a
+// tender created from a template.
+type TemplateInstance struct {
+	Values             map[string]string `json:"values,omitempty"`
+	SubmissionDeadline *time.Time        `json:"submissionDeadline,omitempty"`
+	AuctionStartsAt    *time.Time        `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt      *time.Time        `json:"auctionEndsAt,omitempty"`
+}
+
+// TenderClone overrides the name and gives the schedule of a cloned tender.
+type TenderClone struct {
+	Name               string     `json:"name,omitempty"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	AuctionStartsAt    *time.Time `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt      *time.Time `json:"auctionEndsAt,omitempty"`
+}
+
+func (t TenderTemplate) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if strings.TrimSpace(t.Name) == "" {
+		problems["name"] = "Name is required"
+	} else if len([]rune(t.Name)) > 100 {
+		problems["name"] = "Name cannot be longer than 100 characters"
+	}
+
+	if t.Tender.Name == "" {
+		problems["tender.name"] = "Tender name is required"
+	}
+	if t.Tender.Description == "" {
+		problems["tender.description"] = "Tender description is required"
+	}
+	if t.Tender.ServiceType == "" {
+		problems["tender.serviceType"] = "Service type is required"
+	} else if len([]rune(t.Tender.ServiceType)) > 50 {
+		problems["tender.serviceType"] = "Invalid service type"
+	}
+
+	if t.Tender.Budget != "" && !ValidAmount(t.Tender.Budget) {
+		problems["tender.budget"] = "Budget must be a positive decimal with at most 2 fraction digits"
+	}
+	if t.Tender.Currency != "" && !ValidCurrency(t.Tender.Currency) {
+		problems["tender.currency"] = "Currency must be a 3-letter ISO 4217 code"
+	} else if t.Tender.Budget != "" && t.Tender.Currency == "" {
+		problems["tender.currency"] = "Currency is required when budget is set"
+	}
+
+	if len(t.Tender.Criteria) > 0 {
+		criteria := make(map[string]string)
+		validateCriteria(t.Tender.Criteria, criteria)
+		if problem, ok := criteria["criteria"]; ok {
+			problems["tender.criteria"] = problem
+		}
+	}
+
+	for _, l := range t.Tender.Lots {
+		if l.Name == "" || l.Description == "" {
+			problems["tender.lots"] = "Lot name and description are required"
+		} else if l.Budget != "" && !ValidAmount(l.Budget) {
+			problems["tender.lots"] = "Lot budget must be a positive decimal with at most 2 fraction digits"
+		}
+	}
+
+	if t.Tender.AccessMode != "" && t.Tender.AccessMode != "Open" && t.Tender.AccessMode != "InvitationOnly" {
+		problems["tender.accessMode"] = "Access mode must be Open or InvitationOnly"
+	}
+
+	return problems
+}
+
+func (i TemplateInstance) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	for name := range i.Values {
+		if strings.TrimSpace(name) == "" {
+			problems["values"] = "Placeholder names cannot be empty"
+		}
+	}
+
+	return problems
+}
+
+func (c TenderClone) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if len([]rune(c.Name)) > 50 {
+		problems["name"] = "Name cannot be longer than 50 characters"
+	}
+
+	if c.SubmissionDeadline != nil && !c.SubmissionDeadline.After(time.Now()) {
+		problems["submissionDeadline"] = "Submission deadline must be in the future"
+	}
+
+	if c.AuctionStartsAt != nil || c.AuctionEndsAt != nil {
+		switch {
+		case c.AuctionStartsAt == nil || c.AuctionEndsAt == nil:
+			problems["auctionEndsAt"] = "Auction start and end are both required"
+		case !c.AuctionEndsAt.After(*c.AuctionStartsAt):
+			problems["auctionEndsAt"] = "Auction end must be after its start"
+		case !c.AuctionEndsAt.After(time.Now()):
+			problems["auctionEndsAt"] = "Auction end must be in the future"
+		}
+	}
+
+	return problems
+}
@@ -1,9 +1,87 @@
 package model
 
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"github.com/google/uuid"
+)
+
 type Tender struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	ServiceType     string `json:"serviceType"`
-	OrganizationID  string `json:"organizationId"`
-	CreatorUsername string `json:"creatorUsername"`
+	Name               string      `json:"name"`
+	Description        string      `json:"description"`
+	ServiceType        string      `json:"serviceType"`
+	OrganizationID     string      `json:"organizationId"`
+	CreatorUsername    string      `json:"creatorUsername"`
+	Sealed             bool        `json:"sealed"`
+	SubmissionDeadline *time.Time  `json:"submissionDeadline"`
+	Budget             string      `json:"budget,omitempty"`
+	Currency           string      `json:"currency,omitempty"`
+	Criteria           []Criterion `json:"criteria,omitempty"`
+	Lots               []Lot       `json:"lots,omitempty"`
+	AuctionStartsAt    *time.Time  `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt      *time.Time  `json:"auctionEndsAt,omitempty"`
+	AccessMode         string      `json:"accessMode,omitempty"`
+}
+
+type Invitations struct {
+	OrganizationIDs []uuid.UUID `json:"organizationIds,omitempty"`
+	EmployeeIDs     []uuid.UUID `json:"employeeIds,omitempty"`
+}
+
+func (i Invitations) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if len(i.OrganizationIDs) == 0 && len(i.EmployeeIDs) == 0 {
+		problems["invitations"] = "At least one organization or employee is required"
+	}
+	if slices.Contains(i.OrganizationIDs, uuid.Nil) {
+		problems["organizationIds"] = "Organization ID cannot be empty"
+	}
+	if slices.Contains(i.EmployeeIDs, uuid.Nil) {
+		problems["employeeIds"] = "Employee ID cannot be empty"
+	}
+
+	return problems
+}
+
+type Criterion struct {
+	Name   string      `json:"name"`
+	Weight json.Number `json:"weight"`
+}
+
+type Lot struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Budget      string `json:"budget,omitempty"`
+}
+
+// maxCancellationReasonLength bounds the free text of a cancellation.
+const maxCancellationReasonLength = 2000
+
+type TenderCancellation struct {
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+func (c TenderCancellation) Valid(ctx context.Context) map[string]string {
+	problems := make(map[string]string)
+
+	if c.Category == "" {
+		problems["category"] = "Category is required"
+	} else if !slices.Contains(entity.CancellationCategories, c.Category) {
+		problems["category"] = "Category must be one of " + strings.Join(entity.CancellationCategories, ", ")
+	}
+
+	if strings.TrimSpace(c.Reason) == "" {
+		problems["reason"] = "Reason is required"
+	} else if len([]rune(c.Reason)) > maxCancellationReasonLength {
+		problems["reason"] = "Reason cannot be longer than 2000 characters"
+	}
+
+	return problems
 }
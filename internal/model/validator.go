@@ -2,10 +2,18 @@ package model
 
 import (
 	"context"
+	"math/big"
+	"regexp"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	amountPattern   = regexp.MustCompile(`^\d{1,16}(\.\d{1,2})?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
 type Validator interface {
 	Valid(ctx context.Context) map[string]string
 }
@@ -25,23 +33,83 @@ func (t Tender) Valid(ctx context.Context) map[string]string {
 
 	if t.ServiceType == "" {
 		problems["serviceType"] = "Service type is required"
-	} else if _, valid := validateServiceType(t.ServiceType); !valid {
+	} else if len([]rune(t.ServiceType)) > 50 {
 		problems["serviceType"] = "Invalid service type"
 	}
 
+	if t.Sealed && t.SubmissionDeadline == nil {
+		problems["submissionDeadline"] = "Submission deadline is required for sealed tenders"
+	} else if t.SubmissionDeadline != nil && !t.SubmissionDeadline.After(time.Now()) {
+		problems["submissionDeadline"] = "Submission deadline must be in the future"
+	}
+
+	if t.Budget != "" && !ValidAmount(t.Budget) {
+		problems["budget"] = "Budget must be a positive decimal with at most 2 fraction digits"
+	}
+
+	if t.Currency != "" && !ValidCurrency(t.Currency) {
+		problems["currency"] = "Currency must be a 3-letter ISO 4217 code"
+	} else if t.Budget != "" && t.Currency == "" {
+		problems["currency"] = "Currency is required when budget is set"
+	}
+
+	if len(t.Criteria) > 0 {
+		validateCriteria(t.Criteria, problems)
+	}
+
+	for _, l := range t.Lots {
+		if l.Name == "" {
+			problems["lots"] = "Lot name is required"
+		} else if len([]rune(l.Name)) > 50 {
+			problems["lots"] = "Lot name cannot be longer than 50 characters"
+		} else if l.Description == "" {
+			problems["lots"] = "Lot description is required"
+		} else if l.Budget != "" && !ValidAmount(l.Budget) {
+			problems["lots"] = "Lot budget must be a positive decimal with at most 2 fraction digits"
+		} else if l.Budget != "" && t.Currency == "" {
+			problems["currency"] = "Currency is required when budget is set"
+		}
+	}
+
+	if t.AuctionStartsAt != nil || t.AuctionEndsAt != nil {
+		validateAuction(t, problems)
+	}
+
+	if t.AccessMode != "" && t.AccessMode != "Open" && t.AccessMode != "InvitationOnly" {
+		problems["accessMode"] = "Access mode must be Open or InvitationOnly"
+	}
+
 	return problems
 }
-func validateServiceType(serviceType string) (string, bool) {
-	validServiceTypes := map[string]bool{
-		"Construction": true,
-		"Delivery":     true,
-		"Manufacture":  true,
+
+// validateAuction checks the settings of a reverse auction tender, which is
+// ranked by price only. The service type is checked against the taxonomy by
+// the tender service.
+func validateAuction(t Tender, problems map[string]string) {
+	switch {
+	case t.AuctionStartsAt == nil || t.AuctionEndsAt == nil:
+		problems["auctionEndsAt"] = "Auction start and end are both required"
+	case !t.AuctionEndsAt.After(*t.AuctionStartsAt):
+		problems["auctionEndsAt"] = "Auction end must be after its start"
+	case !t.AuctionEndsAt.After(time.Now()):
+		problems["auctionEndsAt"] = "Auction end must be in the future"
 	}
 
-	if valid, ok := validServiceTypes[serviceType]; ok && valid {
-		return serviceType, true
+	if t.Currency == "" {
+		problems["currency"] = "Currency is required for a reverse auction"
+	}
+	if t.Sealed {
+		problems["sealed"] = "A reverse auction cannot be sealed"
+	}
+	if t.SubmissionDeadline != nil {
+		problems["submissionDeadline"] = "The auction end is the submission deadline of a reverse auction"
+	}
+	if len(t.Criteria) > 0 {
+		problems["criteria"] = "Reverse auctions are ranked by price only"
+	}
+	if len(t.Lots) > 0 {
+		problems["lots"] = "Reverse auctions cannot be split into lots"
 	}
-	return "", false
 }
 
 func (b *Bid) Valid(ctx context.Context) map[string]string {
@@ -71,9 +139,103 @@ func (b *Bid) Valid(ctx context.Context) map[string]string {
 		problems["authorId"] = "Author ID is required"
 	}
 
+	if b.Price == "" {
+		problems["price"] = "Price is required"
+	} else if !ValidAmount(b.Price) {
+		problems["price"] = "Price must be a positive decimal with at most 2 fraction digits"
+	}
+
+	if b.Currency == "" {
+		problems["currency"] = "Currency is required"
+	} else if !ValidCurrency(b.Currency) {
+		problems["currency"] = "Currency must be a 3-letter ISO 4217 code"
+	}
+
+	if b.DeliveryDays <= 0 {
+		problems["deliveryDays"] = "Delivery time must be a positive number of days"
+	}
+
+	if b.ValidityDays <= 0 {
+		problems["validityDays"] = "Validity period must be a positive number of days"
+	}
+
+	lots := make(map[uuid.UUID]bool, len(b.LotIDs))
+	for _, lotID := range b.LotIDs {
+		if lotID == uuid.Nil || lots[lotID] {
+			problems["lotIds"] = "Lot IDs must be unique and non-empty"
+		}
+		lots[lotID] = true
+	}
+
+	if b.Signature != nil {
+		for field, problem := range b.Signature.Valid(ctx) {
+			problems[field] = problem
+		}
+	}
+
 	return problems
 }
 
+// ValidAmount reports whether amount is a positive exact decimal that fits NUMERIC(18, 2).
+func ValidAmount(amount string) bool {
+	if !amountPattern.MatchString(amount) {
+		return false
+	}
+
+	r, ok := new(big.Rat).SetString(amount)
+	return ok && r.Sign() > 0
+}
+
+// ValidPercent reports whether value is a decimal in the (0, 100] range, or
+// [0, 100] when zero is allowed, with at most 2 fraction digits.
+func ValidPercent(value string, allowZero bool) bool {
+	if !amountPattern.MatchString(value) {
+		return false
+	}
+
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || r.Cmp(big.NewRat(100, 1)) > 0 {
+		return false
+	}
+	return r.Sign() > 0 || (allowZero && r.Sign() == 0)
+}
+
+func validateCriteria(criteria []Criterion, problems map[string]string) {
+	total := new(big.Rat)
+	names := make(map[string]bool, len(criteria))
+
+	for _, c := range criteria {
+		if c.Name == "" {
+			problems["criteria"] = "Criterion name is required"
+			return
+		}
+		if len([]rune(c.Name)) > 50 {
+			problems["criteria"] = "Criterion name cannot be longer than 50 characters"
+			return
+		}
+		if names[c.Name] {
+			problems["criteria"] = "Criterion names must be unique"
+			return
+		}
+		names[c.Name] = true
+
+		if !ValidPercent(c.Weight.String(), false) {
+			problems["criteria"] = "Criterion weight must be a decimal between 0 and 100"
+			return
+		}
+		weight, _ := new(big.Rat).SetString(c.Weight.String())
+		total.Add(total, weight)
+	}
+
+	if total.Cmp(big.NewRat(100, 1)) != 0 {
+		problems["criteria"] = "Criterion weights must add up to 100"
+	}
+}
+
+func ValidCurrency(currency string) bool {
+	return currencyPattern.MatchString(currency)
+}
+
 func validateAuthorType(authorType string) bool {
 	validAuthorTypes := map[string]bool{
 		"Organization": true,
@@ -0,0 +1,341 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var approvalPolicyColumns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"min_budget",
+	"required_approvals",
+	"created_by",
+	"created_at",
+}
+
+var approvalRequestColumns = []string{
+	"id",
+	"tender_id",
+	"policy_id",
+	"required_approvals",
+	"requested_by",
+	"status",
+	"created_at",
+	"decided_at",
+}
+
+var approvalRequestReturning = "RETURNING " + strings.Join(approvalRequestColumns, ", ")
+
+var approvalStepColumns = []string{
+	"id",
+	"request_id",
+	"approver_id",
+	"decision",
+	"comment",
+	"created_at",
+}
+
+func scanApprovalPolicy(row pgx.Row) (*entity.ApprovalPolicy, error) {
+	var p entity.ApprovalPolicy
+	err := row.Scan(
+		&p.ID,
+		&p.OrganizationID,
+		&p.Name,
+		&p.MinBudget,
+		&p.RequiredApprovals,
+		&p.CreatedBy,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanApprovalRequest(row pgx.Row) (*entity.ApprovalRequest, error) {
+	var r entity.ApprovalRequest
+	err := row.Scan(
+		&r.ID,
+		&r.TenderID,
+		&r.PolicyID,
+		&r.RequiredApprovals,
+		&r.RequestedBy,
+		&r.Status,
+		&r.CreatedAt,
+		&r.DecidedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func scanApprovalStep(row pgx.Row) (*entity.ApprovalStep, error) {
+	var s entity.ApprovalStep
+	err := row.Scan(
+		&s.ID,
+		&s.RequestID,
+		&s.ApproverID,
+		&s.Decision,
+		&s.Comment,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+type ApprovalRepo struct {
+	*postgres.Postgres
+}
+
+func NewApprovalRepo(pg *postgres.Postgres) *ApprovalRepo {
+	return &ApprovalRepo{pg}
+}
+
+func (ar *ApprovalRepo) CreatePolicy(ctx context.Context, p *entity.ApprovalPolicy) (*entity.ApprovalPolicy, error) {
+	sql, args, _ := ar.Builder.
+		Insert("approval_policy").
+		Columns("id", "organization_id", "name", "min_budget", "required_approvals", "created_by").
+		Values(uuid.New(), p.OrganizationID, p.Name, p.MinBudget, p.RequiredApprovals, p.CreatedBy).
+		Suffix("RETURNING " + strings.Join(approvalPolicyColumns, ", ")).
+		ToSql()
+
+	policy, err := scanApprovalPolicy(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - CreatePolicy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (ar *ApprovalRepo) GetPoliciesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.ApprovalPolicy, error) {
+	sql, args, _ := ar.Builder.
+		Select(approvalPolicyColumns...).
+		From("approval_policy").
+		Where(squirrel.Eq{"organization_id": organizationID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - GetPoliciesByOrganization: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*entity.ApprovalPolicy
+	for rows.Next() {
+		policy, err := scanApprovalPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (ar *ApprovalRepo) DeletePolicy(ctx context.Context, policyID, organizationID uuid.UUID) error {
+	sql, args, _ := ar.Builder.
+		Delete("approval_policy").
+		Where(squirrel.Eq{"id": policyID, "organization_id": organizationID}).
+		ToSql()
+
+	tag, err := ar.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - ApprovalRepo - DeletePolicy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+// FindApplicablePolicy returns the strictest policy of the organization that
+// applies to a tender with the given budget. Policies with a minimum budget
+// do not apply to tenders without a budget.
+func (ar *ApprovalRepo) FindApplicablePolicy(ctx context.Context, organizationID uuid.UUID, budget *string) (*entity.ApprovalPolicy, error) {
+	sql, args, _ := ar.Builder.
+		Select(approvalPolicyColumns...).
+		From("approval_policy").
+		Where(squirrel.Eq{"organization_id": organizationID}).
+		Where(squirrel.Or{
+			squirrel.Eq{"min_budget": nil},
+			squirrel.Expr("min_budget <= ?", budget),
+		}).
+		OrderBy("required_approvals DESC", "created_at ASC").
+		Limit(1).
+		ToSql()
+
+	policy, err := scanApprovalPolicy(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - FindApplicablePolicy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (ar *ApprovalRepo) CreateRequest(ctx context.Context, r *entity.ApprovalRequest) (*entity.ApprovalRequest, error) {
+	sql, args, _ := ar.Builder.
+		Insert("approval_request").
+		Columns("id", "tender_id", "policy_id", "required_approvals", "requested_by").
+		Values(uuid.New(), r.TenderID, r.PolicyID, r.RequiredApprovals, r.RequestedBy).
+		Suffix(approvalRequestReturning).
+		ToSql()
+
+	request, err := scanApprovalRequest(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - CreateRequest: %w", err)
+	}
+
+	return request, nil
+}
+
+func (ar *ApprovalRepo) GetPendingRequestForUpdate(ctx context.Context, tenderID uuid.UUID) (*entity.ApprovalRequest, error) {
+	sql, args, _ := ar.Builder.
+		Select(approvalRequestColumns...).
+		From("approval_request").
+		Where(squirrel.Eq{"tender_id": tenderID, "status": entity.ApprovalPending}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	request, err := scanApprovalRequest(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - GetPendingRequestForUpdate: %w", err)
+	}
+
+	return request, nil
+}
+
+func (ar *ApprovalRepo) GetRequestsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.ApprovalRequest, error) {
+	sql, args, _ := ar.Builder.
+		Select(approvalRequestColumns...).
+		From("approval_request").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - GetRequestsByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*entity.ApprovalRequest
+	for rows.Next() {
+		request, err := scanApprovalRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return requests, nil
+}
+
+func (ar *ApprovalRepo) UpdateRequestStatus(ctx context.Context, requestID uuid.UUID, status string, decidedAt time.Time) (*entity.ApprovalRequest, error) {
+	sql, args, _ := ar.Builder.
+		Update("approval_request").
+		Set("status", status).
+		Set("decided_at", decidedAt).
+		Where(squirrel.Eq{"id": requestID}).
+		Suffix(approvalRequestReturning).
+		ToSql()
+
+	request, err := scanApprovalRequest(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - UpdateRequestStatus: %w", err)
+	}
+
+	return request, nil
+}
+
+func (ar *ApprovalRepo) CreateStep(ctx context.Context, s *entity.ApprovalStep) (*entity.ApprovalStep, error) {
+	sql, args, _ := ar.Builder.
+		Insert("approval_step").
+		Columns("id", "request_id", "approver_id", "decision", "comment").
+		Values(uuid.New(), s.RequestID, s.ApproverID, s.Decision, s.Comment).
+		Suffix("RETURNING " + strings.Join(approvalStepColumns, ", ")).
+		ToSql()
+
+	step, err := scanApprovalStep(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - CreateStep: %w", err)
+	}
+
+	return step, nil
+}
+
+func (ar *ApprovalRepo) GetStepsByRequests(ctx context.Context, requestIDs []uuid.UUID) ([]*entity.ApprovalStep, error) {
+	sql, args, _ := ar.Builder.
+		Select(approvalStepColumns...).
+		From("approval_step").
+		Where(squirrel.Eq{"request_id": requestIDs}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ApprovalRepo - GetStepsByRequests: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []*entity.ApprovalStep
+	for rows.Next() {
+		step, err := scanApprovalStep(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		steps = append(steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return steps, nil
+}
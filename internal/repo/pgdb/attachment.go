@@ -0,0 +1,130 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var attachmentColumns = []string{
+	"id",
+	"tender_id",
+	"bid_id",
+	"file_name",
+	"content_type",
+	"size",
+	"checksum",
+	"storage_key",
+	"uploaded_by",
+	"created_at",
+}
+
+var attachmentReturning = "RETURNING " + strings.Join(attachmentColumns, ", ")
+
+func scanAttachment(row pgx.Row) (*entity.Attachment, error) {
+	var a entity.Attachment
+	err := row.Scan(
+		&a.ID,
+		&a.TenderID,
+		&a.BidID,
+		&a.FileName,
+		&a.ContentType,
+		&a.Size,
+		&a.Checksum,
+		&a.StorageKey,
+		&a.UploadedBy,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+type AttachmentRepo struct {
+	*postgres.Postgres
+}
+
+func NewAttachmentRepo(pg *postgres.Postgres) *AttachmentRepo {
+	return &AttachmentRepo{pg}
+}
+
+func (ar *AttachmentRepo) CreateAttachment(ctx context.Context, a *entity.Attachment) (*entity.Attachment, error) {
+	sql, args, _ := ar.Builder.
+		Insert("attachment").
+		Columns("id", "tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by").
+		Values(a.ID, a.TenderID, a.BidID, a.FileName, a.ContentType, a.Size, a.Checksum, a.StorageKey, a.UploadedBy).
+		Suffix(attachmentReturning).
+		ToSql()
+
+	created, err := scanAttachment(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AttachmentRepo - CreateAttachment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (ar *AttachmentRepo) GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.Attachment, error) {
+	sql, args, _ := ar.Builder.
+		Select(attachmentColumns...).
+		From("attachment").
+		Where(squirrel.Eq{"id": attachmentID}).
+		ToSql()
+
+	attachment, err := scanAttachment(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - AttachmentRepo - GetAttachmentByID: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (ar *AttachmentRepo) GetAttachmentsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Attachment, error) {
+	return ar.getAttachments(ctx, squirrel.Eq{"tender_id": tenderID})
+}
+
+func (ar *AttachmentRepo) GetAttachmentsByBid(ctx context.Context, bidID uuid.UUID) ([]*entity.Attachment, error) {
+	return ar.getAttachments(ctx, squirrel.Eq{"bid_id": bidID})
+}
+
+func (ar *AttachmentRepo) getAttachments(ctx context.Context, where squirrel.Eq) ([]*entity.Attachment, error) {
+	sql, args, _ := ar.Builder.
+		Select(attachmentColumns...).
+		From("attachment").
+		Where(where).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AttachmentRepo - getAttachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*entity.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,73 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type AuctionRepo struct {
+	*postgres.Postgres
+}
+
+func NewAuctionRepo(pg *postgres.Postgres) *AuctionRepo {
+	return &AuctionRepo{pg}
+}
+
+func (ar *AuctionRepo) CreateOffer(ctx context.Context, offer *entity.AuctionOffer) (*entity.AuctionOffer, error) {
+	sql, args, _ := ar.Builder.
+		Insert("auction_offer").
+		Columns("id", "tender_id", "bid_id", "price").
+		Values(uuid.New(), offer.TenderID, offer.BidID, offer.Price).
+		Suffix("RETURNING id, tender_id, bid_id, price, created_at").
+		ToSql()
+
+	var created entity.AuctionOffer
+	err := ar.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+		&created.ID,
+		&created.TenderID,
+		&created.BidID,
+		&created.Price,
+		&created.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AuctionRepo - CreateOffer: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetOffersByTender returns the auction offers of a tender, oldest first.
+func (ar *AuctionRepo) GetOffersByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.AuctionOffer, error) {
+	sql, args, _ := ar.Builder.
+		Select("id", "tender_id", "bid_id", "price", "created_at").
+		From("auction_offer").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AuctionRepo - GetOffersByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var offers []*entity.AuctionOffer
+	for rows.Next() {
+		var offer entity.AuctionOffer
+		if err := rows.Scan(&offer.ID, &offer.TenderID, &offer.BidID, &offer.Price, &offer.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		offers = append(offers, &offer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return offers, nil
+}
@@ -0,0 +1,164 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+var auditEventColumns = []string{
+	"id",
+	"actor",
+	"action",
+	"entity_type",
+	"entity_id",
+	"before",
+	"after",
+	"request_id",
+	"ip",
+	"created_at",
+	"seq",
+	"prev_hash",
+	"hash",
+}
+
+func scanAuditEvent(row pgx.Row) (*entity.AuditEvent, error) {
+	var e entity.AuditEvent
+	err := row.Scan(
+		&e.ID,
+		&e.Actor,
+		&e.Action,
+		&e.EntityType,
+		&e.EntityID,
+		&e.Before,
+		&e.After,
+		&e.RequestID,
+		&e.IP,
+		&e.CreatedAt,
+		&e.Seq,
+		&e.PrevHash,
+		&e.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+type AuditRepo struct {
+	*postgres.Postgres
+}
+
+func NewAuditRepo(pg *postgres.Postgres) *AuditRepo {
+	return &AuditRepo{pg}
+}
+
+// CreateEvent stores a chained event. Its ID and creation time are part of
+// its chain hash and are set by the caller.
+func (ar *AuditRepo) CreateEvent(ctx context.Context, e *entity.AuditEvent) (*entity.AuditEvent, error) {
+	sql, args, _ := ar.Builder.
+		Insert("audit_event").
+		Columns("id", "actor", "action", "entity_type", "entity_id", "before", "after", "request_id", "ip", "created_at", "seq", "prev_hash", "hash").
+		Values(e.ID, e.Actor, e.Action, e.EntityType, e.EntityID, e.Before, e.After, e.RequestID, e.IP, e.CreatedAt, e.Seq, e.PrevHash, e.Hash).
+		Suffix("RETURNING " + strings.Join(auditEventColumns, ", ")).
+		ToSql()
+
+	event, err := scanAuditEvent(ar.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AuditRepo - CreateEvent: %w", err)
+	}
+
+	return event, nil
+}
+
+// GetEvents lists the events matching the filter, latest first.
+func (ar *AuditRepo) GetEvents(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEvent, error) {
+	query := ar.Builder.
+		Select(auditEventColumns...).
+		From("audit_event")
+	if filter.Actor != "" {
+		query = query.Where(squirrel.Eq{"actor": filter.Actor})
+	}
+	if filter.Action != "" {
+		query = query.Where(squirrel.Eq{"action": filter.Action})
+	}
+	if filter.EntityType != "" {
+		query = query.Where(squirrel.Eq{"entity_type": filter.EntityType})
+	}
+	if filter.EntityID != nil {
+		query = query.Where(squirrel.Eq{"entity_id": *filter.EntityID})
+	}
+	if filter.RequestID != "" {
+		query = query.Where(squirrel.Eq{"request_id": filter.RequestID})
+	}
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{"created_at": *filter.To})
+	}
+
+	sql, args, _ := query.
+		OrderBy("created_at DESC", "id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AuditRepo - GetEvents: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetChainedEvents lists the events of the audit chain following afterSeq,
+// in chain order.
+func (ar *AuditRepo) GetChainedEvents(ctx context.Context, afterSeq int64, limit int) ([]*entity.AuditEvent, error) {
+	sql, args, _ := ar.Builder.
+		Select(auditEventColumns...).
+		From("audit_event").
+		Where(squirrel.Gt{"seq": afterSeq}).
+		OrderBy("seq").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := ar.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - AuditRepo - GetChainedEvents: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - AuditRepo - GetChainedEvents: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgdb - AuditRepo - GetChainedEvents: %w", err)
+	}
+
+	return events, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
@@ -14,6 +15,76 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var bidColumns = []string{
+	"id",
+	"name",
+	"description",
+	"status",
+	"tender_id",
+	"author_type",
+	"author_id",
+	"version",
+	"price",
+	"currency",
+	"delivery_days",
+	"validity_days",
+	"total_score",
+	"sealed_payload",
+	"needs_ack",
+	"COALESCE(decision, '')",
+	"feedback_rating",
+	"created_at",
+}
+
+var bidReturning = "RETURNING " + strings.Join(bidColumns, ", ")
+
+func scanBid(row pgx.Row) (*entity.Bid, error) {
+	var bid entity.Bid
+	err := row.Scan(
+		&bid.ID,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.TenderID,
+		&bid.AuthorType,
+		&bid.AuthorID,
+		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
+		&bid.DeliveryDays,
+		&bid.ValidityDays,
+		&bid.TotalScore,
+		&bid.SealedPayload,
+		&bid.NeedsAck,
+		&bid.Decision,
+		&bid.FeedbackRating,
+		&bid.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &bid, nil
+}
+
+func scanBids(rows pgx.Rows) ([]*entity.Bid, error) {
+	defer rows.Close()
+
+	var bids []*entity.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return bids, nil
+}
+
 type BidRepo struct {
 	*postgres.Postgres
 }
@@ -26,23 +97,12 @@ func NewBidRepo(pg *postgres.Postgres) *BidRepo {
 func (br *BidRepo) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid, error) {
 	sql, args, _ := br.Builder.
 		Insert("bid").
-		Columns("id", "name", "description", "status", "tender_id", "author_type", "author_id").
-		Values(uuid.New(), bid.Name, bid.Description, "CREATED", bid.TenderID, bid.AuthorType, bid.AuthorID).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Columns("id", "name", "description", "status", "tender_id", "author_type", "author_id", "price", "currency", "delivery_days", "validity_days", "sealed_payload").
+		Values(uuid.New(), bid.Name, bid.Description, entity.BidStatusCreated, bid.TenderID, bid.AuthorType, bid.AuthorID, bid.Price, bid.Currency, bid.DeliveryDays, bid.ValidityDays, bid.SealedPayload).
+		Suffix(bidReturning).
 		ToSql()
 
-	var createdBid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&createdBid.ID,
-		&createdBid.Name,
-		&createdBid.Description,
-		&createdBid.Status,
-		&createdBid.TenderID,
-		&createdBid.AuthorType,
-		&createdBid.AuthorID,
-		&createdBid.Version,
-		&createdBid.CreatedAt,
-	)
+	createdBid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if ok := errors.As(err, &pgErr); ok {
@@ -53,20 +113,19 @@ func (br *BidRepo) CreateBid(ctx context.Context, bid *entity.Bid) (*entity.Bid,
 		return nil, fmt.Errorf("pgdb - BidRepo - CreateBid: %w", err)
 	}
 
-	return &createdBid, nil
+	return createdBid, nil
 }
 func (br *BidRepo) FindByTenderAndAuthor(ctx context.Context, tenderID, authorID uuid.UUID) (*entity.Bid, error) {
 	sql, args, _ := br.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
-		From("bids").
+		Select(bidColumns...).
+		From("bid").
 		Where(squirrel.Eq{"tender_id": tenderID, "author_id": authorID}).
+		// The active bid comes first; withdrawn and canceled ones stay in place.
+		OrderBy("status IN ('Canceled', 'Withdrawn') ASC", "created_at DESC").
+		Limit(1).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID, &bid.Name, &bid.Description, &bid.TenderID,
-		&bid.AuthorType, &bid.AuthorID, &bid.Version, &bid.CreatedAt,
-	)
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repoerrs.ErrNotFound
@@ -74,10 +133,10 @@ func (br *BidRepo) FindByTenderAndAuthor(ctx context.Context, tenderID, authorID
 		return nil, err
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 func (br *BidRepo) GetUserBids(ctx context.Context, limit, offset int, authorID uuid.UUID) ([]*entity.Bid, error) {
-	sql, args, _ := br.Builder.Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+	sql, args, _ := br.Builder.Select(bidColumns...).
 		From("bid").
 		Where(squirrel.Eq{"author_id": authorID}).
 		Limit(uint64(limit)).
@@ -92,46 +151,12 @@ func (br *BidRepo) GetUserBids(ctx context.Context, limit, offset int, authorID
 		}
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
-	defer rows.Close()
-
-	var bids []*entity.Bid
-	for rows.Next() {
-		var bid entity.Bid
-		if err := rows.Scan(
-			&bid.ID,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.TenderID,
-			&bid.AuthorType,
-			&bid.AuthorID,
-			&bid.Version,
-			&bid.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		bids = append(bids, &bid)
-	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
-	}
-
-	return bids, nil
+	return scanBids(rows)
 }
 
 func (br *BidRepo) GetBidsByTender(ctx context.Context, limit int, offset int, tenderID uuid.UUID) ([]*entity.Bid, error) {
-	sql, args, _ := br.Builder.Select(
-		"id",
-		"name",
-		"description",
-		"status",
-		"tender_id",
-		"author_type",
-		"author_id",
-		"version",
-		"created_at",
-	).
+	sql, args, _ := br.Builder.Select(bidColumns...).
 		From("bid").
 		Where(squirrel.Eq{"tender_id": tenderID}).
 		OrderBy("name ASC").
@@ -139,68 +164,48 @@ func (br *BidRepo) GetBidsByTender(ctx context.Context, limit int, offset int, t
 		Offset(uint64(offset)).
 		ToSql()
 
-	rows, err := br.Pool.Query(ctx, sql, args...)
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
-	defer rows.Close()
 
-	var bids []*entity.Bid
-	for rows.Next() {
-		var bid entity.Bid
-		if err := rows.Scan(
-			&bid.ID,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.TenderID,
-			&bid.AuthorType,
-			&bid.AuthorID,
-			&bid.Version,
-			&bid.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		bids = append(bids, &bid)
-	}
+	return scanBids(rows)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+// GetAllBidsByTender returns every bid of the tender without pagination.
+func (br *BidRepo) GetAllBidsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error) {
+	sql, args, _ := br.Builder.Select(bidColumns...).
+		From("bid").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - GetAllBidsByTender: %w", err)
 	}
 
-	return bids, nil
+	return scanBids(rows)
 }
 
 func (br *BidRepo) GetBidByID(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
 
-	sql, args, _ := br.Builder.Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+	sql, args, _ := br.Builder.Select(bidColumns...).
 		From("bid").
 		Where(squirrel.Eq{"id": bidID}).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderID,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
-
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
-	return &bid, nil
+	return bid, nil
 }
 
 func (br *BidRepo) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, status string) (*entity.Bid, error) {
@@ -209,29 +214,22 @@ func (br *BidRepo) UpdateBidStatus(ctx context.Context, bidID uuid.UUID, status
 		Set("status", status).
 		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": bidID}).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix(bidReturning).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderID,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 func (br *BidRepo) UpdateBid(ctx context.Context, bidID uuid.UUID, updates map[string]interface{}) (*entity.Bid, error) {
 	sql, args, _ := br.Builder.
@@ -239,29 +237,44 @@ func (br *BidRepo) UpdateBid(ctx context.Context, bidID uuid.UUID, updates map[s
 		SetMap(updates).
 		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": bidID}).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix(bidReturning).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderID,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
+}
+
+// RevealBid replaces the sealed payload with plaintext contents without
+// bumping the version: revealing is not an edit made by the author.
+func (br *BidRepo) RevealBid(ctx context.Context, bidID uuid.UUID, updates map[string]interface{}) (*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Update("bid").
+		SetMap(updates).
+		Set("sealed_payload", nil).
+		Where(squirrel.Eq{"id": bidID}).
+		Suffix(bidReturning).
+		ToSql()
+
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - RevealBid: %w", err)
+	}
+
+	return bid, nil
 }
 func (br *BidRepo) UpdateBidDecision(ctx context.Context, bidID uuid.UUID, decision string) (*entity.Bid, error) {
 	sql, args, _ := br.Builder.
@@ -269,21 +282,10 @@ func (br *BidRepo) UpdateBidDecision(ctx context.Context, bidID uuid.UUID, decis
 		Set("decision", decision).
 		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": bidID}).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix(bidReturning).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderID,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
@@ -291,30 +293,26 @@ func (br *BidRepo) UpdateBidDecision(ctx context.Context, bidID uuid.UUID, decis
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 
-func (br *BidRepo) UpdateBidFeedback(ctx context.Context, bidID uuid.UUID, feedback string) (*entity.Bid, error) {
-	sql, args, _ := br.Builder.
+// UpdateBidFeedback stores the review of a bid. The star rating is kept
+// when none is given.
+func (br *BidRepo) UpdateBidFeedback(ctx context.Context, bidID uuid.UUID, feedback string, rating *int) (*entity.Bid, error) {
+	query := br.Builder.
 		Update("bid").
 		Set("feedback", feedback).
-		Set("version", squirrel.Expr("version + 1")).
+		Set("version", squirrel.Expr("version + 1"))
+	if rating != nil {
+		query = query.Set("feedback_rating", *rating)
+	}
+
+	sql, args, _ := query.
 		Where(squirrel.Eq{"id": bidID}).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix(bidReturning).
 		ToSql()
 
-	var bid entity.Bid
-	err := br.Pool.QueryRow(ctx, sql, args...).Scan(
-		&bid.ID,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.TenderID,
-		&bid.AuthorType,
-		&bid.AuthorID,
-		&bid.Version,
-		&bid.CreatedAt,
-	)
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repoerrs.ErrNotFound
@@ -322,5 +320,355 @@ func (br *BidRepo) UpdateBidFeedback(ctx context.Context, bidID uuid.UUID, feedb
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
+}
+
+// UpdateBidTotalScore stores the computed weighted score; it is derived data,
+// so the bid version is left untouched.
+func (br *BidRepo) UpdateBidTotalScore(ctx context.Context, bidID uuid.UUID, totalScore *string) (*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Update("bid").
+		Set("total_score", totalScore).
+		Where(squirrel.Eq{"id": bidID}).
+		Suffix(bidReturning).
+		ToSql()
+
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - UpdateBidTotalScore: %w", err)
+	}
+
+	return bid, nil
+}
+
+// RequireAcknowledgement flags every active bid of the tender as needing its
+// author's acknowledgement and returns the flagged bids.
+func (br *BidRepo) RequireAcknowledgement(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Update("bid").
+		Set("needs_ack", true).
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		Where(squirrel.NotEq{"status": entity.InactiveBidStatuses}).
+		Suffix(bidReturning).
+		ToSql()
+
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - RequireAcknowledgement: %w", err)
+	}
+
+	return scanBids(rows)
+}
+
+// CancelBidsByTender cancels every active bid of the tender and returns the
+// canceled bids.
+func (br *BidRepo) CancelBidsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Update("bid").
+		Set("status", entity.BidStatusCanceled).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		Where(squirrel.NotEq{"status": entity.InactiveBidStatuses}).
+		Suffix(bidReturning).
+		ToSql()
+
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - CancelBidsByTender: %w", err)
+	}
+
+	return scanBids(rows)
+}
+
+// AcknowledgeBid clears the acknowledgement flag without bumping the version:
+// the bid itself is unchanged.
+func (br *BidRepo) AcknowledgeBid(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Update("bid").
+		Set("needs_ack", false).
+		Where(squirrel.Eq{"id": bidID}).
+		Suffix(bidReturning).
+		ToSql()
+
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - AcknowledgeBid: %w", err)
+	}
+
+	return bid, nil
+}
+
+// GetBidForUpdate locks the bid row until the surrounding transaction ends.
+func (br *BidRepo) GetBidForUpdate(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
+	sql, args, _ := br.Builder.
+		Select(bidColumns...).
+		From("bid").
+		Where(squirrel.Eq{"id": bidID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	bid, err := scanBid(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidForUpdate: %w", err)
+	}
+
+	return bid, nil
+}
+
+var bidVersionColumns = []string{
+	"id",
+	"bid_id",
+	"version",
+	"status",
+	"name",
+	"description",
+	"price",
+	"currency",
+	"delivery_days",
+	"validity_days",
+	"sealed_payload",
+	"created_at",
+}
+
+func scanBidVersion(row pgx.Row) (*entity.BidVersion, error) {
+	var v entity.BidVersion
+	err := row.Scan(
+		&v.ID,
+		&v.BidID,
+		&v.Version,
+		&v.Status,
+		&v.Name,
+		&v.Description,
+		&v.Price,
+		&v.Currency,
+		&v.DeliveryDays,
+		&v.ValidityDays,
+		&v.SealedPayload,
+		&v.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// CreateBidVersion stores a snapshot of the bid as it is now.
+func (br *BidRepo) CreateBidVersion(ctx context.Context, bid *entity.Bid) (*entity.BidVersion, error) {
+	sql, args, _ := br.Builder.
+		Insert("bid_version").
+		Columns("id", "bid_id", "version", "status", "name", "description", "price", "currency", "delivery_days", "validity_days", "sealed_payload").
+		Values(uuid.New(), bid.ID, bid.Version, bid.Status, bid.Name, bid.Description, bid.Price, bid.Currency, bid.DeliveryDays, bid.ValidityDays, bid.SealedPayload).
+		Suffix("RETURNING " + strings.Join(bidVersionColumns, ", ")).
+		ToSql()
+
+	version, err := scanBidVersion(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - CreateBidVersion: %w", err)
+	}
+
+	return version, nil
+}
+
+func (br *BidRepo) GetBidVersions(ctx context.Context, bidID uuid.UUID) ([]*entity.BidVersion, error) {
+	sql, args, _ := br.Builder.
+		Select(bidVersionColumns...).
+		From("bid_version").
+		Where(squirrel.Eq{"bid_id": bidID}).
+		OrderBy("version ASC").
+		ToSql()
+
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidVersions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*entity.BidVersion
+	for rows.Next() {
+		version, err := scanBidVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - BidRepo - GetBidVersions: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidVersions: %w", err)
+	}
+
+	return versions, nil
+}
+
+var bidSubmissionColumns = []string{
+	"seq",
+	"prev_hash",
+	"hash",
+	"bid_id",
+	"tender_id",
+	"author_id",
+	"version",
+	"status",
+	"name",
+	"description",
+	"price",
+	"currency",
+	"delivery_days",
+	"validity_days",
+	"sealed_payload",
+	"created_at",
+}
+
+func scanBidSubmission(row pgx.Row) (*entity.BidSubmission, error) {
+	var s entity.BidSubmission
+	err := row.Scan(
+		&s.Seq,
+		&s.PrevHash,
+		&s.Hash,
+		&s.BidID,
+		&s.TenderID,
+		&s.AuthorID,
+		&s.Version,
+		&s.Status,
+		&s.Name,
+		&s.Description,
+		&s.Price,
+		&s.Currency,
+		&s.DeliveryDays,
+		&s.ValidityDays,
+		&s.SealedPayload,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (br *BidRepo) CreateBidSubmission(ctx context.Context, s *entity.BidSubmission) (*entity.BidSubmission, error) {
+	sql, args, _ := br.Builder.
+		Insert("bid_submission").
+		Columns(bidSubmissionColumns...).
+		Values(s.Seq, s.PrevHash, s.Hash, s.BidID, s.TenderID, s.AuthorID, s.Version, s.Status, s.Name, s.Description,
+			s.Price, s.Currency, s.DeliveryDays, s.ValidityDays, s.SealedPayload, s.CreatedAt).
+		Suffix("RETURNING " + strings.Join(bidSubmissionColumns, ", ")).
+		ToSql()
+
+	submission, err := scanBidSubmission(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - CreateBidSubmission: %w", err)
+	}
+
+	return submission, nil
+}
+
+// GetBidSubmissions lists the submissions of the bid chain following
+// afterSeq, in chain order.
+func (br *BidRepo) GetBidSubmissions(ctx context.Context, afterSeq int64, limit int) ([]*entity.BidSubmission, error) {
+	sql, args, _ := br.Builder.
+		Select(bidSubmissionColumns...).
+		From("bid_submission").
+		Where(squirrel.Gt{"seq": afterSeq}).
+		OrderBy("seq").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := br.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidSubmissions: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []*entity.BidSubmission
+	for rows.Next() {
+		submission, err := scanBidSubmission(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - BidRepo - GetBidSubmissions: %w", err)
+		}
+		submissions = append(submissions, submission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidSubmissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+var bidSignatureColumns = []string{
+	"id",
+	"bid_id",
+	"version",
+	"key_id",
+	"document",
+	"sealed",
+	"signature",
+	"created_at",
+}
+
+func scanBidSignature(row pgx.Row) (*entity.BidSignature, error) {
+	var s entity.BidSignature
+	err := row.Scan(
+		&s.ID,
+		&s.BidID,
+		&s.Version,
+		&s.KeyID,
+		&s.Document,
+		&s.Sealed,
+		&s.Signature,
+		&s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (br *BidRepo) CreateBidSignature(ctx context.Context, s *entity.BidSignature) (*entity.BidSignature, error) {
+	sql, args, _ := br.Builder.
+		Insert("bid_signature").
+		Columns("id", "bid_id", "version", "key_id", "document", "sealed", "signature").
+		Values(uuid.New(), s.BidID, s.Version, s.KeyID, s.Document, s.Sealed, s.Signature).
+		Suffix("RETURNING " + strings.Join(bidSignatureColumns, ", ")).
+		ToSql()
+
+	signature, err := scanBidSignature(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, repoerrs.ErrAlreadyExists
+			case "23503":
+				return nil, repoerrs.ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - CreateBidSignature: %w", err)
+	}
+
+	return signature, nil
+}
+
+func (br *BidRepo) GetBidSignature(ctx context.Context, bidID uuid.UUID, version int) (*entity.BidSignature, error) {
+	sql, args, _ := br.Builder.
+		Select(bidSignatureColumns...).
+		From("bid_signature").
+		Where(squirrel.Eq{"bid_id": bidID, "version": version}).
+		ToSql()
+
+	signature, err := scanBidSignature(br.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - BidRepo - GetBidSignature: %w", err)
+	}
+
+	return signature, nil
 }
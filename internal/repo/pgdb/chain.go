@@ -0,0 +1,186 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var chainHeadColumns = []string{
+	"name",
+	"seq",
+	"hash",
+	"updated_at",
+}
+
+func scanChainHead(row pgx.Row) (*entity.ChainHead, error) {
+	var h entity.ChainHead
+	err := row.Scan(
+		&h.Chain,
+		&h.Seq,
+		&h.Hash,
+		&h.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+var checkpointColumns = []string{
+	"id",
+	"chain",
+	"seq",
+	"hash",
+	"public_key",
+	"signature",
+	"created_at",
+}
+
+func scanCheckpoint(row pgx.Row) (*entity.ChainCheckpoint, error) {
+	var c entity.ChainCheckpoint
+	err := row.Scan(
+		&c.ID,
+		&c.Chain,
+		&c.Seq,
+		&c.Hash,
+		&c.PublicKey,
+		&c.Signature,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+type ChainRepo struct {
+	*postgres.Postgres
+}
+
+func NewChainRepo(pg *postgres.Postgres) *ChainRepo {
+	return &ChainRepo{pg}
+}
+
+func (cr *ChainRepo) getHead(ctx context.Context, op, chain, suffix string) (*entity.ChainHead, error) {
+	sql, args, _ := cr.Builder.
+		Select(chainHeadColumns...).
+		From("hash_chain").
+		Where(squirrel.Eq{"name": chain}).
+		Suffix(suffix).
+		ToSql()
+
+	head, err := scanChainHead(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ChainRepo - %s: %w", op, err)
+	}
+
+	return head, nil
+}
+
+func (cr *ChainRepo) GetHead(ctx context.Context, chain string) (*entity.ChainHead, error) {
+	return cr.getHead(ctx, "GetHead", chain, "")
+}
+
+// GetHeadForUpdate locks the head of the chain, which serializes the appends
+// to the chain until the end of the transaction.
+func (cr *ChainRepo) GetHeadForUpdate(ctx context.Context, chain string) (*entity.ChainHead, error) {
+	return cr.getHead(ctx, "GetHeadForUpdate", chain, "FOR UPDATE")
+}
+
+func (cr *ChainRepo) UpdateHead(ctx context.Context, chain string, link entity.ChainLink) error {
+	sql, args, _ := cr.Builder.
+		Update("hash_chain").
+		Set("seq", link.Seq).
+		Set("hash", link.Hash).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"name": chain}).
+		ToSql()
+
+	tag, err := cr.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - ChainRepo - UpdateHead: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr *ChainRepo) CreateCheckpoint(ctx context.Context, c *entity.ChainCheckpoint) (*entity.ChainCheckpoint, error) {
+	sql, args, _ := cr.Builder.
+		Insert("chain_checkpoint").
+		Columns("id", "chain", "seq", "hash", "public_key", "signature", "created_at").
+		Values(uuid.New(), c.Chain, c.Seq, c.Hash, c.PublicKey, c.Signature, c.CreatedAt).
+		Suffix("RETURNING " + strings.Join(checkpointColumns, ", ")).
+		ToSql()
+
+	checkpoint, err := scanCheckpoint(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ChainRepo - CreateCheckpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// GetCheckpoints lists the checkpoints of the chain, earliest first.
+func (cr *ChainRepo) GetCheckpoints(ctx context.Context, chain string) ([]*entity.ChainCheckpoint, error) {
+	sql, args, _ := cr.Builder.
+		Select(checkpointColumns...).
+		From("chain_checkpoint").
+		Where(squirrel.Eq{"chain": chain}).
+		OrderBy("seq ASC", "created_at ASC").
+		ToSql()
+
+	rows, err := cr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ChainRepo - GetCheckpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var checkpoints []*entity.ChainCheckpoint
+	for rows.Next() {
+		checkpoint, err := scanCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - ChainRepo - GetCheckpoints: %w", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgdb - ChainRepo - GetCheckpoints: %w", err)
+	}
+
+	return checkpoints, nil
+}
+
+func (cr *ChainRepo) GetLatestCheckpoint(ctx context.Context, chain string) (*entity.ChainCheckpoint, error) {
+	sql, args, _ := cr.Builder.
+		Select(checkpointColumns...).
+		From("chain_checkpoint").
+		Where(squirrel.Eq{"chain": chain}).
+		OrderBy("seq DESC", "created_at DESC").
+		Limit(1).
+		ToSql()
+
+	checkpoint, err := scanCheckpoint(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ChainRepo - GetLatestCheckpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
@@ -0,0 +1,198 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var conflictDeclarationColumns = []string{
+	"id",
+	"employee_id",
+	"organization_id",
+	"relationship",
+	"description",
+	"created_at",
+}
+
+var conflictFlagColumns = []string{
+	"id",
+	"tender_id",
+	"bid_id",
+	"employee_id",
+	"organization_id",
+	"stage",
+	"rule",
+	"detail",
+	"created_at",
+}
+
+func scanConflictDeclaration(row pgx.Row) (*entity.ConflictDeclaration, error) {
+	var d entity.ConflictDeclaration
+	err := row.Scan(
+		&d.ID,
+		&d.EmployeeID,
+		&d.OrganizationID,
+		&d.Relationship,
+		&d.Description,
+		&d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func scanConflictFlag(row pgx.Row) (*entity.ConflictFlag, error) {
+	var f entity.ConflictFlag
+	err := row.Scan(
+		&f.ID,
+		&f.TenderID,
+		&f.BidID,
+		&f.EmployeeID,
+		&f.OrganizationID,
+		&f.Stage,
+		&f.Rule,
+		&f.Detail,
+		&f.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+type ConflictRepo struct {
+	*postgres.Postgres
+}
+
+func NewConflictRepo(pg *postgres.Postgres) *ConflictRepo {
+	return &ConflictRepo{pg}
+}
+
+func (cr *ConflictRepo) CreateDeclaration(ctx context.Context, d *entity.ConflictDeclaration) (*entity.ConflictDeclaration, error) {
+	sql, args, _ := cr.Builder.
+		Insert("conflict_declaration").
+		Columns("id", "employee_id", "organization_id", "relationship", "description").
+		Values(uuid.New(), d.EmployeeID, d.OrganizationID, d.Relationship, d.Description).
+		Suffix("RETURNING " + strings.Join(conflictDeclarationColumns, ", ")).
+		ToSql()
+
+	created, err := scanConflictDeclaration(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, repoerrs.ErrAlreadyExists
+			case "23503":
+				return nil, repoerrs.ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("pgdb - ConflictRepo - CreateDeclaration: %w", err)
+	}
+
+	return created, nil
+}
+
+func (cr *ConflictRepo) GetDeclarationsByEmployee(ctx context.Context, employeeID uuid.UUID) ([]*entity.ConflictDeclaration, error) {
+	sql, args, _ := cr.Builder.
+		Select(conflictDeclarationColumns...).
+		From("conflict_declaration").
+		Where(squirrel.Eq{"employee_id": employeeID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := cr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ConflictRepo - GetDeclarationsByEmployee: %w", err)
+	}
+	defer rows.Close()
+
+	var declarations []*entity.ConflictDeclaration
+	for rows.Next() {
+		declaration, err := scanConflictDeclaration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		declarations = append(declarations, declaration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return declarations, nil
+}
+
+func (cr *ConflictRepo) DeleteDeclaration(ctx context.Context, declarationID, employeeID uuid.UUID) error {
+	sql, args, _ := cr.Builder.
+		Delete("conflict_declaration").
+		Where(squirrel.Eq{"id": declarationID, "employee_id": employeeID}).
+		ToSql()
+
+	tag, err := cr.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - ConflictRepo - DeleteDeclaration: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr *ConflictRepo) CreateFlag(ctx context.Context, f *entity.ConflictFlag) (*entity.ConflictFlag, error) {
+	sql, args, _ := cr.Builder.
+		Insert("conflict_flag").
+		Columns("id", "tender_id", "bid_id", "employee_id", "organization_id", "stage", "rule", "detail").
+		Values(uuid.New(), f.TenderID, f.BidID, f.EmployeeID, f.OrganizationID, f.Stage, f.Rule, f.Detail).
+		Suffix("RETURNING " + strings.Join(conflictFlagColumns, ", ")).
+		ToSql()
+
+	created, err := scanConflictFlag(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ConflictRepo - CreateFlag: %w", err)
+	}
+
+	return created, nil
+}
+
+func (cr *ConflictRepo) GetFlagsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.ConflictFlag, error) {
+	sql, args, _ := cr.Builder.
+		Select(conflictFlagColumns...).
+		From("conflict_flag").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := cr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ConflictRepo - GetFlagsByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*entity.ConflictFlag
+	for rows.Next() {
+		flag, err := scanConflictFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,354 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var contractColumns = []string{
+	"id",
+	"tender_id",
+	"bid_id",
+	"lot_id",
+	"bid_version",
+	"buyer_organization_id",
+	"supplier_organization_id",
+	"supplier_id",
+	"price",
+	"currency",
+	"status",
+	"created_at",
+	"completed_at",
+}
+
+var contractReturning = "RETURNING " + strings.Join(contractColumns, ", ")
+
+var milestoneColumns = []string{
+	"id",
+	"contract_id",
+	"name",
+	"description",
+	"due_date",
+	"supplier_confirmed_at",
+	"buyer_confirmed_at",
+	"completed_at",
+	"created_at",
+}
+
+var milestoneReturning = "RETURNING " + strings.Join(milestoneColumns, ", ")
+
+func scanContract(row pgx.Row) (*entity.Contract, error) {
+	var c entity.Contract
+	err := row.Scan(
+		&c.ID,
+		&c.TenderID,
+		&c.BidID,
+		&c.LotID,
+		&c.BidVersion,
+		&c.BuyerOrganizationID,
+		&c.SupplierOrganizationID,
+		&c.SupplierID,
+		&c.Price,
+		&c.Currency,
+		&c.Status,
+		&c.CreatedAt,
+		&c.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func scanMilestone(row pgx.Row) (*entity.Milestone, error) {
+	var m entity.Milestone
+	err := row.Scan(
+		&m.ID,
+		&m.ContractID,
+		&m.Name,
+		&m.Description,
+		&m.DueDate,
+		&m.SupplierConfirmedAt,
+		&m.BuyerConfirmedAt,
+		&m.CompletedAt,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+type ContractRepo struct {
+	*postgres.Postgres
+}
+
+func NewContractRepo(pg *postgres.Postgres) *ContractRepo {
+	return &ContractRepo{pg}
+}
+
+// CreateContract stores the contract of an award. A bid gets a single
+// contract for the tender and one per lot it wins; an existing one is left
+// untouched and reported as ErrAlreadyExists without aborting the
+// transaction.
+func (cr *ContractRepo) CreateContract(ctx context.Context, c *entity.Contract) (*entity.Contract, error) {
+	sql, args, _ := cr.Builder.
+		Insert("contract").
+		Columns("id", "tender_id", "bid_id", "lot_id", "bid_version", "buyer_organization_id", "supplier_organization_id", "supplier_id", "price", "currency").
+		Values(uuid.New(), c.TenderID, c.BidID, c.LotID, c.BidVersion, c.BuyerOrganizationID, c.SupplierOrganizationID, c.SupplierID, c.Price, c.Currency).
+		Suffix("ON CONFLICT (bid_id, lot_id) DO NOTHING " + contractReturning).
+		ToSql()
+
+	created, err := scanContract(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - CreateContract: %w", err)
+	}
+
+	return created, nil
+}
+
+// CountTenderContracts counts the contracts awarded for the tender, lots
+// included.
+func (cr *ContractRepo) CountTenderContracts(ctx context.Context, tenderID uuid.UUID) (int, error) {
+	sql, args, _ := cr.Builder.
+		Select("count(*)").
+		From("contract").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		ToSql()
+
+	var count int
+	if err := cr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("pgdb - ContractRepo - CountTenderContracts: %w", err)
+	}
+
+	return count, nil
+}
+
+func (cr *ContractRepo) GetContractByID(ctx context.Context, contractID uuid.UUID) (*entity.Contract, error) {
+	sql, args, _ := cr.Builder.
+		Select(contractColumns...).
+		From("contract").
+		Where(squirrel.Eq{"id": contractID}).
+		ToSql()
+
+	contract, err := scanContract(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetContractByID: %w", err)
+	}
+
+	return contract, nil
+}
+
+func (cr *ContractRepo) GetContractForUpdate(ctx context.Context, contractID uuid.UUID) (*entity.Contract, error) {
+	sql, args, _ := cr.Builder.
+		Select(contractColumns...).
+		From("contract").
+		Where(squirrel.Eq{"id": contractID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	contract, err := scanContract(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetContractForUpdate: %w", err)
+	}
+
+	return contract, nil
+}
+
+// GetUserContracts lists the contracts the employee takes part in, either as
+// the supplier or as a responsible of the buyer organization. With
+// overdueOnly set only contracts with an overdue milestone are returned.
+func (cr *ContractRepo) GetUserContracts(ctx context.Context, limit, offset int, employeeID uuid.UUID, overdueOnly bool) ([]*entity.Contract, error) {
+	query := cr.Builder.
+		Select(contractColumns...).
+		From("contract").
+		Where(squirrel.Or{
+			squirrel.Eq{"supplier_id": employeeID},
+			squirrel.Expr("buyer_organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)", employeeID),
+			squirrel.Expr("supplier_organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)", employeeID),
+		})
+	if overdueOnly {
+		query = query.Where(squirrel.Expr(
+			"EXISTS (SELECT 1 FROM contract_milestone m WHERE m.contract_id = contract.id AND m.completed_at IS NULL AND m.due_date < ?)",
+			time.Now().UTC(),
+		))
+	}
+
+	sql, args, _ := query.
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := cr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetUserContracts: %w", err)
+	}
+	defer rows.Close()
+
+	var contracts []*entity.Contract
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		contracts = append(contracts, contract)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (cr *ContractRepo) CompleteContract(ctx context.Context, contractID uuid.UUID, completedAt time.Time) (*entity.Contract, error) {
+	sql, args, _ := cr.Builder.
+		Update("contract").
+		Set("status", entity.ContractCompleted).
+		Set("completed_at", completedAt).
+		Where(squirrel.Eq{"id": contractID}).
+		Suffix(contractReturning).
+		ToSql()
+
+	contract, err := scanContract(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - CompleteContract: %w", err)
+	}
+
+	return contract, nil
+}
+
+func (cr *ContractRepo) CreateMilestone(ctx context.Context, m *entity.Milestone) (*entity.Milestone, error) {
+	sql, args, _ := cr.Builder.
+		Insert("contract_milestone").
+		Columns("id", "contract_id", "name", "description", "due_date").
+		Values(uuid.New(), m.ContractID, m.Name, m.Description, m.DueDate).
+		Suffix(milestoneReturning).
+		ToSql()
+
+	created, err := scanMilestone(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ContractRepo - CreateMilestone: %w", err)
+	}
+
+	return created, nil
+}
+
+func (cr *ContractRepo) GetMilestonesByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.Milestone, error) {
+	sql, args, _ := cr.Builder.
+		Select(milestoneColumns...).
+		From("contract_milestone").
+		Where(squirrel.Eq{"contract_id": contractID}).
+		OrderBy("due_date ASC", "created_at ASC").
+		ToSql()
+
+	rows, err := cr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetMilestonesByContract: %w", err)
+	}
+	defer rows.Close()
+
+	var milestones []*entity.Milestone
+	for rows.Next() {
+		milestone, err := scanMilestone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		milestones = append(milestones, milestone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return milestones, nil
+}
+
+func (cr *ContractRepo) GetMilestoneForUpdate(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error) {
+	sql, args, _ := cr.Builder.
+		Select(milestoneColumns...).
+		From("contract_milestone").
+		Where(squirrel.Eq{"id": milestoneID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	milestone, err := scanMilestone(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetMilestoneForUpdate: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// ConfirmMilestone stores the confirmations and the completion time of the
+// milestone.
+func (cr *ContractRepo) ConfirmMilestone(ctx context.Context, m *entity.Milestone) (*entity.Milestone, error) {
+	sql, args, _ := cr.Builder.
+		Update("contract_milestone").
+		Set("supplier_confirmed_at", m.SupplierConfirmedAt).
+		Set("buyer_confirmed_at", m.BuyerConfirmedAt).
+		Set("completed_at", m.CompletedAt).
+		Where(squirrel.Eq{"id": m.ID}).
+		Suffix(milestoneReturning).
+		ToSql()
+
+	milestone, err := scanMilestone(cr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ContractRepo - ConfirmMilestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// GetSupplierRecord counts the completed, on-time and overdue milestones of
+// the contracts an organization supplies.
+func (cr *ContractRepo) GetSupplierRecord(ctx context.Context, organizationID uuid.UUID) (*entity.SupplierRecord, error) {
+	now := time.Now().UTC()
+	sql, args, _ := cr.Builder.
+		Select(
+			"COUNT(*) FILTER (WHERE m.completed_at IS NOT NULL)",
+			"COUNT(*) FILTER (WHERE m.completed_at <= m.due_date)",
+		).
+		Column("COUNT(*) FILTER (WHERE m.completed_at IS NULL AND m.due_date < ?)", now).
+		From("contract_milestone m").
+		Join("contract c ON c.id = m.contract_id").
+		Where(squirrel.Eq{"c.supplier_organization_id": organizationID}).
+		ToSql()
+
+	var record entity.SupplierRecord
+	err := cr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&record.Completed, &record.OnTime, &record.Overdue)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ContractRepo - GetSupplierRecord: %w", err)
+	}
+
+	return &record, nil
+}
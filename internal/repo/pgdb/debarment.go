@@ -0,0 +1,268 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var debarmentColumns = []string{
+	"id",
+	"organization_id",
+	"employee_id",
+	"buyer_organization_id",
+	"reason",
+	"expires_at",
+	"created_by",
+	"created_at",
+	"lifted_at",
+}
+
+var debarmentReturning = "RETURNING " + strings.Join(debarmentColumns, ", ")
+
+var debarmentBlockColumns = []string{
+	"id",
+	"debarment_id",
+	"tender_id",
+	"employee_id",
+	"attempted_at",
+}
+
+func scanDebarment(row pgx.Row) (*entity.Debarment, error) {
+	var d entity.Debarment
+	err := row.Scan(
+		&d.ID,
+		&d.OrganizationID,
+		&d.EmployeeID,
+		&d.BuyerOrganizationID,
+		&d.Reason,
+		&d.ExpiresAt,
+		&d.CreatedBy,
+		&d.CreatedAt,
+		&d.LiftedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func scanDebarments(rows pgx.Rows) ([]*entity.Debarment, error) {
+	defer rows.Close()
+
+	var debarments []*entity.Debarment
+	for rows.Next() {
+		debarment, err := scanDebarment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		debarments = append(debarments, debarment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return debarments, nil
+}
+
+// activeDebarment matches the debarments in force at the given time.
+func activeDebarment(now time.Time) squirrel.Sqlizer {
+	return squirrel.And{
+		squirrel.Eq{"lifted_at": nil},
+		squirrel.Or{squirrel.Eq{"expires_at": nil}, squirrel.Gt{"expires_at": now}},
+	}
+}
+
+type DebarmentRepo struct {
+	*postgres.Postgres
+}
+
+func NewDebarmentRepo(pg *postgres.Postgres) *DebarmentRepo {
+	return &DebarmentRepo{pg}
+}
+
+func (dr *DebarmentRepo) CreateDebarment(ctx context.Context, d *entity.Debarment) (*entity.Debarment, error) {
+	sql, args, _ := dr.Builder.
+		Insert("debarment").
+		Columns("id", "organization_id", "employee_id", "buyer_organization_id", "reason", "expires_at", "created_by").
+		Values(uuid.New(), d.OrganizationID, d.EmployeeID, d.BuyerOrganizationID, d.Reason, d.ExpiresAt, d.CreatedBy).
+		Suffix(debarmentReturning).
+		ToSql()
+
+	created, err := scanDebarment(dr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - CreateDebarment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (dr *DebarmentRepo) GetDebarmentForUpdate(ctx context.Context, debarmentID uuid.UUID) (*entity.Debarment, error) {
+	sql, args, _ := dr.Builder.
+		Select(debarmentColumns...).
+		From("debarment").
+		Where(squirrel.Eq{"id": debarmentID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	debarment, err := scanDebarment(dr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - GetDebarmentForUpdate: %w", err)
+	}
+
+	return debarment, nil
+}
+
+// GetDebarments lists the debarments, latest first. With activeOnly set the
+// lifted and expired ones are left out.
+func (dr *DebarmentRepo) GetDebarments(ctx context.Context, limit, offset int, activeOnly bool) ([]*entity.Debarment, error) {
+	query := dr.Builder.
+		Select(debarmentColumns...).
+		From("debarment")
+	if activeOnly {
+		query = query.Where(activeDebarment(time.Now().UTC()))
+	}
+
+	sql, args, _ := query.
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := dr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - GetDebarments: %w", err)
+	}
+
+	return scanDebarments(rows)
+}
+
+func (dr *DebarmentRepo) UpdateDebarment(ctx context.Context, debarmentID uuid.UUID, updates map[string]interface{}) (*entity.Debarment, error) {
+	sql, args, _ := dr.Builder.
+		Update("debarment").
+		SetMap(updates).
+		Where(squirrel.Eq{"id": debarmentID}).
+		Suffix(debarmentReturning).
+		ToSql()
+
+	debarment, err := scanDebarment(dr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - UpdateDebarment: %w", err)
+	}
+
+	return debarment, nil
+}
+
+// FindActiveDebarment returns a debarment that keeps the employee from
+// bidding on a tender of the buyer organization: one of the employee or of
+// an organization they are responsible for, either global or scoped to the
+// buyer.
+func (dr *DebarmentRepo) FindActiveDebarment(ctx context.Context, employeeID, buyerOrganizationID uuid.UUID) (*entity.Debarment, error) {
+	sql, args, _ := dr.Builder.
+		Select(debarmentColumns...).
+		From("debarment").
+		Where(squirrel.Or{
+			squirrel.Eq{"employee_id": employeeID},
+			squirrel.Expr("organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)", employeeID),
+		}).
+		Where(squirrel.Or{
+			squirrel.Eq{"buyer_organization_id": nil},
+			squirrel.Eq{"buyer_organization_id": buyerOrganizationID},
+		}).
+		Where(activeDebarment(time.Now().UTC())).
+		OrderBy("created_at ASC").
+		Limit(1).
+		ToSql()
+
+	debarment, err := scanDebarment(dr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - FindActiveDebarment: %w", err)
+	}
+
+	return debarment, nil
+}
+
+func (dr *DebarmentRepo) CreateDebarmentBlock(ctx context.Context, b *entity.DebarmentBlock) (*entity.DebarmentBlock, error) {
+	sql, args, _ := dr.Builder.
+		Insert("debarment_block").
+		Columns("id", "debarment_id", "tender_id", "employee_id").
+		Values(uuid.New(), b.DebarmentID, b.TenderID, b.EmployeeID).
+		Suffix("RETURNING " + strings.Join(debarmentBlockColumns, ", ")).
+		ToSql()
+
+	var block entity.DebarmentBlock
+	err := dr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+		&block.ID,
+		&block.DebarmentID,
+		&block.TenderID,
+		&block.EmployeeID,
+		&block.AttemptedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - CreateDebarmentBlock: %w", err)
+	}
+
+	return &block, nil
+}
+
+func (dr *DebarmentRepo) GetDebarmentBlocks(ctx context.Context, limit, offset int) ([]*entity.DebarmentBlock, error) {
+	sql, args, _ := dr.Builder.
+		Select(debarmentBlockColumns...).
+		From("debarment_block").
+		OrderBy("attempted_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := dr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - DebarmentRepo - GetDebarmentBlocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []*entity.DebarmentBlock
+	for rows.Next() {
+		var block entity.DebarmentBlock
+		err := rows.Scan(
+			&block.ID,
+			&block.DebarmentID,
+			&block.TenderID,
+			&block.EmployeeID,
+			&block.AttemptedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		blocks = append(blocks, &block)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return blocks, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
@@ -11,6 +12,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type EmployeeRepo struct {
@@ -90,3 +92,125 @@ func (er *EmployeeRepo) IsResponsible(ctx context.Context, employeeID uuid.UUID)
 	}
 	return true, nil
 }
+
+var employeeKeyColumns = []string{
+	"id",
+	"employee_id",
+	"algorithm",
+	"public_key",
+	"fingerprint",
+	"created_at",
+	"revoked_at",
+}
+
+func scanEmployeeKey(row pgx.Row) (*entity.EmployeeKey, error) {
+	var k entity.EmployeeKey
+	err := row.Scan(
+		&k.ID,
+		&k.EmployeeID,
+		&k.Algorithm,
+		&k.PublicKey,
+		&k.Fingerprint,
+		&k.CreatedAt,
+		&k.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (er *EmployeeRepo) CreateKey(ctx context.Context, k *entity.EmployeeKey) (*entity.EmployeeKey, error) {
+	sql, args, _ := er.Builder.
+		Insert("employee_key").
+		Columns("id", "employee_id", "algorithm", "public_key", "fingerprint").
+		Values(uuid.New(), k.EmployeeID, k.Algorithm, k.PublicKey, k.Fingerprint).
+		Suffix("RETURNING " + strings.Join(employeeKeyColumns, ", ")).
+		ToSql()
+
+	key, err := scanEmployeeKey(er.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, repoerrs.ErrAlreadyExists
+			case "23503":
+				return nil, repoerrs.ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("pgdb - EmployeeRepo - CreateKey: %w", err)
+	}
+
+	return key, nil
+}
+
+func (er *EmployeeRepo) GetKeyByID(ctx context.Context, keyID uuid.UUID) (*entity.EmployeeKey, error) {
+	sql, args, _ := er.Builder.
+		Select(employeeKeyColumns...).
+		From("employee_key").
+		Where(squirrel.Eq{"id": keyID}).
+		ToSql()
+
+	key, err := scanEmployeeKey(er.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - EmployeeRepo - GetKeyByID: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetKeys lists the keys of the employee, revoked ones included, oldest
+// first.
+func (er *EmployeeRepo) GetKeys(ctx context.Context, employeeID uuid.UUID) ([]*entity.EmployeeKey, error) {
+	sql, args, _ := er.Builder.
+		Select(employeeKeyColumns...).
+		From("employee_key").
+		Where(squirrel.Eq{"employee_id": employeeID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := er.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - EmployeeRepo - GetKeys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.EmployeeKey
+	for rows.Next() {
+		key, err := scanEmployeeKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - EmployeeRepo - GetKeys: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgdb - EmployeeRepo - GetKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeKey marks the key revoked. Revoking a key twice keeps the time it
+// was first revoked.
+func (er *EmployeeRepo) RevokeKey(ctx context.Context, keyID uuid.UUID) (*entity.EmployeeKey, error) {
+	sql, args, _ := er.Builder.
+		Update("employee_key").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, CURRENT_TIMESTAMP)")).
+		Where(squirrel.Eq{"id": keyID}).
+		Suffix("RETURNING " + strings.Join(employeeKeyColumns, ", ")).
+		ToSql()
+
+	key, err := scanEmployeeKey(er.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - EmployeeRepo - RevokeKey: %w", err)
+	}
+
+	return key, nil
+}
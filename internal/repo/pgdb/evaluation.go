@@ -0,0 +1,177 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type EvaluationRepo struct {
+	*postgres.Postgres
+}
+
+func NewEvaluationRepo(pg *postgres.Postgres) *EvaluationRepo {
+	return &EvaluationRepo{pg}
+}
+
+func (er *EvaluationRepo) CreateCriteria(ctx context.Context, tenderID uuid.UUID, criteria []*entity.Criterion) ([]*entity.Criterion, error) {
+	created := make([]*entity.Criterion, 0, len(criteria))
+	for _, c := range criteria {
+		sql, args, _ := er.Builder.
+			Insert("tender_criterion").
+			Columns("id", "tender_id", "name", "weight").
+			Values(uuid.New(), tenderID, c.Name, c.Weight).
+			Suffix("RETURNING id, tender_id, name, weight").
+			ToSql()
+
+		var criterion entity.Criterion
+		err := er.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+			&criterion.ID,
+			&criterion.TenderID,
+			&criterion.Name,
+			&criterion.Weight,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, repoerrs.ErrAlreadyExists
+			}
+			return nil, fmt.Errorf("pgdb - EvaluationRepo - CreateCriteria: %w", err)
+		}
+		created = append(created, &criterion)
+	}
+
+	return created, nil
+}
+
+func (er *EvaluationRepo) GetCriteriaByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Criterion, error) {
+	sql, args, _ := er.Builder.
+		Select("id", "tender_id", "name", "weight").
+		From("tender_criterion").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("weight DESC", "name ASC").
+		ToSql()
+
+	rows, err := er.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - EvaluationRepo - GetCriteriaByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var criteria []*entity.Criterion
+	for rows.Next() {
+		var criterion entity.Criterion
+		if err := rows.Scan(
+			&criterion.ID,
+			&criterion.TenderID,
+			&criterion.Name,
+			&criterion.Weight,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		criteria = append(criteria, &criterion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return criteria, nil
+}
+
+// UpsertBidScore stores the score of a reviewer; scoring the same criterion
+// again replaces the previous value.
+func (er *EvaluationRepo) UpsertBidScore(ctx context.Context, score *entity.BidScore) (*entity.BidScore, error) {
+	sql, args, _ := er.Builder.
+		Insert("bid_score").
+		Columns("id", "bid_id", "criterion_id", "reviewer_id", "score").
+		Values(uuid.New(), score.BidID, score.CriterionID, score.ReviewerID, score.Score).
+		Suffix("ON CONFLICT (bid_id, criterion_id, reviewer_id) DO UPDATE SET score = EXCLUDED.score, updated_at = CURRENT_TIMESTAMP").
+		Suffix("RETURNING id, bid_id, criterion_id, reviewer_id, score, created_at, updated_at").
+		ToSql()
+
+	var saved entity.BidScore
+	err := er.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+		&saved.ID,
+		&saved.BidID,
+		&saved.CriterionID,
+		&saved.ReviewerID,
+		&saved.Score,
+		&saved.CreatedAt,
+		&saved.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - EvaluationRepo - UpsertBidScore: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (er *EvaluationRepo) GetScoresByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.BidScore, error) {
+	sql, args, _ := er.Builder.
+		Select("s.id", "s.bid_id", "s.criterion_id", "s.reviewer_id", "s.score", "s.created_at", "s.updated_at").
+		From("bid_score s").
+		Join("bid b ON b.id = s.bid_id").
+		Where(squirrel.Eq{"b.tender_id": tenderID}).
+		ToSql()
+
+	rows, err := er.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - EvaluationRepo - GetScoresByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []*entity.BidScore
+	for rows.Next() {
+		var score entity.BidScore
+		if err := rows.Scan(
+			&score.ID,
+			&score.BidID,
+			&score.CriterionID,
+			&score.ReviewerID,
+			&score.Score,
+			&score.CreatedAt,
+			&score.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		scores = append(scores, &score)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return scores, nil
+}
+
+// CountMissingScores returns how many (active bid, criterion) pairs of the
+// tender have not been scored by any reviewer yet.
+func (er *EvaluationRepo) CountMissingScores(ctx context.Context, tenderID uuid.UUID) (int, error) {
+	sql, args, _ := er.Builder.
+		Select("count(*)").
+		From("bid b").
+		Join("tender_criterion c ON c.tender_id = b.tender_id").
+		Where(squirrel.Eq{"b.tender_id": tenderID}).
+		Where(squirrel.NotEq{"b.status": entity.InactiveBidStatuses}).
+		Where("NOT EXISTS (SELECT 1 FROM bid_score s WHERE s.bid_id = b.id AND s.criterion_id = c.id)").
+		ToSql()
+
+	var count int
+	if err := er.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("pgdb - EvaluationRepo - CountMissingScores: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,141 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// invitedTenders selects the tenders an employee is invited to, directly or
+// as a responsible of an invited organization. It takes the employee ID twice.
+const invitedTenders = `SELECT tender_id FROM tender_invitation
+	WHERE employee_id = ?
+	OR organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)`
+
+var invitationColumns = []string{
+	"id",
+	"tender_id",
+	"organization_id",
+	"employee_id",
+	"created_at",
+}
+
+var invitationReturning = "RETURNING " + strings.Join(invitationColumns, ", ")
+
+func scanInvitation(row pgx.Row) (*entity.Invitation, error) {
+	var i entity.Invitation
+	err := row.Scan(
+		&i.ID,
+		&i.TenderID,
+		&i.OrganizationID,
+		&i.EmployeeID,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+type InvitationRepo struct {
+	*postgres.Postgres
+}
+
+func NewInvitationRepo(pg *postgres.Postgres) *InvitationRepo {
+	return &InvitationRepo{pg}
+}
+
+func (ir *InvitationRepo) CreateInvitation(ctx context.Context, i *entity.Invitation) (*entity.Invitation, error) {
+	sql, args, _ := ir.Builder.
+		Insert("tender_invitation").
+		Columns("id", "tender_id", "organization_id", "employee_id").
+		Values(uuid.New(), i.TenderID, i.OrganizationID, i.EmployeeID).
+		Suffix(invitationReturning).
+		ToSql()
+
+	created, err := scanInvitation(ir.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, repoerrs.ErrAlreadyExists
+			case "23503":
+				return nil, repoerrs.ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("pgdb - InvitationRepo - CreateInvitation: %w", err)
+	}
+
+	return created, nil
+}
+
+func (ir *InvitationRepo) GetInvitationsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Invitation, error) {
+	sql, args, _ := ir.Builder.
+		Select(invitationColumns...).
+		From("tender_invitation").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := ir.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - InvitationRepo - GetInvitationsByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*entity.Invitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (ir *InvitationRepo) DeleteInvitation(ctx context.Context, tenderID, invitationID uuid.UUID) error {
+	sql, args, _ := ir.Builder.
+		Delete("tender_invitation").
+		Where(squirrel.Eq{"id": invitationID, "tender_id": tenderID}).
+		ToSql()
+
+	tag, err := ir.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - InvitationRepo - DeleteInvitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ir *InvitationRepo) IsInvited(ctx context.Context, tenderID, employeeID uuid.UUID) (bool, error) {
+	sql, args, _ := ir.Builder.
+		Select().
+		Column(squirrel.Expr("EXISTS (SELECT 1 FROM tender WHERE id = ? AND id IN ("+invitedTenders+"))", tenderID, employeeID, employeeID)).
+		ToSql()
+
+	var invited bool
+	if err := ir.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&invited); err != nil {
+		return false, fmt.Errorf("pgdb - InvitationRepo - IsInvited: %w", err)
+	}
+
+	return invited, nil
+}
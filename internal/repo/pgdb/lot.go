@@ -0,0 +1,218 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var lotColumns = []string{
+	"id",
+	"tender_id",
+	"name",
+	"description",
+	"budget",
+	"status",
+	"winner_bid_id",
+	"decided_at",
+	"created_at",
+}
+
+var lotReturning = "RETURNING " + strings.Join(lotColumns, ", ")
+
+func scanLot(row pgx.Row) (*entity.Lot, error) {
+	var lot entity.Lot
+	err := row.Scan(
+		&lot.ID,
+		&lot.TenderID,
+		&lot.Name,
+		&lot.Description,
+		&lot.Budget,
+		&lot.Status,
+		&lot.WinnerBidID,
+		&lot.DecidedAt,
+		&lot.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+type LotRepo struct {
+	*postgres.Postgres
+}
+
+func NewLotRepo(pg *postgres.Postgres) *LotRepo {
+	return &LotRepo{pg}
+}
+
+func (lr *LotRepo) CreateLots(ctx context.Context, tenderID uuid.UUID, lots []*entity.Lot) ([]*entity.Lot, error) {
+	created := make([]*entity.Lot, 0, len(lots))
+	for _, l := range lots {
+		sql, args, _ := lr.Builder.
+			Insert("tender_lot").
+			Columns("id", "tender_id", "name", "description", "budget", "status").
+			Values(uuid.New(), tenderID, l.Name, l.Description, l.Budget, entity.LotStatusOpen).
+			Suffix(lotReturning).
+			ToSql()
+
+		lot, err := scanLot(lr.Querier(ctx).QueryRow(ctx, sql, args...))
+		if err != nil {
+			return nil, fmt.Errorf("pgdb - LotRepo - CreateLots: %w", err)
+		}
+		created = append(created, lot)
+	}
+
+	return created, nil
+}
+
+func (lr *LotRepo) GetLotsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Lot, error) {
+	sql, args, _ := lr.Builder.
+		Select(lotColumns...).
+		From("tender_lot").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC", "name ASC").
+		ToSql()
+
+	rows, err := lr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - LotRepo - GetLotsByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []*entity.Lot
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return lots, nil
+}
+
+// GetLotForUpdate locks the lot row until the surrounding transaction ends.
+func (lr *LotRepo) GetLotForUpdate(ctx context.Context, lotID uuid.UUID) (*entity.Lot, error) {
+	sql, args, _ := lr.Builder.
+		Select(lotColumns...).
+		From("tender_lot").
+		Where(squirrel.Eq{"id": lotID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	lot, err := scanLot(lr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - LotRepo - GetLotForUpdate: %w", err)
+	}
+
+	return lot, nil
+}
+
+func (lr *LotRepo) UpdateLotDecision(ctx context.Context, lotID uuid.UUID, status string, winnerBidID *uuid.UUID) (*entity.Lot, error) {
+	sql, args, _ := lr.Builder.
+		Update("tender_lot").
+		Set("status", status).
+		Set("winner_bid_id", winnerBidID).
+		Set("decided_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": lotID}).
+		Suffix(lotReturning).
+		ToSql()
+
+	lot, err := scanLot(lr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - LotRepo - UpdateLotDecision: %w", err)
+	}
+
+	return lot, nil
+}
+
+func (lr *LotRepo) CountOpenLots(ctx context.Context, tenderID uuid.UUID) (int, error) {
+	sql, args, _ := lr.Builder.
+		Select("count(*)").
+		From("tender_lot").
+		Where(squirrel.Eq{"tender_id": tenderID, "status": entity.LotStatusOpen}).
+		ToSql()
+
+	var count int
+	if err := lr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("pgdb - LotRepo - CountOpenLots: %w", err)
+	}
+
+	return count, nil
+}
+
+func (lr *LotRepo) SetBidLots(ctx context.Context, bidID uuid.UUID, lotIDs []uuid.UUID) error {
+	sql, args, _ := lr.Builder.
+		Delete("bid_lot").
+		Where(squirrel.Eq{"bid_id": bidID}).
+		ToSql()
+
+	if _, err := lr.Querier(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("pgdb - LotRepo - SetBidLots: %w", err)
+	}
+
+	if len(lotIDs) == 0 {
+		return nil
+	}
+
+	insert := lr.Builder.Insert("bid_lot").Columns("bid_id", "lot_id")
+	for _, lotID := range lotIDs {
+		insert = insert.Values(bidID, lotID)
+	}
+	sql, args, _ = insert.ToSql()
+
+	if _, err := lr.Querier(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("pgdb - LotRepo - SetBidLots: %w", err)
+	}
+
+	return nil
+}
+
+func (lr *LotRepo) GetBidLotIDs(ctx context.Context, bidID uuid.UUID) ([]uuid.UUID, error) {
+	sql, args, _ := lr.Builder.
+		Select("lot_id").
+		From("bid_lot").
+		Where(squirrel.Eq{"bid_id": bidID}).
+		ToSql()
+
+	rows, err := lr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - LotRepo - GetBidLotIDs: %w", err)
+	}
+	defer rows.Close()
+
+	var lotIDs []uuid.UUID
+	for rows.Next() {
+		var lotID uuid.UUID
+		if err := rows.Scan(&lotID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		lotIDs = append(lotIDs, lotID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return lotIDs, nil
+}
@@ -0,0 +1,117 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var messageColumns = []string{
+	"id",
+	"bid_id",
+	"sender_id",
+	"body",
+	"created_at",
+	"read_at",
+}
+
+var messageReturning = "RETURNING " + strings.Join(messageColumns, ", ")
+
+func scanMessage(row pgx.Row) (*entity.Message, error) {
+	var m entity.Message
+	err := row.Scan(
+		&m.ID,
+		&m.BidID,
+		&m.SenderID,
+		&m.Body,
+		&m.CreatedAt,
+		&m.ReadAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+type MessageRepo struct {
+	*postgres.Postgres
+}
+
+func NewMessageRepo(pg *postgres.Postgres) *MessageRepo {
+	return &MessageRepo{pg}
+}
+
+func (mr *MessageRepo) CreateMessage(ctx context.Context, m *entity.Message) (*entity.Message, error) {
+	sql, args, _ := mr.Builder.
+		Insert("bid_message").
+		Columns("id", "bid_id", "sender_id", "body").
+		Values(uuid.New(), m.BidID, m.SenderID, m.Body).
+		Suffix(messageReturning).
+		ToSql()
+
+	created, err := scanMessage(mr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - MessageRepo - CreateMessage: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetMessagesByBid returns a page of the thread, newest messages first.
+func (mr *MessageRepo) GetMessagesByBid(ctx context.Context, limit, offset int, bidID uuid.UUID) ([]*entity.Message, error) {
+	sql, args, _ := mr.Builder.
+		Select(messageColumns...).
+		From("bid_message").
+		Where(squirrel.Eq{"bid_id": bidID}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := mr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - MessageRepo - GetMessagesByBid: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*entity.Message
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkMessagesRead sets the read receipt of the given messages that have not
+// been read yet and returns the time it used.
+func (mr *MessageRepo) MarkMessagesRead(ctx context.Context, messageIDs []uuid.UUID) (time.Time, error) {
+	readAt := time.Now().UTC()
+
+	sql, args, _ := mr.Builder.
+		Update("bid_message").
+		Set("read_at", readAt).
+		Where(squirrel.Eq{"id": messageIDs}).
+		Where(squirrel.Eq{"read_at": nil}).
+		ToSql()
+
+	if _, err := mr.Querier(ctx).Exec(ctx, sql, args...); err != nil {
+		return time.Time{}, fmt.Errorf("pgdb - MessageRepo - MarkMessagesRead: %w", err)
+	}
+
+	return readAt, nil
+}
@@ -0,0 +1,157 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var negotiationColumns = []string{
+	"id",
+	"bid_id",
+	"round",
+	"bid_version",
+	"proposed_by",
+	"target_price",
+	"comment",
+	"status",
+	"response_comment",
+	"response_bid_version",
+	"created_at",
+	"responded_at",
+}
+
+var negotiationReturning = "RETURNING " + strings.Join(negotiationColumns, ", ")
+
+func scanNegotiationRound(row pgx.Row) (*entity.NegotiationRound, error) {
+	var n entity.NegotiationRound
+	err := row.Scan(
+		&n.ID,
+		&n.BidID,
+		&n.Round,
+		&n.BidVersion,
+		&n.ProposedBy,
+		&n.TargetPrice,
+		&n.Comment,
+		&n.Status,
+		&n.ResponseComment,
+		&n.ResponseBidVersion,
+		&n.CreatedAt,
+		&n.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+type NegotiationRepo struct {
+	*postgres.Postgres
+}
+
+func NewNegotiationRepo(pg *postgres.Postgres) *NegotiationRepo {
+	return &NegotiationRepo{pg}
+}
+
+// CreateRound opens a negotiation round. Only one round of a bid can be open
+// at a time.
+func (nr *NegotiationRepo) CreateRound(ctx context.Context, n *entity.NegotiationRound) (*entity.NegotiationRound, error) {
+	sql, args, _ := nr.Builder.
+		Insert("bid_negotiation_round").
+		Columns("id", "bid_id", "round", "bid_version", "proposed_by", "target_price", "comment").
+		Values(uuid.New(), n.BidID, n.Round, n.BidVersion, n.ProposedBy, n.TargetPrice, n.Comment).
+		Suffix(negotiationReturning).
+		ToSql()
+
+	created, err := scanNegotiationRound(nr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("pgdb - NegotiationRepo - CreateRound: %w", err)
+	}
+
+	return created, nil
+}
+
+func (nr *NegotiationRepo) GetRoundsByBid(ctx context.Context, bidID uuid.UUID) ([]*entity.NegotiationRound, error) {
+	sql, args, _ := nr.Builder.
+		Select(negotiationColumns...).
+		From("bid_negotiation_round").
+		Where(squirrel.Eq{"bid_id": bidID}).
+		OrderBy("round ASC").
+		ToSql()
+
+	rows, err := nr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - NegotiationRepo - GetRoundsByBid: %w", err)
+	}
+	defer rows.Close()
+
+	var rounds []*entity.NegotiationRound
+	for rows.Next() {
+		round, err := scanNegotiationRound(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		rounds = append(rounds, round)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return rounds, nil
+}
+
+func (nr *NegotiationRepo) GetOpenRoundForUpdate(ctx context.Context, bidID uuid.UUID) (*entity.NegotiationRound, error) {
+	sql, args, _ := nr.Builder.
+		Select(negotiationColumns...).
+		From("bid_negotiation_round").
+		Where(squirrel.Eq{"bid_id": bidID, "status": entity.NegotiationOpen}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	round, err := scanNegotiationRound(nr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - NegotiationRepo - GetOpenRoundForUpdate: %w", err)
+	}
+
+	return round, nil
+}
+
+func (nr *NegotiationRepo) CloseRound(ctx context.Context, n *entity.NegotiationRound) (*entity.NegotiationRound, error) {
+	sql, args, _ := nr.Builder.
+		Update("bid_negotiation_round").
+		Set("status", n.Status).
+		Set("response_comment", n.ResponseComment).
+		Set("response_bid_version", n.ResponseBidVersion).
+		Set("responded_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": n.ID}).
+		Suffix(negotiationReturning).
+		ToSql()
+
+	closed, err := scanNegotiationRound(nr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - NegotiationRepo - CloseRound: %w", err)
+	}
+
+	return closed, nil
+}
@@ -0,0 +1,129 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var notificationColumns = []string{
+	"id",
+	"recipient_id",
+	"type",
+	"tender_id",
+	"bid_id",
+	"message",
+	"created_at",
+	"read_at",
+}
+
+var notificationReturning = "RETURNING " + strings.Join(notificationColumns, ", ")
+
+func scanNotification(row pgx.Row) (*entity.Notification, error) {
+	var n entity.Notification
+	err := row.Scan(
+		&n.ID,
+		&n.RecipientID,
+		&n.Type,
+		&n.TenderID,
+		&n.BidID,
+		&n.Message,
+		&n.CreatedAt,
+		&n.ReadAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+type NotificationRepo struct {
+	*postgres.Postgres
+}
+
+func NewNotificationRepo(pg *postgres.Postgres) *NotificationRepo {
+	return &NotificationRepo{pg}
+}
+
+func (nr *NotificationRepo) CreateNotification(ctx context.Context, n *entity.Notification) (*entity.Notification, error) {
+	sql, args, _ := nr.Builder.
+		Insert("notification").
+		Columns("id", "recipient_id", "type", "tender_id", "bid_id", "message").
+		Values(uuid.New(), n.RecipientID, n.Type, n.TenderID, n.BidID, n.Message).
+		Suffix(notificationReturning).
+		ToSql()
+
+	created, err := scanNotification(nr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - NotificationRepo - CreateNotification: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetNotifications returns a page of the recipient's notifications, newest first.
+func (nr *NotificationRepo) GetNotifications(ctx context.Context, limit, offset int, recipientID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	queryBuilder := nr.Builder.
+		Select(notificationColumns...).
+		From("notification").
+		Where(squirrel.Eq{"recipient_id": recipientID})
+
+	if unreadOnly {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"read_at": nil})
+	}
+
+	sql, args, _ := queryBuilder.
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := nr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - NotificationRepo - GetNotifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*entity.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (nr *NotificationRepo) MarkNotificationRead(ctx context.Context, notificationID, recipientID uuid.UUID) (*entity.Notification, error) {
+	sql, args, _ := nr.Builder.
+		Update("notification").
+		Set("read_at", squirrel.Expr("COALESCE(read_at, ?)", time.Now().UTC())).
+		Where(squirrel.Eq{"id": notificationID, "recipient_id": recipientID}).
+		Suffix(notificationReturning).
+		ToSql()
+
+	notification, err := scanNotification(nr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - NotificationRepo - MarkNotificationRead: %w", err)
+	}
+
+	return notification, nil
+}
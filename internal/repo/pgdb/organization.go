@@ -75,3 +75,72 @@ func (or *OrganizationRepo) IsResponsibleForTender(ctx context.Context, userID u
 
 	return count > 0, nil
 }
+
+// GetEmployeeOrganizationID returns the organization the employee is
+// responsible for. Employees responsible for several organizations get the
+// one they were added to first.
+func (or *OrganizationRepo) GetEmployeeOrganizationID(ctx context.Context, employeeID uuid.UUID) (uuid.UUID, error) {
+	sql, args, _ := or.Builder.
+		Select("organization_id").
+		From("organization_responsible").
+		Where(squirrel.Eq{"user_id": employeeID}).
+		OrderBy("id").
+		Limit(1).
+		ToSql()
+
+	var organizationID uuid.UUID
+	err := or.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, repoerrs.ErrNotFound
+		}
+		return uuid.Nil, fmt.Errorf("OrganizationRepo.GetEmployeeOrganizationID - query execution failed: %w", err)
+	}
+
+	return organizationID, nil
+}
+
+func (or *OrganizationRepo) OrganizationExists(ctx context.Context, organizationID uuid.UUID) (bool, error) {
+	sql, args, _ := or.Builder.
+		Select("count(*)").
+		From("organization").
+		Where(squirrel.Eq{"id": organizationID}).
+		ToSql()
+
+	var count int
+	err := or.Querier(ctx).QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("OrganizationRepo.OrganizationExists - query execution failed: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (or *OrganizationRepo) GetEmployeeOrganizationIDs(ctx context.Context, employeeID uuid.UUID) ([]uuid.UUID, error) {
+	sql, args, _ := or.Builder.
+		Select("organization_id").
+		From("organization_responsible").
+		Where(squirrel.Eq{"user_id": employeeID}).
+		ToSql()
+
+	rows, err := or.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OrganizationRepo.GetEmployeeOrganizationIDs - query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	var organizationIDs []uuid.UUID
+	for rows.Next() {
+		var organizationID uuid.UUID
+		if err := rows.Scan(&organizationID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		organizationIDs = append(organizationIDs, organizationID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return organizationIDs, nil
+}
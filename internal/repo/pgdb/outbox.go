@@ -0,0 +1,142 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var outboxColumns = []string{
+	"id",
+	"seq",
+	"type",
+	"aggregate_type",
+	"aggregate_id",
+	"payload",
+	"occurred_at",
+	"attempts",
+	"next_attempt_at",
+	"last_error",
+	"delivered_at",
+}
+
+func scanOutboxEvent(row pgx.Row) (*entity.OutboxEvent, error) {
+	var e entity.OutboxEvent
+	err := row.Scan(
+		&e.ID,
+		&e.Seq,
+		&e.Type,
+		&e.AggregateType,
+		&e.AggregateID,
+		&e.Payload,
+		&e.OccurredAt,
+		&e.Attempts,
+		&e.NextAttemptAt,
+		&e.LastError,
+		&e.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+type OutboxRepo struct {
+	*postgres.Postgres
+}
+
+func NewOutboxRepo(pg *postgres.Postgres) *OutboxRepo {
+	return &OutboxRepo{pg}
+}
+
+func (or *OutboxRepo) CreateEvent(ctx context.Context, e *entity.OutboxEvent) (*entity.OutboxEvent, error) {
+	sql, args, _ := or.Builder.
+		Insert("outbox").
+		Columns("id", "type", "aggregate_type", "aggregate_id", "payload", "occurred_at", "next_attempt_at").
+		Values(uuid.New(), e.Type, e.AggregateType, e.AggregateID, e.Payload, e.OccurredAt, e.OccurredAt).
+		Suffix("RETURNING " + strings.Join(outboxColumns, ", ")).
+		ToSql()
+
+	event, err := scanOutboxEvent(or.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - OutboxRepo - CreateEvent: %w", err)
+	}
+
+	return event, nil
+}
+
+// GetDueEventForUpdate locks the earliest undelivered event due at now,
+// skipping the ones another relay already holds. An event waits for the
+// earlier events of its aggregate, so that they are delivered in order.
+func (or *OutboxRepo) GetDueEventForUpdate(ctx context.Context, now time.Time) (*entity.OutboxEvent, error) {
+	sql, args, _ := or.Builder.
+		Select(outboxColumns...).
+		From("outbox o").
+		Where(squirrel.Eq{"o.delivered_at": nil}).
+		Where(squirrel.LtOrEq{"o.next_attempt_at": now}).
+		Where("NOT EXISTS (SELECT 1 FROM outbox p WHERE p.aggregate_id = o.aggregate_id AND p.delivered_at IS NULL AND p.seq < o.seq)").
+		OrderBy("o.seq ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+
+	event, err := scanOutboxEvent(or.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - OutboxRepo - GetDueEventForUpdate: %w", err)
+	}
+
+	return event, nil
+}
+
+func (or *OutboxRepo) MarkDelivered(ctx context.Context, eventID uuid.UUID, deliveredAt time.Time) error {
+	sql, args, _ := or.Builder.
+		Update("outbox").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", "").
+		Set("delivered_at", deliveredAt).
+		Where(squirrel.Eq{"id": eventID}).
+		ToSql()
+
+	tag, err := or.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - OutboxRepo - MarkDelivered: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkFailed counts a failed delivery and schedules the next attempt.
+func (or *OutboxRepo) MarkFailed(ctx context.Context, eventID uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	sql, args, _ := or.Builder.
+		Update("outbox").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", lastError).
+		Set("next_attempt_at", nextAttemptAt).
+		Where(squirrel.Eq{"id": eventID}).
+		ToSql()
+
+	tag, err := or.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - OutboxRepo - MarkFailed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
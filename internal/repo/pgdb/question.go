@@ -0,0 +1,147 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var questionColumns = []string{
+	"id",
+	"tender_id",
+	"author_id",
+	"question",
+	"answer",
+	"answered_by",
+	"visibility",
+	"tender_version",
+	"created_at",
+	"answered_at",
+}
+
+var questionReturning = "RETURNING " + strings.Join(questionColumns, ", ")
+
+func scanQuestion(row pgx.Row) (*entity.Question, error) {
+	var q entity.Question
+	err := row.Scan(
+		&q.ID,
+		&q.TenderID,
+		&q.AuthorID,
+		&q.Question,
+		&q.Answer,
+		&q.AnsweredBy,
+		&q.Visibility,
+		&q.TenderVersion,
+		&q.CreatedAt,
+		&q.AnsweredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+type QuestionRepo struct {
+	*postgres.Postgres
+}
+
+func NewQuestionRepo(pg *postgres.Postgres) *QuestionRepo {
+	return &QuestionRepo{pg}
+}
+
+func (qr *QuestionRepo) CreateQuestion(ctx context.Context, q *entity.Question) (*entity.Question, error) {
+	sql, args, _ := qr.Builder.
+		Insert("tender_question").
+		Columns("id", "tender_id", "author_id", "question").
+		Values(uuid.New(), q.TenderID, q.AuthorID, q.Question).
+		Suffix(questionReturning).
+		ToSql()
+
+	created, err := scanQuestion(qr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - QuestionRepo - CreateQuestion: %w", err)
+	}
+
+	return created, nil
+}
+
+func (qr *QuestionRepo) GetQuestionsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Question, error) {
+	sql, args, _ := qr.Builder.
+		Select(questionColumns...).
+		From("tender_question").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := qr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - QuestionRepo - GetQuestionsByTender: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []*entity.Question
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		questions = append(questions, question)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (qr *QuestionRepo) GetQuestionForUpdate(ctx context.Context, questionID uuid.UUID) (*entity.Question, error) {
+	sql, args, _ := qr.Builder.
+		Select(questionColumns...).
+		From("tender_question").
+		Where(squirrel.Eq{"id": questionID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	question, err := scanQuestion(qr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - QuestionRepo - GetQuestionForUpdate: %w", err)
+	}
+
+	return question, nil
+}
+
+func (qr *QuestionRepo) AnswerQuestion(ctx context.Context, q *entity.Question) (*entity.Question, error) {
+	sql, args, _ := qr.Builder.
+		Update("tender_question").
+		Set("answer", q.Answer).
+		Set("answered_by", q.AnsweredBy).
+		Set("visibility", q.Visibility).
+		Set("tender_version", q.TenderVersion).
+		Set("answered_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": q.ID}).
+		Suffix(questionReturning).
+		ToSql()
+
+	answered, err := scanQuestion(qr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - QuestionRepo - AnswerQuestion: %w", err)
+	}
+
+	return answered, nil
+}
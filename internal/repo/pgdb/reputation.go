@@ -0,0 +1,162 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var reputationColumns = []string{
+	"subject_type",
+	"subject_id",
+	"bids_submitted",
+	"bids_withdrawn",
+	"bids_decided",
+	"bids_approved",
+	"ratings_count",
+	"ratings_sum",
+	"milestones_completed",
+	"milestones_on_time",
+	"score",
+	"updated_at",
+}
+
+// reputationCounters are the columns AddReputation adds its deltas to.
+var reputationCounters = reputationColumns[2:10]
+
+var reputationReturning = "RETURNING " + strings.Join(reputationColumns, ", ")
+
+func scanReputation(row pgx.Row) (*entity.Reputation, error) {
+	var r entity.Reputation
+	err := row.Scan(
+		&r.SubjectType,
+		&r.SubjectID,
+		&r.BidsSubmitted,
+		&r.BidsWithdrawn,
+		&r.BidsDecided,
+		&r.BidsApproved,
+		&r.RatingsCount,
+		&r.RatingsSum,
+		&r.MilestonesCompleted,
+		&r.MilestonesOnTime,
+		&r.Score,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+type ReputationRepo struct {
+	*postgres.Postgres
+}
+
+func NewReputationRepo(pg *postgres.Postgres) *ReputationRepo {
+	return &ReputationRepo{pg}
+}
+
+// AddReputation adds the counters of delta to the reputation of its subject,
+// creating it on the first event. The row stays locked until the end of the
+// transaction so that the score can be recomputed from the returned counters.
+func (rr *ReputationRepo) AddReputation(ctx context.Context, delta *entity.Reputation) (*entity.Reputation, error) {
+	updates := make([]string, 0, len(reputationCounters)+1)
+	for _, column := range reputationCounters {
+		updates = append(updates, fmt.Sprintf("%s = reputation.%s + EXCLUDED.%s", column, column, column))
+	}
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
+
+	sql, args, _ := rr.Builder.
+		Insert("reputation").
+		Columns(reputationColumns[:10]...).
+		Values(
+			delta.SubjectType,
+			delta.SubjectID,
+			delta.BidsSubmitted,
+			delta.BidsWithdrawn,
+			delta.BidsDecided,
+			delta.BidsApproved,
+			delta.RatingsCount,
+			delta.RatingsSum,
+			delta.MilestonesCompleted,
+			delta.MilestonesOnTime,
+		).
+		Suffix("ON CONFLICT (subject_type, subject_id) DO UPDATE SET " + strings.Join(updates, ", ") + " " + reputationReturning).
+		ToSql()
+
+	reputation, err := scanReputation(rr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ReputationRepo - AddReputation: %w", err)
+	}
+
+	return reputation, nil
+}
+
+func (rr *ReputationRepo) UpdateReputationScore(ctx context.Context, subjectType string, subjectID uuid.UUID, score *float64) error {
+	sql, args, _ := rr.Builder.
+		Update("reputation").
+		Set("score", score).
+		Where(squirrel.Eq{"subject_type": subjectType, "subject_id": subjectID}).
+		ToSql()
+
+	if _, err := rr.Querier(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("pgdb - ReputationRepo - UpdateReputationScore: %w", err)
+	}
+
+	return nil
+}
+
+func (rr *ReputationRepo) GetReputation(ctx context.Context, subjectType string, subjectID uuid.UUID) (*entity.Reputation, error) {
+	sql, args, _ := rr.Builder.
+		Select(reputationColumns...).
+		From("reputation").
+		Where(squirrel.Eq{"subject_type": subjectType, "subject_id": subjectID}).
+		ToSql()
+
+	reputation, err := scanReputation(rr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - ReputationRepo - GetReputation: %w", err)
+	}
+
+	return reputation, nil
+}
+
+func (rr *ReputationRepo) GetReputations(ctx context.Context, subjectType string, subjectIDs []uuid.UUID) ([]*entity.Reputation, error) {
+	sql, args, _ := rr.Builder.
+		Select(reputationColumns...).
+		From("reputation").
+		Where(squirrel.Eq{"subject_type": subjectType, "subject_id": subjectIDs}).
+		ToSql()
+
+	rows, err := rr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ReputationRepo - GetReputations: %w", err)
+	}
+	defer rows.Close()
+
+	var reputations []*entity.Reputation
+	for rows.Next() {
+		reputation, err := scanReputation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		reputations = append(reputations, reputation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return reputations, nil
+}
@@ -0,0 +1,272 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var seriesColumns = []string{
+	"id",
+	"organization_id",
+	"template_id",
+	"rule",
+	"starts_at",
+	"placeholder_values",
+	"submission_days",
+	"auto_publish",
+	"status",
+	"next_occurrence",
+	"next_run_at",
+	"last_error",
+	"created_by",
+	"created_at",
+	"updated_at",
+}
+
+var seriesReturning = "RETURNING " + strings.Join(seriesColumns, ", ")
+
+func scanSeries(row pgx.Row) (*entity.TenderSeries, error) {
+	var s entity.TenderSeries
+	err := row.Scan(
+		&s.ID,
+		&s.OrganizationID,
+		&s.TemplateID,
+		&s.Rule,
+		&s.StartsAt,
+		&s.Values,
+		&s.SubmissionDays,
+		&s.AutoPublish,
+		&s.Status,
+		&s.NextOccurrence,
+		&s.NextRunAt,
+		&s.LastError,
+		&s.CreatedBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+type SeriesRepo struct {
+	*postgres.Postgres
+}
+
+func NewSeriesRepo(pg *postgres.Postgres) *SeriesRepo {
+	return &SeriesRepo{pg}
+}
+
+func (sr *SeriesRepo) CreateSeries(ctx context.Context, s *entity.TenderSeries) (*entity.TenderSeries, error) {
+	values := s.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	sql, args, _ := sr.Builder.
+		Insert("tender_series").
+		Columns("id", "organization_id", "template_id", "rule", "starts_at", "placeholder_values", "submission_days",
+			"auto_publish", "status", "next_occurrence", "next_run_at", "created_by").
+		Values(uuid.New(), s.OrganizationID, s.TemplateID, s.Rule, s.StartsAt, values, s.SubmissionDays,
+			s.AutoPublish, s.Status, s.NextOccurrence, s.NextRunAt, s.CreatedBy).
+		Suffix(seriesReturning).
+		ToSql()
+
+	series, err := scanSeries(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - CreateSeries: %w", err)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepo) GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*entity.TenderSeries, error) {
+	sql, args, _ := sr.Builder.
+		Select(seriesColumns...).
+		From("tender_series").
+		Where(squirrel.Eq{"id": seriesID}).
+		ToSql()
+
+	series, err := scanSeries(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - GetSeriesByID: %w", err)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepo) GetSeriesForUpdate(ctx context.Context, seriesID uuid.UUID) (*entity.TenderSeries, error) {
+	sql, args, _ := sr.Builder.
+		Select(seriesColumns...).
+		From("tender_series").
+		Where(squirrel.Eq{"id": seriesID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	series, err := scanSeries(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - GetSeriesForUpdate: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetDueSeriesForUpdate locks one active series whose next run is due,
+// skipping the ones another scheduler already holds.
+func (sr *SeriesRepo) GetDueSeriesForUpdate(ctx context.Context, now time.Time) (*entity.TenderSeries, error) {
+	sql, args, _ := sr.Builder.
+		Select(seriesColumns...).
+		From("tender_series").
+		Where(squirrel.Eq{"status": entity.SeriesStatusActive}).
+		Where(squirrel.LtOrEq{"next_run_at": now}).
+		OrderBy("next_run_at ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+
+	series, err := scanSeries(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - GetDueSeriesForUpdate: %w", err)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepo) GetSeriesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.TenderSeries, error) {
+	sql, args, _ := sr.Builder.
+		Select(seriesColumns...).
+		From("tender_series").
+		Where(squirrel.Eq{"organization_id": organizationID}).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	rows, err := sr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - SeriesRepo - GetSeriesByOrganization: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*entity.TenderSeries
+	for rows.Next() {
+		s, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		series = append(series, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepo) UpdateSeries(ctx context.Context, seriesID uuid.UUID, updates map[string]interface{}) (*entity.TenderSeries, error) {
+	sql, args, _ := sr.Builder.
+		Update("tender_series").
+		SetMap(updates).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{"id": seriesID}).
+		Suffix(seriesReturning).
+		ToSql()
+
+	series, err := scanSeries(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - UpdateSeries: %w", err)
+	}
+
+	return series, nil
+}
+
+func (sr *SeriesRepo) CreateInstance(ctx context.Context, i *entity.SeriesInstance) (*entity.SeriesInstance, error) {
+	sql, args, _ := sr.Builder.
+		Insert("tender_series_instance").
+		Columns("series_id", "tender_id", "occurrence", "scheduled_at").
+		Values(i.SeriesID, i.TenderID, i.Occurrence, i.ScheduledAt).
+		Suffix("RETURNING series_id, tender_id, occurrence, scheduled_at, created_at").
+		ToSql()
+
+	var instance entity.SeriesInstance
+	err := sr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+		&instance.SeriesID,
+		&instance.TenderID,
+		&instance.Occurrence,
+		&instance.ScheduledAt,
+		&instance.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, repoerrs.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("pgdb - SeriesRepo - CreateInstance: %w", err)
+	}
+
+	return &instance, nil
+}
+
+func (sr *SeriesRepo) GetInstancesBySeries(ctx context.Context, seriesID uuid.UUID) ([]*entity.SeriesInstance, error) {
+	sql, args, _ := sr.Builder.
+		Select("series_id", "tender_id", "occurrence", "scheduled_at", "created_at").
+		From("tender_series_instance").
+		Where(squirrel.Eq{"series_id": seriesID}).
+		OrderBy("occurrence ASC").
+		ToSql()
+
+	rows, err := sr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - SeriesRepo - GetInstancesBySeries: %w", err)
+	}
+	defer rows.Close()
+
+	var instances []*entity.SeriesInstance
+	for rows.Next() {
+		var instance entity.SeriesInstance
+		err := rows.Scan(
+			&instance.SeriesID,
+			&instance.TenderID,
+			&instance.Occurrence,
+			&instance.ScheduledAt,
+			&instance.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		instances = append(instances, &instance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return instances, nil
+}
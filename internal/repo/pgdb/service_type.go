@@ -0,0 +1,209 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var serviceTypeColumns = []string{
+	"id",
+	"name",
+	"code",
+	"parent_id",
+	"labels",
+	"active",
+	"created_at",
+}
+
+var serviceTypeReturning = "RETURNING " + strings.Join(serviceTypeColumns, ", ")
+
+// serviceTypeDescendants selects the names of the given service types and of
+// all their descendants. It takes the list of names as its only argument.
+const serviceTypeDescendants = `WITH RECURSIVE descendants AS (
+	SELECT id, name FROM service_type WHERE name = ANY(?)
+	UNION
+	SELECT st.id, st.name FROM service_type st JOIN descendants d ON st.parent_id = d.id
+) SELECT name FROM descendants`
+
+func scanServiceType(row pgx.Row) (*entity.ServiceType, error) {
+	var st entity.ServiceType
+	err := row.Scan(
+		&st.ID,
+		&st.Name,
+		&st.Code,
+		&st.ParentID,
+		&st.Labels,
+		&st.Active,
+		&st.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func serviceTypeError(op string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repoerrs.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return repoerrs.ErrAlreadyExists
+		case "23503":
+			return repoerrs.ErrInUse
+		}
+	}
+	return fmt.Errorf("pgdb - ServiceTypeRepo - %s: %w", op, err)
+}
+
+type ServiceTypeRepo struct {
+	*postgres.Postgres
+}
+
+func NewServiceTypeRepo(pg *postgres.Postgres) *ServiceTypeRepo {
+	return &ServiceTypeRepo{pg}
+}
+
+func (sr *ServiceTypeRepo) CreateServiceType(ctx context.Context, st *entity.ServiceType) (*entity.ServiceType, error) {
+	sql, args, _ := sr.Builder.
+		Insert("service_type").
+		Columns("id", "name", "code", "parent_id", "labels", "active").
+		Values(uuid.New(), st.Name, st.Code, st.ParentID, st.Labels, true).
+		Suffix(serviceTypeReturning).
+		ToSql()
+
+	created, err := scanServiceType(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, serviceTypeError("CreateServiceType", err)
+	}
+
+	return created, nil
+}
+
+func (sr *ServiceTypeRepo) GetServiceTypes(ctx context.Context) ([]*entity.ServiceType, error) {
+	sql, args, _ := sr.Builder.
+		Select(serviceTypeColumns...).
+		From("service_type").
+		OrderBy("code ASC").
+		ToSql()
+
+	rows, err := sr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ServiceTypeRepo - GetServiceTypes: %w", err)
+	}
+	defer rows.Close()
+
+	var serviceTypes []*entity.ServiceType
+	for rows.Next() {
+		st, err := scanServiceType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		serviceTypes = append(serviceTypes, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return serviceTypes, nil
+}
+
+func (sr *ServiceTypeRepo) GetServiceTypeByID(ctx context.Context, id uuid.UUID) (*entity.ServiceType, error) {
+	sql, args, _ := sr.Builder.
+		Select(serviceTypeColumns...).
+		From("service_type").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	st, err := scanServiceType(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, serviceTypeError("GetServiceTypeByID", err)
+	}
+
+	return st, nil
+}
+
+func (sr *ServiceTypeRepo) GetServiceTypeByName(ctx context.Context, name string) (*entity.ServiceType, error) {
+	sql, args, _ := sr.Builder.
+		Select(serviceTypeColumns...).
+		From("service_type").
+		Where(squirrel.Eq{"name": name}).
+		ToSql()
+
+	st, err := scanServiceType(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, serviceTypeError("GetServiceTypeByName", err)
+	}
+
+	return st, nil
+}
+
+// GetDescendantNames returns the name of the service type and the names of
+// all its descendants.
+func (sr *ServiceTypeRepo) GetDescendantNames(ctx context.Context, name string) ([]string, error) {
+	sql, args, _ := sr.Builder.
+		Select("name").
+		From("service_type").
+		Where("name IN ("+serviceTypeDescendants+")", []string{name}).
+		OrderBy("code ASC").
+		ToSql()
+
+	rows, err := sr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ServiceTypeRepo - GetDescendantNames: %w", err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - ServiceTypeRepo - GetDescendantNames: %w", err)
+	}
+
+	return names, nil
+}
+
+func (sr *ServiceTypeRepo) UpdateServiceType(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*entity.ServiceType, error) {
+	sql, args, _ := sr.Builder.
+		Update("service_type").
+		SetMap(updates).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(serviceTypeReturning).
+		ToSql()
+
+	st, err := scanServiceType(sr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, serviceTypeError("UpdateServiceType", err)
+	}
+
+	return st, nil
+}
+
+func (sr *ServiceTypeRepo) DeleteServiceType(ctx context.Context, id uuid.UUID) error {
+	sql, args, _ := sr.Builder.
+		Delete("service_type").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	tag, err := sr.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return serviceTypeError("DeleteServiceType", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var templateColumns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"content",
+	"created_by",
+	"created_at",
+}
+
+func scanTemplate(row pgx.Row) (*entity.TenderTemplate, error) {
+	var t entity.TenderTemplate
+	err := row.Scan(
+		&t.ID,
+		&t.OrganizationID,
+		&t.Name,
+		&t.Content,
+		&t.CreatedBy,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+type TemplateRepo struct {
+	*postgres.Postgres
+}
+
+func NewTemplateRepo(pg *postgres.Postgres) *TemplateRepo {
+	return &TemplateRepo{pg}
+}
+
+func (tr *TemplateRepo) CreateTemplate(ctx context.Context, t *entity.TenderTemplate) (*entity.TenderTemplate, error) {
+	sql, args, _ := tr.Builder.
+		Insert("tender_template").
+		Columns("id", "organization_id", "name", "content", "created_by").
+		Values(uuid.New(), t.OrganizationID, t.Name, t.Content, t.CreatedBy).
+		Suffix("RETURNING " + strings.Join(templateColumns, ", ")).
+		ToSql()
+
+	template, err := scanTemplate(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, repoerrs.ErrAlreadyExists
+			case "23503":
+				return nil, repoerrs.ErrNotFound
+			}
+		}
+		return nil, fmt.Errorf("pgdb - TemplateRepo - CreateTemplate: %w", err)
+	}
+
+	return template, nil
+}
+
+func (tr *TemplateRepo) GetTemplateByID(ctx context.Context, templateID uuid.UUID) (*entity.TenderTemplate, error) {
+	sql, args, _ := tr.Builder.
+		Select(templateColumns...).
+		From("tender_template").
+		Where(squirrel.Eq{"id": templateID}).
+		ToSql()
+
+	template, err := scanTemplate(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - TemplateRepo - GetTemplateByID: %w", err)
+	}
+
+	return template, nil
+}
+
+func (tr *TemplateRepo) GetTemplatesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.TenderTemplate, error) {
+	sql, args, _ := tr.Builder.
+		Select(templateColumns...).
+		From("tender_template").
+		Where(squirrel.Eq{"organization_id": organizationID}).
+		OrderBy("name ASC").
+		ToSql()
+
+	rows, err := tr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - TemplateRepo - GetTemplatesByOrganization: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*entity.TenderTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (tr *TemplateRepo) DeleteTemplate(ctx context.Context, templateID, organizationID uuid.UUID) error {
+	sql, args, _ := tr.Builder.
+		Delete("tender_template").
+		Where(squirrel.Eq{"id": templateID, "organization_id": organizationID}).
+		ToSql()
+
+	tag, err := tr.Querier(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("pgdb - TemplateRepo - DeleteTemplate: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
@@ -14,6 +16,80 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var tenderColumns = []string{
+	"id",
+	"name",
+	"description",
+	"service_type",
+	"status",
+	"version",
+	"organization_id",
+	"creator_username",
+	"sealed",
+	"submission_deadline",
+	"opened_at",
+	"budget",
+	"currency",
+	"auction_starts_at",
+	"auction_ends_at",
+	"access_mode",
+	"cancellation_category",
+	"cancellation_reason",
+	"canceled_at",
+	"created_at",
+}
+
+var tenderReturning = "RETURNING " + strings.Join(tenderColumns, ", ")
+
+func scanTender(row pgx.Row) (*entity.Tender, error) {
+	var tender entity.Tender
+	err := row.Scan(
+		&tender.ID,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.Status,
+		&tender.Version,
+		&tender.OrganizationID,
+		&tender.CreatorUsername,
+		&tender.Sealed,
+		&tender.SubmissionDeadline,
+		&tender.OpenedAt,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.AuctionStartsAt,
+		&tender.AuctionEndsAt,
+		&tender.AccessMode,
+		&tender.CancellationCategory,
+		&tender.CancellationReason,
+		&tender.CanceledAt,
+		&tender.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tender, nil
+}
+
+func scanTenders(rows pgx.Rows) ([]*entity.Tender, error) {
+	defer rows.Close()
+
+	var tenders []*entity.Tender
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		tenders = append(tenders, tender)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return tenders, nil
+}
+
 type TenderRepo struct {
 	*postgres.Postgres
 }
@@ -25,25 +101,12 @@ func NewTenderRepo(pg *postgres.Postgres) *TenderRepo {
 func (tr *TenderRepo) CreateTender(ctx context.Context, t *entity.Tender) (*entity.Tender, error) {
 	sql, args, _ := tr.Builder.
 		Insert("tender").
-		Columns("id", "name", "description", "service_type", "status", "organization_id", "creator_username").
-		Values(uuid.New(), t.Name, t.Description, t.ServiceType, "CREATED", t.OrganizationID, t.CreatorUsername).
-		Suffix("RETURNING id, name, description, service_type, status,version, organization_id, creator_username, created_at").
+		Columns("id", "name", "description", "service_type", "status", "organization_id", "creator_username", "sealed", "submission_deadline", "budget", "currency", "auction_starts_at", "auction_ends_at", "access_mode").
+		Values(uuid.New(), t.Name, t.Description, t.ServiceType, "CREATED", t.OrganizationID, t.CreatorUsername, t.Sealed, t.SubmissionDeadline, t.Budget, t.Currency, t.AuctionStartsAt, t.AuctionEndsAt, t.AccessMode).
+		Suffix(tenderReturning).
 		ToSql()
 
-	var createdTender entity.Tender
-
-	err := tr.Pool.QueryRow(ctx, sql, args...).Scan(
-		&createdTender.ID,
-		&createdTender.Name,
-		&createdTender.Description,
-		&createdTender.ServiceType,
-		&createdTender.Status,
-		&createdTender.Version,
-		&createdTender.OrganizationID,
-		&createdTender.CreatorUsername,
-		&createdTender.CreatedAt,
-	)
-
+	createdTender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if ok := errors.As(err, &pgErr); ok {
@@ -54,16 +117,24 @@ func (tr *TenderRepo) CreateTender(ctx context.Context, t *entity.Tender) (*enti
 		return nil, fmt.Errorf("pgdb - TenderRepo - CreateTender: %w", err)
 	}
 
-	return &createdTender, nil
+	return createdTender, nil
 }
 
-func (tr *TenderRepo) GetTenders(ctx context.Context, limit int, offset int, serviceTypes []string) ([]*entity.Tender, error) {
+// GetTenders lists tenders by name. Filtering by a service type matches its
+// subcategories as well. Invitation-only tenders are listed only to the
+// viewer's invited or owning organizations.
+func (tr *TenderRepo) GetTenders(ctx context.Context, limit int, offset int, serviceTypes []string, viewerID uuid.UUID) ([]*entity.Tender, error) {
 	queryBuilder := tr.Builder.
-		Select("id", "name", "description", "service_type", "status", "version", "organization_id", "creator_username", "created_at").
-		From("tender")
+		Select(tenderColumns...).
+		From("tender").
+		Where(squirrel.Or{
+			squirrel.Eq{"access_mode": entity.TenderAccessOpen},
+			squirrel.Expr("id IN ("+invitedTenders+")", viewerID, viewerID),
+			squirrel.Expr("organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)", viewerID),
+		})
 
 	if len(serviceTypes) > 0 {
-		queryBuilder = queryBuilder.Where(squirrel.Eq{"service_type": serviceTypes})
+		queryBuilder = queryBuilder.Where("service_type IN ("+serviceTypeDescendants+")", serviceTypes)
 	}
 	queryBuilder = queryBuilder.OrderBy("name ASC")
 
@@ -79,36 +150,12 @@ func (tr *TenderRepo) GetTenders(ctx context.Context, limit int, offset int, ser
 		}
 		return nil, fmt.Errorf("pgdb - TenderRepo - GetTenders: %w", err)
 	}
-	defer rows.Close()
-
-	var tenders []*entity.Tender
-	for rows.Next() {
-		var tender entity.Tender
-		if err := rows.Scan(
-			&tender.ID,
-			&tender.Name,
-			&tender.Description,
-			&tender.ServiceType,
-			&tender.Status,
-			&tender.Version,
-			&tender.OrganizationID,
-			&tender.CreatorUsername,
-			&tender.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		tenders = append(tenders, &tender)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
-	}
 
-	return tenders, nil
+	return scanTenders(rows)
 }
 
 func (tr *TenderRepo) GetUserTenders(ctx context.Context, limit, offset int, username string) ([]*entity.Tender, error) {
-	sql, args, _ := tr.Builder.Select("id", "name", "description", "service_type", "status", "version", "organization_id", "creator_username", "created_at").
+	sql, args, _ := tr.Builder.Select(tenderColumns...).
 		From("tender").
 		Where(squirrel.Eq{"creator_username": username}).
 		OrderBy("name ASC").
@@ -123,61 +170,78 @@ func (tr *TenderRepo) GetUserTenders(ctx context.Context, limit, offset int, use
 		}
 		return nil, fmt.Errorf("pgdb - TenderRepo - GetUserTenders: %w", err)
 	}
-	defer rows.Close()
 
-	var tenders []*entity.Tender
-	for rows.Next() {
-		var tender entity.Tender
-		if err := rows.Scan(
-			&tender.ID,
-			&tender.Name,
-			&tender.Description,
-			&tender.ServiceType,
-			&tender.Status,
-			&tender.Version,
-			&tender.OrganizationID,
-			&tender.CreatorUsername,
-			&tender.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		tenders = append(tenders, &tender)
-	}
+	return scanTenders(rows)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+func (tr *TenderRepo) GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
+	sql, args, _ := tr.Builder.
+		Select(tenderColumns...).
+		From("tender").
+		Where(squirrel.Eq{"id": tenderID}).
+		ToSql()
+
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - TenderRepo - GetTenderByID: %w", err)
 	}
 
-	return tenders, nil
+	return tender, nil
 }
 
-func (tr *TenderRepo) GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
+// GetTenderForUpdate locks the tender row until the surrounding transaction ends.
+func (tr *TenderRepo) GetTenderForUpdate(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
 	sql, args, _ := tr.Builder.
-		Select("id", "name", "description", "service_type", "status", "version", "organization_id", "creator_username", "created_at").
+		Select(tenderColumns...).
 		From("tender").
 		Where(squirrel.Eq{"id": tenderID}).
+		Suffix("FOR UPDATE").
 		ToSql()
 
-	var tender entity.Tender
-	err := tr.Pool.QueryRow(ctx, sql, args...).Scan(
-		&tender.ID,
-		&tender.Name,
-		&tender.Description,
-		&tender.ServiceType,
-		&tender.Status,
-		&tender.Version,
-		&tender.OrganizationID,
-		&tender.CreatorUsername,
-		&tender.CreatedAt,
-	)
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repoerrs.ErrNotFound
 		}
-		return nil, fmt.Errorf("pgdb - TenderRepo - GetTenderByID: %w", err)
+		return nil, fmt.Errorf("pgdb - TenderRepo - GetTenderForUpdate: %w", err)
 	}
 
-	return &tender, nil
+	return tender, nil
+}
+
+// GetDueSealedTenderForUpdate locks one sealed tender that is not opened yet
+// although its submission deadline passed at now, skipping the tenders in
+// skip and the ones another job already holds.
+func (tr *TenderRepo) GetDueSealedTenderForUpdate(ctx context.Context, now time.Time, skip []uuid.UUID) (*entity.Tender, error) {
+	query := tr.Builder.
+		Select(tenderColumns...).
+		From("tender").
+		Where(squirrel.Eq{"sealed": true, "opened_at": nil}).
+		Where(squirrel.Or{
+			squirrel.LtOrEq{"submission_deadline": now},
+			squirrel.LtOrEq{"auction_ends_at": now},
+		})
+	if len(skip) > 0 {
+		query = query.Where(squirrel.NotEq{"id": skip})
+	}
+	sql, args, _ := query.
+		OrderBy("submission_deadline ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - TenderRepo - GetDueSealedTenderForUpdate: %w", err)
+	}
+
+	return tender, nil
 }
 
 func (tr *TenderRepo) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, status string) (*entity.Tender, error) {
@@ -186,21 +250,10 @@ func (tr *TenderRepo) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID
 		Set("status", status).
 		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": tenderID}).
-		Suffix("RETURNING id, name, description, service_type, status, version, organization_id, creator_username, created_at").
+		Suffix(tenderReturning).
 		ToSql()
 
-	var tender entity.Tender
-	err := tr.Pool.QueryRow(ctx, sql, args...).Scan(
-		&tender.ID,
-		&tender.Name,
-		&tender.Description,
-		&tender.ServiceType,
-		&tender.Status,
-		&tender.Version,
-		&tender.OrganizationID,
-		&tender.CreatorUsername,
-		&tender.CreatedAt,
-	)
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repoerrs.ErrNotFound
@@ -208,7 +261,51 @@ func (tr *TenderRepo) UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID
 		return nil, fmt.Errorf("pgdb - TenderRepo - UpdateTenderStatus: %w", err)
 	}
 
-	return &tender, nil
+	return tender, nil
+}
+
+func (tr *TenderRepo) CancelTender(ctx context.Context, tenderID uuid.UUID, category, reason string) (*entity.Tender, error) {
+	sql, args, _ := tr.Builder.
+		Update("tender").
+		Set("status", entity.TenderStatusCanceled).
+		Set("cancellation_category", category).
+		Set("cancellation_reason", reason).
+		Set("canceled_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": tenderID}).
+		Suffix(tenderReturning).
+		ToSql()
+
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - TenderRepo - CancelTender: %w", err)
+	}
+
+	return tender, nil
+}
+
+// IncrementTenderVersion records a change of the tender that is not a change
+// of its own columns, such as a published clarification.
+func (tr *TenderRepo) IncrementTenderVersion(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error) {
+	sql, args, _ := tr.Builder.
+		Update("tender").
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": tenderID}).
+		Suffix(tenderReturning).
+		ToSql()
+
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pgdb - TenderRepo - IncrementTenderVersion: %w", err)
+	}
+
+	return tender, nil
 }
 
 func (tr *TenderRepo) UpdateTender(ctx context.Context, tenderID uuid.UUID, updates map[string]interface{}) (*entity.Tender, error) {
@@ -216,21 +313,10 @@ func (tr *TenderRepo) UpdateTender(ctx context.Context, tenderID uuid.UUID, upda
 		Update("tender").
 		SetMap(updates).
 		Where(squirrel.Eq{"id": tenderID}).
-		Suffix("RETURNING id, name, description, service_type, status, version, organization_id, creator_username, created_at").
+		Suffix(tenderReturning).
 		ToSql()
 
-	var tender entity.Tender
-	err := tr.Pool.QueryRow(ctx, sql, args...).Scan(
-		&tender.ID,
-		&tender.Name,
-		&tender.Description,
-		&tender.ServiceType,
-		&tender.Status,
-		&tender.Version,
-		&tender.OrganizationID,
-		&tender.CreatorUsername,
-		&tender.CreatedAt,
-	)
+	tender, err := scanTender(tr.Querier(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repoerrs.ErrNotFound
@@ -238,5 +324,110 @@ func (tr *TenderRepo) UpdateTender(ctx context.Context, tenderID uuid.UUID, upda
 		return nil, fmt.Errorf("pgdb - TenderRepo - UpdateTender: %w", err)
 	}
 
-	return &tender, nil
+	return tender, nil
+}
+
+func (tr *TenderRepo) CreateTenderOpening(ctx context.Context, tenderID uuid.UUID, bidCount int) (*entity.TenderOpening, error) {
+	sql, args, _ := tr.Builder.
+		Insert("tender_opening").
+		Columns("id", "tender_id", "bid_count").
+		Values(uuid.New(), tenderID, bidCount).
+		Suffix("RETURNING id, tender_id, bid_count, opened_at").
+		ToSql()
+
+	var opening entity.TenderOpening
+	err := tr.Querier(ctx).QueryRow(ctx, sql, args...).Scan(
+		&opening.ID,
+		&opening.TenderID,
+		&opening.BidCount,
+		&opening.OpenedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - TenderRepo - CreateTenderOpening: %w", err)
+	}
+
+	sql, args, _ = tr.Builder.
+		Update("tender").
+		Set("opened_at", opening.OpenedAt).
+		Where(squirrel.Eq{"id": tenderID}).
+		ToSql()
+
+	if _, err = tr.Querier(ctx).Exec(ctx, sql, args...); err != nil {
+		return nil, fmt.Errorf("pgdb - TenderRepo - CreateTenderOpening: %w", err)
+	}
+
+	return &opening, nil
+}
+
+var tenderAmendmentColumns = []string{
+	"id",
+	"tender_id",
+	"version",
+	"reason",
+	"changes",
+	"created_by",
+	"created_at",
+}
+
+func scanTenderAmendment(row pgx.Row) (*entity.TenderAmendment, error) {
+	var a entity.TenderAmendment
+	err := row.Scan(
+		&a.ID,
+		&a.TenderID,
+		&a.Version,
+		&a.Reason,
+		&a.Changes,
+		&a.CreatedBy,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (tr *TenderRepo) CreateTenderAmendment(ctx context.Context, a *entity.TenderAmendment) (*entity.TenderAmendment, error) {
+	sql, args, _ := tr.Builder.
+		Insert("tender_amendment").
+		Columns("id", "tender_id", "version", "reason", "changes", "created_by").
+		Values(uuid.New(), a.TenderID, a.Version, a.Reason, a.Changes, a.CreatedBy).
+		Suffix("RETURNING " + strings.Join(tenderAmendmentColumns, ", ")).
+		ToSql()
+
+	amendment, err := scanTenderAmendment(tr.Querier(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - TenderRepo - CreateTenderAmendment: %w", err)
+	}
+
+	return amendment, nil
+}
+
+func (tr *TenderRepo) GetTenderAmendments(ctx context.Context, tenderID uuid.UUID) ([]*entity.TenderAmendment, error) {
+	sql, args, _ := tr.Builder.
+		Select(tenderAmendmentColumns...).
+		From("tender_amendment").
+		Where(squirrel.Eq{"tender_id": tenderID}).
+		OrderBy("version ASC").
+		ToSql()
+
+	rows, err := tr.Querier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgdb - TenderRepo - GetTenderAmendments: %w", err)
+	}
+	defer rows.Close()
+
+	var amendments []*entity.TenderAmendment
+	for rows.Next() {
+		amendment, err := scanTenderAmendment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		amendments = append(amendments, amendment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return amendments, nil
 }
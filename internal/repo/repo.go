@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo/pgdb"
@@ -9,18 +10,35 @@ import (
 	"github.com/google/uuid"
 )
 
+// Transactor runs fn in a single database transaction shared by every
+// repository call made with the context it receives.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Employee interface {
 	GetByUsername(ctx context.Context, username string) (*entity.Employee, error)
 	GetByID(ctx context.Context, employeeID uuid.UUID) (*entity.Employee, error)
 	IsResponsible(ctx context.Context, employeeID uuid.UUID) (bool, error)
+	CreateKey(ctx context.Context, k *entity.EmployeeKey) (*entity.EmployeeKey, error)
+	GetKeyByID(ctx context.Context, keyID uuid.UUID) (*entity.EmployeeKey, error)
+	GetKeys(ctx context.Context, employeeID uuid.UUID) ([]*entity.EmployeeKey, error)
+	RevokeKey(ctx context.Context, keyID uuid.UUID) (*entity.EmployeeKey, error)
 }
 type Tender interface {
 	CreateTender(ctx context.Context, t *entity.Tender) (*entity.Tender, error)
-	GetTenders(ctx context.Context, limit, offset int, serviceTypes []string) ([]*entity.Tender, error)
+	GetTenders(ctx context.Context, limit, offset int, serviceTypes []string, viewerID uuid.UUID) ([]*entity.Tender, error)
 	GetUserTenders(ctx context.Context, limit, offset int, username string) ([]*entity.Tender, error)
 	GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error)
+	GetTenderForUpdate(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error)
+	GetDueSealedTenderForUpdate(ctx context.Context, now time.Time, skip []uuid.UUID) (*entity.Tender, error)
 	UpdateTenderStatus(ctx context.Context, tenderID uuid.UUID, status string) (*entity.Tender, error)
 	UpdateTender(ctx context.Context, tenderID uuid.UUID, updates map[string]interface{}) (*entity.Tender, error)
+	CancelTender(ctx context.Context, tenderID uuid.UUID, category, reason string) (*entity.Tender, error)
+	IncrementTenderVersion(ctx context.Context, tenderID uuid.UUID) (*entity.Tender, error)
+	CreateTenderAmendment(ctx context.Context, a *entity.TenderAmendment) (*entity.TenderAmendment, error)
+	GetTenderAmendments(ctx context.Context, tenderID uuid.UUID) ([]*entity.TenderAmendment, error)
+	CreateTenderOpening(ctx context.Context, tenderID uuid.UUID, bidCount int) (*entity.TenderOpening, error)
 }
 
 type Bid interface {
@@ -28,28 +46,232 @@ type Bid interface {
 	FindByTenderAndAuthor(ctx context.Context, tenderID, authorID uuid.UUID) (*entity.Bid, error)
 	GetUserBids(ctx context.Context, limit, offset int, authorID uuid.UUID) ([]*entity.Bid, error)
 	GetBidsByTender(ctx context.Context, limit int, offset int, tenderID uuid.UUID) ([]*entity.Bid, error)
+	GetAllBidsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error)
 	GetBidByID(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error)
+	GetBidForUpdate(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error)
 	UpdateBidStatus(ctx context.Context, bidID uuid.UUID, status string) (*entity.Bid, error)
 	UpdateBid(ctx context.Context, bidID uuid.UUID, updates map[string]interface{}) (*entity.Bid, error)
+	RevealBid(ctx context.Context, bidID uuid.UUID, updates map[string]interface{}) (*entity.Bid, error)
+	UpdateBidTotalScore(ctx context.Context, bidID uuid.UUID, totalScore *string) (*entity.Bid, error)
 	UpdateBidDecision(ctx context.Context, bidID uuid.UUID, decision string) (*entity.Bid, error)
-	UpdateBidFeedback(ctx context.Context, bidID uuid.UUID, feedback string) (*entity.Bid, error)
+	UpdateBidFeedback(ctx context.Context, bidID uuid.UUID, feedback string, rating *int) (*entity.Bid, error)
+	RequireAcknowledgement(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error)
+	AcknowledgeBid(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error)
+	CancelBidsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Bid, error)
+	CreateBidVersion(ctx context.Context, bid *entity.Bid) (*entity.BidVersion, error)
+	GetBidVersions(ctx context.Context, bidID uuid.UUID) ([]*entity.BidVersion, error)
+	CreateBidSubmission(ctx context.Context, s *entity.BidSubmission) (*entity.BidSubmission, error)
+	GetBidSubmissions(ctx context.Context, afterSeq int64, limit int) ([]*entity.BidSubmission, error)
+	CreateBidSignature(ctx context.Context, s *entity.BidSignature) (*entity.BidSignature, error)
+	GetBidSignature(ctx context.Context, bidID uuid.UUID, version int) (*entity.BidSignature, error)
 }
 type Organization interface {
 	GetOrganizationResponsible(ctx context.Context, organizationID uuid.UUID, employeeID uuid.UUID) (*entity.OrganizationResponsible, error)
 	IsResponsibleForTender(ctx context.Context, userID uuid.UUID, tenderID uuid.UUID) (bool, error)
+	GetEmployeeOrganizationID(ctx context.Context, employeeID uuid.UUID) (uuid.UUID, error)
+	OrganizationExists(ctx context.Context, organizationID uuid.UUID) (bool, error)
+	GetEmployeeOrganizationIDs(ctx context.Context, employeeID uuid.UUID) ([]uuid.UUID, error)
+}
+type Evaluation interface {
+	CreateCriteria(ctx context.Context, tenderID uuid.UUID, criteria []*entity.Criterion) ([]*entity.Criterion, error)
+	GetCriteriaByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Criterion, error)
+	UpsertBidScore(ctx context.Context, score *entity.BidScore) (*entity.BidScore, error)
+	GetScoresByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.BidScore, error)
+	CountMissingScores(ctx context.Context, tenderID uuid.UUID) (int, error)
+}
+type Lot interface {
+	CreateLots(ctx context.Context, tenderID uuid.UUID, lots []*entity.Lot) ([]*entity.Lot, error)
+	GetLotsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Lot, error)
+	GetLotForUpdate(ctx context.Context, lotID uuid.UUID) (*entity.Lot, error)
+	UpdateLotDecision(ctx context.Context, lotID uuid.UUID, status string, winnerBidID *uuid.UUID) (*entity.Lot, error)
+	CountOpenLots(ctx context.Context, tenderID uuid.UUID) (int, error)
+	SetBidLots(ctx context.Context, bidID uuid.UUID, lotIDs []uuid.UUID) error
+	GetBidLotIDs(ctx context.Context, bidID uuid.UUID) ([]uuid.UUID, error)
+}
+type Auction interface {
+	CreateOffer(ctx context.Context, offer *entity.AuctionOffer) (*entity.AuctionOffer, error)
+	GetOffersByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.AuctionOffer, error)
+}
+type ServiceType interface {
+	CreateServiceType(ctx context.Context, st *entity.ServiceType) (*entity.ServiceType, error)
+	GetServiceTypes(ctx context.Context) ([]*entity.ServiceType, error)
+	GetServiceTypeByID(ctx context.Context, id uuid.UUID) (*entity.ServiceType, error)
+	GetServiceTypeByName(ctx context.Context, name string) (*entity.ServiceType, error)
+	GetDescendantNames(ctx context.Context, name string) ([]string, error)
+	UpdateServiceType(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*entity.ServiceType, error)
+	DeleteServiceType(ctx context.Context, id uuid.UUID) error
+}
+type Attachment interface {
+	CreateAttachment(ctx context.Context, a *entity.Attachment) (*entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.Attachment, error)
+	GetAttachmentsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Attachment, error)
+	GetAttachmentsByBid(ctx context.Context, bidID uuid.UUID) ([]*entity.Attachment, error)
+}
+type Question interface {
+	CreateQuestion(ctx context.Context, q *entity.Question) (*entity.Question, error)
+	GetQuestionsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Question, error)
+	GetQuestionForUpdate(ctx context.Context, questionID uuid.UUID) (*entity.Question, error)
+	AnswerQuestion(ctx context.Context, q *entity.Question) (*entity.Question, error)
+}
+type Negotiation interface {
+	CreateRound(ctx context.Context, n *entity.NegotiationRound) (*entity.NegotiationRound, error)
+	GetRoundsByBid(ctx context.Context, bidID uuid.UUID) ([]*entity.NegotiationRound, error)
+	GetOpenRoundForUpdate(ctx context.Context, bidID uuid.UUID) (*entity.NegotiationRound, error)
+	CloseRound(ctx context.Context, n *entity.NegotiationRound) (*entity.NegotiationRound, error)
+}
+type Message interface {
+	CreateMessage(ctx context.Context, m *entity.Message) (*entity.Message, error)
+	GetMessagesByBid(ctx context.Context, limit, offset int, bidID uuid.UUID) ([]*entity.Message, error)
+	MarkMessagesRead(ctx context.Context, messageIDs []uuid.UUID) (time.Time, error)
+}
+type Invitation interface {
+	CreateInvitation(ctx context.Context, i *entity.Invitation) (*entity.Invitation, error)
+	GetInvitationsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.Invitation, error)
+	DeleteInvitation(ctx context.Context, tenderID, invitationID uuid.UUID) error
+	IsInvited(ctx context.Context, tenderID, employeeID uuid.UUID) (bool, error)
+}
+type Notification interface {
+	CreateNotification(ctx context.Context, n *entity.Notification) (*entity.Notification, error)
+	GetNotifications(ctx context.Context, limit, offset int, recipientID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error)
+	MarkNotificationRead(ctx context.Context, notificationID, recipientID uuid.UUID) (*entity.Notification, error)
+}
+type Contract interface {
+	CreateContract(ctx context.Context, c *entity.Contract) (*entity.Contract, error)
+	CountTenderContracts(ctx context.Context, tenderID uuid.UUID) (int, error)
+	GetContractByID(ctx context.Context, contractID uuid.UUID) (*entity.Contract, error)
+	GetContractForUpdate(ctx context.Context, contractID uuid.UUID) (*entity.Contract, error)
+	GetUserContracts(ctx context.Context, limit, offset int, employeeID uuid.UUID, overdueOnly bool) ([]*entity.Contract, error)
+	CompleteContract(ctx context.Context, contractID uuid.UUID, completedAt time.Time) (*entity.Contract, error)
+	CreateMilestone(ctx context.Context, m *entity.Milestone) (*entity.Milestone, error)
+	GetMilestonesByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.Milestone, error)
+	GetMilestoneForUpdate(ctx context.Context, milestoneID uuid.UUID) (*entity.Milestone, error)
+	ConfirmMilestone(ctx context.Context, m *entity.Milestone) (*entity.Milestone, error)
+	GetSupplierRecord(ctx context.Context, organizationID uuid.UUID) (*entity.SupplierRecord, error)
+}
+type Reputation interface {
+	AddReputation(ctx context.Context, delta *entity.Reputation) (*entity.Reputation, error)
+	UpdateReputationScore(ctx context.Context, subjectType string, subjectID uuid.UUID, score *float64) error
+	GetReputation(ctx context.Context, subjectType string, subjectID uuid.UUID) (*entity.Reputation, error)
+	GetReputations(ctx context.Context, subjectType string, subjectIDs []uuid.UUID) ([]*entity.Reputation, error)
+}
+type Debarment interface {
+	CreateDebarment(ctx context.Context, d *entity.Debarment) (*entity.Debarment, error)
+	GetDebarmentForUpdate(ctx context.Context, debarmentID uuid.UUID) (*entity.Debarment, error)
+	GetDebarments(ctx context.Context, limit, offset int, activeOnly bool) ([]*entity.Debarment, error)
+	UpdateDebarment(ctx context.Context, debarmentID uuid.UUID, updates map[string]interface{}) (*entity.Debarment, error)
+	FindActiveDebarment(ctx context.Context, employeeID, buyerOrganizationID uuid.UUID) (*entity.Debarment, error)
+	CreateDebarmentBlock(ctx context.Context, b *entity.DebarmentBlock) (*entity.DebarmentBlock, error)
+	GetDebarmentBlocks(ctx context.Context, limit, offset int) ([]*entity.DebarmentBlock, error)
+}
+type Conflict interface {
+	CreateDeclaration(ctx context.Context, d *entity.ConflictDeclaration) (*entity.ConflictDeclaration, error)
+	GetDeclarationsByEmployee(ctx context.Context, employeeID uuid.UUID) ([]*entity.ConflictDeclaration, error)
+	DeleteDeclaration(ctx context.Context, declarationID, employeeID uuid.UUID) error
+	CreateFlag(ctx context.Context, f *entity.ConflictFlag) (*entity.ConflictFlag, error)
+	GetFlagsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.ConflictFlag, error)
+}
+type Approval interface {
+	CreatePolicy(ctx context.Context, p *entity.ApprovalPolicy) (*entity.ApprovalPolicy, error)
+	GetPoliciesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.ApprovalPolicy, error)
+	DeletePolicy(ctx context.Context, policyID, organizationID uuid.UUID) error
+	FindApplicablePolicy(ctx context.Context, organizationID uuid.UUID, budget *string) (*entity.ApprovalPolicy, error)
+	CreateRequest(ctx context.Context, r *entity.ApprovalRequest) (*entity.ApprovalRequest, error)
+	GetPendingRequestForUpdate(ctx context.Context, tenderID uuid.UUID) (*entity.ApprovalRequest, error)
+	GetRequestsByTender(ctx context.Context, tenderID uuid.UUID) ([]*entity.ApprovalRequest, error)
+	UpdateRequestStatus(ctx context.Context, requestID uuid.UUID, status string, decidedAt time.Time) (*entity.ApprovalRequest, error)
+	CreateStep(ctx context.Context, s *entity.ApprovalStep) (*entity.ApprovalStep, error)
+	GetStepsByRequests(ctx context.Context, requestIDs []uuid.UUID) ([]*entity.ApprovalStep, error)
+}
+type Template interface {
+	CreateTemplate(ctx context.Context, t *entity.TenderTemplate) (*entity.TenderTemplate, error)
+	GetTemplateByID(ctx context.Context, templateID uuid.UUID) (*entity.TenderTemplate, error)
+	GetTemplatesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.TenderTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID, organizationID uuid.UUID) error
+}
+type Series interface {
+	CreateSeries(ctx context.Context, s *entity.TenderSeries) (*entity.TenderSeries, error)
+	GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*entity.TenderSeries, error)
+	GetSeriesForUpdate(ctx context.Context, seriesID uuid.UUID) (*entity.TenderSeries, error)
+	GetDueSeriesForUpdate(ctx context.Context, now time.Time) (*entity.TenderSeries, error)
+	GetSeriesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*entity.TenderSeries, error)
+	UpdateSeries(ctx context.Context, seriesID uuid.UUID, updates map[string]interface{}) (*entity.TenderSeries, error)
+	CreateInstance(ctx context.Context, i *entity.SeriesInstance) (*entity.SeriesInstance, error)
+	GetInstancesBySeries(ctx context.Context, seriesID uuid.UUID) ([]*entity.SeriesInstance, error)
+}
+type Audit interface {
+	CreateEvent(ctx context.Context, e *entity.AuditEvent) (*entity.AuditEvent, error)
+	GetEvents(ctx context.Context, filter *entity.AuditFilter) ([]*entity.AuditEvent, error)
+	GetChainedEvents(ctx context.Context, afterSeq int64, limit int) ([]*entity.AuditEvent, error)
+}
+
+type Outbox interface {
+	CreateEvent(ctx context.Context, e *entity.OutboxEvent) (*entity.OutboxEvent, error)
+	GetDueEventForUpdate(ctx context.Context, now time.Time) (*entity.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, eventID uuid.UUID, deliveredAt time.Time) error
+	MarkFailed(ctx context.Context, eventID uuid.UUID, nextAttemptAt time.Time, lastError string) error
+}
+type Chain interface {
+	GetHead(ctx context.Context, chain string) (*entity.ChainHead, error)
+	GetHeadForUpdate(ctx context.Context, chain string) (*entity.ChainHead, error)
+	UpdateHead(ctx context.Context, chain string, link entity.ChainLink) error
+	CreateCheckpoint(ctx context.Context, c *entity.ChainCheckpoint) (*entity.ChainCheckpoint, error)
+	GetCheckpoints(ctx context.Context, chain string) ([]*entity.ChainCheckpoint, error)
+	GetLatestCheckpoint(ctx context.Context, chain string) (*entity.ChainCheckpoint, error)
 }
 type Repositories struct {
+	Transactor
 	Tender
 	Employee
 	Organization
 	Bid
+	Evaluation
+	Lot
+	Auction
+	ServiceType
+	Attachment
+	Question
+	Message
+	Invitation
+	Notification
+	Negotiation
+	Contract
+	Reputation
+	Debarment
+	Conflict
+	Approval
+	Template
+	Series
+	Audit
+	Chain
+	Outbox
 }
 
 func NewRepositories(pg *postgres.Postgres) *Repositories {
 	return &Repositories{
+		Transactor:   pg,
 		Tender:       pgdb.NewTenderRepo(pg),
 		Employee:     pgdb.NewEmployeeRepo(pg),
 		Organization: pgdb.NewOrganizationRepo(pg),
 		Bid:          pgdb.NewBidRepo(pg),
+		Evaluation:   pgdb.NewEvaluationRepo(pg),
+		Lot:          pgdb.NewLotRepo(pg),
+		Auction:      pgdb.NewAuctionRepo(pg),
+		ServiceType:  pgdb.NewServiceTypeRepo(pg),
+		Attachment:   pgdb.NewAttachmentRepo(pg),
+		Question:     pgdb.NewQuestionRepo(pg),
+		Message:      pgdb.NewMessageRepo(pg),
+		Invitation:   pgdb.NewInvitationRepo(pg),
+		Notification: pgdb.NewNotificationRepo(pg),
+		Negotiation:  pgdb.NewNegotiationRepo(pg),
+		Contract:     pgdb.NewContractRepo(pg),
+		Reputation:   pgdb.NewReputationRepo(pg),
+		Debarment:    pgdb.NewDebarmentRepo(pg),
+		Conflict:     pgdb.NewConflictRepo(pg),
+		Approval:     pgdb.NewApprovalRepo(pg),
+		Template:     pgdb.NewTemplateRepo(pg),
+		Series:       pgdb.NewSeriesRepo(pg),
+		Audit:        pgdb.NewAuditRepo(pg),
+		Chain:        pgdb.NewChainRepo(pg),
+		Outbox:       pgdb.NewOutboxRepo(pg),
 	}
 }
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrAlreadyExists = errors.New("already exists")
 	ErrNotFound      = errors.New("not found")
+	ErrInUse         = errors.New("in use")
 )
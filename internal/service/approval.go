@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type ApprovalService struct {
+	approvalRepo     repo.Approval
+	tenderRepo       repo.Tender
+	organizationRepo repo.Organization
+	audit            *Auditor
+	events           *EventPublisher
+	tx               repo.Transactor
+}
+
+func NewApprovalService(approvalRepo repo.Approval, tenderRepo repo.Tender, organizationRepo repo.Organization, audit *Auditor, events *EventPublisher, tx repo.Transactor) *ApprovalService {
+	return &ApprovalService{
+		approvalRepo:     approvalRepo,
+		tenderRepo:       tenderRepo,
+		organizationRepo: organizationRepo,
+		audit:            audit,
+		events:           events,
+		tx:               tx,
+	}
+}
+
+// requestApproval opens the approval chain of the publication of the tender
+// when a policy of its organization applies. It returns nil when the tender
+// can be published right away.
+func requestApproval(ctx context.Context, approvalRepo repo.Approval, audit *Auditor, tender *entity.Tender, requesterID uuid.UUID) (*entity.ApprovalRequest, error) {
+	policy, err := approvalRepo.FindApplicablePolicy(ctx, tender.OrganizationID, tender.Budget)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	request, err := approvalRepo.CreateRequest(ctx, &entity.ApprovalRequest{
+		TenderID:          tender.ID,
+		PolicyID:          &policy.ID,
+		RequiredApprovals: policy.RequiredApprovals,
+		RequestedBy:       requesterID,
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return nil, ErrApprovalPending
+		}
+		return nil, err
+	}
+	if err = audit.record(ctx, "approval.request", entity.AuditApproval, request.ID, nil, newApprovalRequestOutput(request, nil)); err != nil {
+		return nil, err
+	}
+
+	sl.Info("service - requestApproval", sl.Any("tender_id", tender.ID), sl.Any("policy_id", policy.ID), sl.Any("request_id", request.ID))
+	return request, nil
+}
+
+// checkBudgetChange makes sure a new budget of the locked tender does not
+// escape the sign-off of its publication. The budget cannot change while the
+// publication awaits approval, and a published tender cannot move to a
+// budget whose policy asks for more approvals than the tender got or than
+// its current budget already asked for.
+func checkBudgetChange(ctx context.Context, approvalRepo repo.Approval, tender *entity.Tender, budget string) error {
+	requests, err := approvalRepo.GetRequestsByTender(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+	approved := 0
+	for _, request := range requests {
+		switch request.Status {
+		case entity.ApprovalPending:
+			return ErrApprovalPending
+		case entity.ApprovalApproved:
+			approved = max(approved, request.RequiredApprovals)
+		}
+	}
+	if tender.Status != entity.TenderStatusPublished {
+		return nil
+	}
+
+	required := func(budget *string) (int, error) {
+		policy, err := approvalRepo.FindApplicablePolicy(ctx, tender.OrganizationID, budget)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		return policy.RequiredApprovals, nil
+	}
+	current, err := required(tender.Budget)
+	if err != nil {
+		return err
+	}
+	amended, err := required(&budget)
+	if err != nil {
+		return err
+	}
+	if amended > max(approved, current) {
+		return ErrBudgetNeedsApproval
+	}
+	return nil
+}
+
+func newApprovalPolicyOutput(p *entity.ApprovalPolicy) *ApprovalPolicyOutput {
+	return &ApprovalPolicyOutput{
+		ID:                p.ID,
+		OrganizationID:    p.OrganizationID,
+		Name:              p.Name,
+		MinBudget:         p.MinBudget,
+		RequiredApprovals: p.RequiredApprovals,
+		CreatedBy:         p.CreatedBy,
+		CreatedAt:         p.CreatedAt,
+	}
+}
+
+func newApprovalRequestOutput(r *entity.ApprovalRequest, steps []*entity.ApprovalStep) *ApprovalRequestOutput {
+	output := &ApprovalRequestOutput{
+		ID:                r.ID,
+		TenderID:          r.TenderID,
+		PolicyID:          r.PolicyID,
+		RequiredApprovals: r.RequiredApprovals,
+		RequestedBy:       r.RequestedBy,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt,
+		DecidedAt:         r.DecidedAt,
+		Steps:             make([]*ApprovalStepOutput, 0),
+	}
+	for _, s := range steps {
+		if s.RequestID != r.ID {
+			continue
+		}
+		output.Steps = append(output.Steps, &ApprovalStepOutput{
+			ApproverID: s.ApproverID,
+			Decision:   s.Decision,
+			Comment:    s.Comment,
+			CreatedAt:  s.CreatedAt,
+		})
+	}
+	return output
+}
+
+func (as *ApprovalService) CreatePolicy(ctx context.Context, input *CreateApprovalPolicyInput) (*ApprovalPolicyOutput, error) {
+	const op = "service - ApprovalService - CreatePolicy"
+
+	if err := checkOrganizationResponsible(ctx, as.organizationRepo, input.OrganizationID, input.CreatedBy); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateApprovalPolicy
+	}
+
+	var policy *entity.ApprovalPolicy
+	err := as.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		policy, err = as.approvalRepo.CreatePolicy(ctx, &entity.ApprovalPolicy{
+			OrganizationID:    input.OrganizationID,
+			Name:              input.Name,
+			MinBudget:         optional(input.MinBudget),
+			RequiredApprovals: input.RequiredApprovals,
+			CreatedBy:         input.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		return as.audit.record(ctx, "approval_policy.create", entity.AuditApprovalPolicy, policy.ID, nil, newApprovalPolicyOutput(policy))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateApprovalPolicy
+	}
+
+	return newApprovalPolicyOutput(policy), nil
+}
+
+func (as *ApprovalService) GetPolicies(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*ApprovalPolicyOutput, error) {
+	const op = "service - ApprovalService - GetPolicies"
+
+	if err := checkOrganizationResponsible(ctx, as.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetApprovals
+	}
+
+	policies, err := as.approvalRepo.GetPoliciesByOrganization(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetApprovals
+	}
+
+	output := make([]*ApprovalPolicyOutput, 0, len(policies))
+	for _, policy := range policies {
+		output = append(output, newApprovalPolicyOutput(policy))
+	}
+	return output, nil
+}
+
+// DeletePolicy removes a policy. Approval chains it has already opened keep
+// running with the number of approvals they were opened with.
+func (as *ApprovalService) DeletePolicy(ctx context.Context, organizationID, policyID, employeeID uuid.UUID) error {
+	const op = "service - ApprovalService - DeletePolicy"
+
+	if err := checkOrganizationResponsible(ctx, as.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteApprovalPolicy
+	}
+
+	err := as.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := as.approvalRepo.DeletePolicy(ctx, policyID, organizationID); err != nil {
+			return err
+		}
+		return as.audit.record(ctx, "approval_policy.delete", entity.AuditApprovalPolicy, policyID, nil, nil)
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrApprovalPolicyNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteApprovalPolicy
+	}
+	return nil
+}
+
+// DecideApproval records the sign-off or the rejection of an approver on the
+// pending approval chain of the tender. The tender is published once the
+// chain has collected the approvals it needs; a single rejection ends it.
+func (as *ApprovalService) DecideApproval(ctx context.Context, input *DecideApprovalInput) (*ApprovalRequestOutput, error) {
+	const op = "service - ApprovalService - DecideApproval"
+
+	var output *ApprovalRequestOutput
+	err := as.tx.WithinTx(ctx, func(ctx context.Context) error {
+		request, err := as.approvalRepo.GetPendingRequestForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrApprovalNotFound
+			}
+			return err
+		}
+		if request.RequestedBy == input.ApproverID {
+			return ErrSelfApproval
+		}
+		pending := newApprovalRequestOutput(request, nil)
+
+		_, err = as.approvalRepo.CreateStep(ctx, &entity.ApprovalStep{
+			RequestID:  request.ID,
+			ApproverID: input.ApproverID,
+			Decision:   input.Decision,
+			Comment:    input.Comment,
+		})
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrAlreadyExists) {
+				return ErrAlreadyApproved
+			}
+			return err
+		}
+
+		steps, err := as.approvalRepo.GetStepsByRequests(ctx, []uuid.UUID{request.ID})
+		if err != nil {
+			return err
+		}
+		approvals := 0
+		for _, step := range steps {
+			if step.Decision == entity.ApprovalApproved {
+				approvals++
+			}
+		}
+
+		now := time.Now().UTC()
+		switch {
+		case input.Decision == entity.ApprovalRejected:
+			request, err = as.approvalRepo.UpdateRequestStatus(ctx, request.ID, entity.ApprovalRejected, now)
+			if err != nil {
+				return err
+			}
+		case approvals >= request.RequiredApprovals:
+			tender, err := as.tenderRepo.GetTenderForUpdate(ctx, request.TenderID)
+			if err != nil {
+				return err
+			}
+			if tender.IsCanceled() {
+				return ErrTenderCanceled
+			}
+			request, err = as.approvalRepo.UpdateRequestStatus(ctx, request.ID, entity.ApprovalApproved, now)
+			if err != nil {
+				return err
+			}
+			published, err := as.tenderRepo.UpdateTenderStatus(ctx, tender.ID, entity.TenderStatusPublished)
+			if err != nil {
+				return err
+			}
+			if err = as.audit.record(ctx, "tender.update_status", entity.AuditTender, tender.ID, newTenderOutput(tender), newTenderOutput(published)); err != nil {
+				return err
+			}
+			if err = as.events.publish(ctx, entity.EventTenderPublished, entity.AggregateTender, tender.ID, newTenderOutput(published)); err != nil {
+				return err
+			}
+			sl.Info(op, sl.Any("tender_id", tender.ID), sl.Any("status", entity.TenderStatusPublished))
+		}
+
+		output = newApprovalRequestOutput(request, steps)
+		return as.audit.record(ctx, "approval.decide", entity.AuditApproval, request.ID, pending, output)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrApprovalNotFound), errors.Is(err, ErrSelfApproval),
+			errors.Is(err, ErrAlreadyApproved), errors.Is(err, ErrTenderCanceled):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotDecideApproval
+	}
+
+	return output, nil
+}
+
+func (as *ApprovalService) GetTenderApprovals(ctx context.Context, tenderID uuid.UUID) ([]*ApprovalRequestOutput, error) {
+	const op = "service - ApprovalService - GetTenderApprovals"
+
+	requests, err := as.approvalRepo.GetRequestsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetApprovals
+	}
+	if len(requests) == 0 {
+		return []*ApprovalRequestOutput{}, nil
+	}
+
+	requestIDs := make([]uuid.UUID, 0, len(requests))
+	for _, request := range requests {
+		requestIDs = append(requestIDs, request.ID)
+	}
+	steps, err := as.approvalRepo.GetStepsByRequests(ctx, requestIDs)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetApprovals
+	}
+
+	output := make([]*ApprovalRequestOutput, 0, len(requests))
+	for _, request := range requests {
+		output = append(output, newApprovalRequestOutput(request, steps))
+	}
+	return output, nil
+}
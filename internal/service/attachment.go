@@ -0,0 +1,289 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	sl "log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/blobstore"
+	"github.com/google/uuid"
+)
+
+// maxAttachmentFileName is the length of the file_name column.
+const maxAttachmentFileName = 255
+
+type AttachmentService struct {
+	attachmentRepo repo.Attachment
+	tenderRepo     repo.Tender
+	bidRepo        repo.Bid
+	blobs          blobstore.BlobStore
+	limits         AttachmentLimits
+	audit          *Auditor
+	tx             repo.Transactor
+}
+
+func NewAttachmentService(attachmentRepo repo.Attachment, tenderRepo repo.Tender, bidRepo repo.Bid, blobs blobstore.BlobStore, limits AttachmentLimits, audit *Auditor, tx repo.Transactor) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		tenderRepo:     tenderRepo,
+		bidRepo:        bidRepo,
+		blobs:          blobs,
+		limits:         limits,
+		audit:          audit,
+		tx:             tx,
+	}
+}
+
+func newAttachmentOutput(a *entity.Attachment) *AttachmentOutput {
+	return &AttachmentOutput{
+		ID:          a.ID,
+		TenderID:    a.TenderID,
+		BidID:       a.BidID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func newAttachmentOutputs(attachments []*entity.Attachment) []*AttachmentOutput {
+	output := make([]*AttachmentOutput, 0, len(attachments))
+	for _, a := range attachments {
+		output = append(output, newAttachmentOutput(a))
+	}
+	return output
+}
+
+func (as *AttachmentService) UploadTenderAttachment(ctx context.Context, input *UploadAttachmentInput) (*AttachmentOutput, error) {
+	const op = "service - AttachmentService - UploadTenderAttachment"
+
+	if _, err := as.tenderRepo.GetTenderByID(ctx, input.ParentID); err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUploadAttachment
+	}
+
+	return as.upload(ctx, op, input, &entity.Attachment{TenderID: &input.ParentID})
+}
+
+// UploadBidAttachment attaches a file to a bid. Only the author may do so, and
+// only while the tender still accepts submissions.
+func (as *AttachmentService) UploadBidAttachment(ctx context.Context, input *UploadAttachmentInput) (*AttachmentOutput, error) {
+	const op = "service - AttachmentService - UploadBidAttachment"
+
+	bid, err := as.getBid(ctx, input.ParentID)
+	if err != nil {
+		if !errors.Is(err, ErrBidNotFound) {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotUploadAttachment
+		}
+		return nil, err
+	}
+	if bid.AuthorID != input.UploaderID {
+		return nil, ErrNotBidAuthor
+	}
+
+	tender, err := as.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUploadAttachment
+	}
+	if tender.SubmissionClosed(time.Now().UTC()) {
+		return nil, ErrSubmissionClosed
+	}
+
+	return as.upload(ctx, op, input, &entity.Attachment{BidID: &bid.ID})
+}
+
+// upload checks the content against the configured limits, stores it in the
+// blob store and records its metadata. The blob is removed again when the
+// metadata cannot be saved.
+func (as *AttachmentService) upload(ctx context.Context, op string, input *UploadAttachmentInput, attachment *entity.Attachment) (*AttachmentOutput, error) {
+	content, err := io.ReadAll(io.LimitReader(input.Content, as.limits.MaxSize+1))
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUploadAttachment
+	}
+	if int64(len(content)) > as.limits.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if len(content) == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+
+	// The declared type of the upload is not trusted, the content is sniffed instead.
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(content))
+	if err != nil || !slices.Contains(as.limits.AllowedTypes, contentType) {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	fileName := input.FileName
+	if runes := []rune(fileName); len(runes) > maxAttachmentFileName {
+		fileName = string(runes[:maxAttachmentFileName])
+	}
+
+	sum := sha256.Sum256(content)
+	attachment.ID = uuid.New()
+	attachment.FileName = fileName
+	attachment.ContentType = contentType
+	attachment.Size = int64(len(content))
+	attachment.Checksum = hex.EncodeToString(sum[:])
+	attachment.StorageKey = "attachments/" + attachment.ID.String()
+	attachment.UploadedBy = input.UploaderID
+
+	if err = as.blobs.Put(ctx, attachment.StorageKey, bytes.NewReader(content), attachment.Size, contentType); err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUploadAttachment
+	}
+
+	var created *entity.Attachment
+	err = as.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = as.attachmentRepo.CreateAttachment(ctx, attachment)
+		if err != nil {
+			return err
+		}
+		return as.audit.record(ctx, "attachment.upload", entity.AuditAttachment, created.ID, nil, newAttachmentOutput(created))
+	})
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		if err = as.blobs.Delete(context.WithoutCancel(ctx), attachment.StorageKey); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()), sl.Any("storage_key", attachment.StorageKey))
+		}
+		return nil, ErrCannotUploadAttachment
+	}
+
+	return newAttachmentOutput(created), nil
+}
+
+func (as *AttachmentService) GetTenderAttachments(ctx context.Context, tenderID uuid.UUID) ([]*AttachmentOutput, error) {
+	const op = "service - AttachmentService - GetTenderAttachments"
+
+	attachments, err := as.attachmentRepo.GetAttachmentsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAttachments
+	}
+
+	return newAttachmentOutputs(attachments), nil
+}
+
+func (as *AttachmentService) GetBidAttachments(ctx context.Context, bidID, requesterID uuid.UUID) ([]*AttachmentOutput, error) {
+	const op = "service - AttachmentService - GetBidAttachments"
+
+	if err := as.checkBidAccess(ctx, bidID, requesterID); err != nil {
+		if !errors.Is(err, ErrBidNotFound) && !errors.Is(err, ErrBidSealed) {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotGetAttachments
+		}
+		return nil, err
+	}
+
+	attachments, err := as.attachmentRepo.GetAttachmentsByBid(ctx, bidID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAttachments
+	}
+
+	return newAttachmentOutputs(attachments), nil
+}
+
+func (as *AttachmentService) DownloadTenderAttachment(ctx context.Context, tenderID, attachmentID uuid.UUID) (*AttachmentContentOutput, error) {
+	const op = "service - AttachmentService - DownloadTenderAttachment"
+
+	attachment, err := as.attachmentRepo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAttachment
+	}
+	if attachment.TenderID == nil || *attachment.TenderID != tenderID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return as.open(ctx, op, attachment)
+}
+
+// DownloadBidAttachment follows the visibility of the bid itself: the content
+// of a sealed bid stays with its author until the bids are opened.
+func (as *AttachmentService) DownloadBidAttachment(ctx context.Context, bidID, attachmentID, requesterID uuid.UUID) (*AttachmentContentOutput, error) {
+	const op = "service - AttachmentService - DownloadBidAttachment"
+
+	if err := as.checkBidAccess(ctx, bidID, requesterID); err != nil {
+		if !errors.Is(err, ErrBidNotFound) && !errors.Is(err, ErrBidSealed) {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotGetAttachment
+		}
+		return nil, err
+	}
+
+	attachment, err := as.attachmentRepo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAttachment
+	}
+	if attachment.BidID == nil || *attachment.BidID != bidID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return as.open(ctx, op, attachment)
+}
+
+func (as *AttachmentService) open(ctx context.Context, op string, attachment *entity.Attachment) (*AttachmentContentOutput, error) {
+	content, err := as.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			sl.Error(op, sl.Any("error", "attachment content is missing"), sl.Any("storage_key", attachment.StorageKey))
+			return nil, ErrAttachmentNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAttachment
+	}
+
+	return &AttachmentContentOutput{
+		AttachmentOutput: newAttachmentOutput(attachment),
+		Content:          content,
+	}, nil
+}
+
+func (as *AttachmentService) getBid(ctx context.Context, bidID uuid.UUID) (*entity.Bid, error) {
+	bid, err := as.bidRepo.GetBidByID(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		return nil, err
+	}
+	return bid, nil
+}
+
+func (as *AttachmentService) checkBidAccess(ctx context.Context, bidID, requesterID uuid.UUID) error {
+	bid, err := as.getBid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+	if bid.IsSealed() && bid.AuthorID != requesterID {
+		return ErrBidSealed
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+const (
+	// auctionServiceType is the service type category reverse auctions are
+	// available for, subcategories included.
+	auctionServiceType = "Delivery"
+	// auctionSnipingWindow is how close to the end an offer has to land for
+	// the auction to be extended.
+	auctionSnipingWindow = time.Minute
+	// auctionExtension is how long the auction stays open after such an offer.
+	auctionExtension = time.Minute
+)
+
+// AuctionService runs reverse auctions. State changes are broadcast to the
+// subscribers of this process only.
+type AuctionService struct {
+	auctionRepo repo.Auction
+	bidRepo     repo.Bid
+	tenderRepo  repo.Tender
+	audit       *Auditor
+	tx          repo.Transactor
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan *AuctionStateOutput]struct{}
+}
+
+func NewAuctionService(auctionRepo repo.Auction, bidRepo repo.Bid, tenderRepo repo.Tender, audit *Auditor, tx repo.Transactor) *AuctionService {
+	return &AuctionService{
+		auctionRepo: auctionRepo,
+		bidRepo:     bidRepo,
+		tenderRepo:  tenderRepo,
+		audit:       audit,
+		tx:          tx,
+		subscribers: make(map[uuid.UUID]map[chan *AuctionStateOutput]struct{}),
+	}
+}
+
+// rankAuctionBids orders the active bids of an auction by price. A tie goes to
+// the bid that reached the price first.
+func rankAuctionBids(bids []*entity.Bid, offers []*entity.AuctionOffer) []*AuctionRankOutput {
+	reachedAt := make(map[uuid.UUID]time.Time, len(bids))
+	for _, offer := range offers {
+		reachedAt[offer.BidID] = offer.CreatedAt
+	}
+
+	type rankedBid struct {
+		bid       *entity.Bid
+		price     *big.Rat
+		reachedAt time.Time
+	}
+
+	ranked := make([]rankedBid, 0, len(bids))
+	for _, bid := range bids {
+		if !bid.IsActive() || bid.Price == nil {
+			continue
+		}
+		price, ok := new(big.Rat).SetString(*bid.Price)
+		if !ok {
+			continue
+		}
+
+		at, ok := reachedAt[bid.ID]
+		if !ok {
+			at = bid.CreatedAt
+		}
+		ranked = append(ranked, rankedBid{bid: bid, price: price, reachedAt: at})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if c := ranked[i].price.Cmp(ranked[j].price); c != 0 {
+			return c < 0
+		}
+		return ranked[i].reachedAt.Before(ranked[j].reachedAt)
+	})
+
+	output := make([]*AuctionRankOutput, 0, len(ranked))
+	for i, r := range ranked {
+		output = append(output, &AuctionRankOutput{
+			Rank:       i + 1,
+			BidID:      r.bid.ID,
+			AuthorType: r.bid.AuthorType,
+			AuthorID:   r.bid.AuthorID,
+			Price:      *r.bid.Price,
+		})
+	}
+	return output
+}
+
+// extendAuction returns the new end of an auction receiving an offer at now,
+// and false when the offer is outside the sniping window.
+func extendAuction(endsAt, now time.Time) (time.Time, bool) {
+	if endsAt.Sub(now) >= auctionSnipingWindow {
+		return endsAt, false
+	}
+	return now.Add(auctionExtension), true
+}
+
+// auctionState builds the auction state from the repositories bound to ctx.
+func (as *AuctionService) auctionState(ctx context.Context, tender *entity.Tender) (*AuctionStateOutput, error) {
+	bids, err := as.bidRepo.GetAllBidsByTender(ctx, tender.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := as.auctionRepo.GetOffersByTender(ctx, tender.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	state := &AuctionStateOutput{
+		TenderID: tender.ID,
+		Currency: tender.Currency,
+		StartsAt: *tender.AuctionStartsAt,
+		EndsAt:   *tender.AuctionEndsAt,
+		Running:  tender.AuctionRunning(now),
+		Finished: tender.AuctionFinished(now),
+		Ranking:  rankAuctionBids(bids, offers),
+	}
+	if len(state.Ranking) > 0 {
+		state.BestPrice = &state.Ranking[0].Price
+	}
+
+	return state, nil
+}
+
+func (as *AuctionService) GetAuctionState(ctx context.Context, tenderID uuid.UUID) (*AuctionStateOutput, error) {
+	const op = "service - AuctionService - GetAuctionState"
+
+	tender, err := as.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAuction
+	}
+	if !tender.IsAuction() {
+		return nil, ErrNotAuction
+	}
+
+	state, err := as.auctionState(ctx, tender)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAuction
+	}
+
+	return state, nil
+}
+
+// PlaceOffer lowers the price of a bid while the auction is running. An offer
+// landing in the last minute pushes the end of the auction back so that
+// everyone gets a chance to respond.
+func (as *AuctionService) PlaceOffer(ctx context.Context, input *PlaceOfferInput) (*AuctionStateOutput, error) {
+	const op = "service - AuctionService - PlaceOffer"
+
+	offerPrice, ok := new(big.Rat).SetString(input.Price)
+	if !ok {
+		return nil, ErrOfferNotLower
+	}
+
+	var state *AuctionStateOutput
+	err := as.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locking the tender serializes the offers of one auction.
+		tender, err := as.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		if !tender.IsAuction() {
+			return ErrNotAuction
+		}
+
+		now := time.Now().UTC()
+		if !tender.AuctionRunning(now) {
+			return ErrAuctionNotRunning
+		}
+
+		bid, err := as.bidRepo.GetBidByID(ctx, input.BidID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			return err
+		}
+		if bid.TenderID != tender.ID || bid.AuthorID != input.AuthorID || !bid.IsActive() {
+			return ErrBidNotFound
+		}
+
+		if bid.Price != nil {
+			current, ok := new(big.Rat).SetString(*bid.Price)
+			if ok && offerPrice.Cmp(current) >= 0 {
+				return ErrOfferNotLower
+			}
+		}
+
+		updated, err := as.bidRepo.UpdateBid(ctx, bid.ID, map[string]interface{}{"price": input.Price})
+		if err != nil {
+			return err
+		}
+		if err = as.audit.recordSubmission(ctx, updated); err != nil {
+			return err
+		}
+		if _, err = as.auctionRepo.CreateOffer(ctx, &entity.AuctionOffer{TenderID: tender.ID, BidID: bid.ID, Price: input.Price}); err != nil {
+			return err
+		}
+		err = as.audit.record(ctx, "auction.offer", entity.AuditBid, bid.ID, map[string]any{"Price": bid.Price}, map[string]any{"Price": input.Price})
+		if err != nil {
+			return err
+		}
+
+		if extended, ok := extendAuction(*tender.AuctionEndsAt, now); ok {
+			tender, err = as.tenderRepo.UpdateTender(ctx, tender.ID, map[string]interface{}{"auction_ends_at": extended})
+			if err != nil {
+				return err
+			}
+			sl.Info(op, sl.Any("tender_id", tender.ID), sl.Any("auction_ends_at", extended))
+		}
+
+		state, err = as.auctionState(ctx, tender)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrNotAuction), errors.Is(err, ErrAuctionNotRunning),
+			errors.Is(err, ErrBidNotFound), errors.Is(err, ErrOfferNotLower):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotPlaceOffer
+	}
+
+	as.publish(state)
+	return state, nil
+}
+
+// Subscribe returns a channel receiving every new state of the tender auction
+// and a function to stop the subscription. A slow subscriber only gets the
+// latest state.
+func (as *AuctionService) Subscribe(tenderID uuid.UUID) (<-chan *AuctionStateOutput, func()) {
+	ch := make(chan *AuctionStateOutput, 1)
+
+	as.mu.Lock()
+	if as.subscribers[tenderID] == nil {
+		as.subscribers[tenderID] = make(map[chan *AuctionStateOutput]struct{})
+	}
+	as.subscribers[tenderID][ch] = struct{}{}
+	as.mu.Unlock()
+
+	return ch, func() {
+		as.mu.Lock()
+		defer as.mu.Unlock()
+
+		delete(as.subscribers[tenderID], ch)
+		if len(as.subscribers[tenderID]) == 0 {
+			delete(as.subscribers, tenderID)
+		}
+	}
+}
+
+func (as *AuctionService) publish(state *AuctionStateOutput) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	for ch := range as.subscribers[state.TenderID] {
+		select {
+		case ch <- state:
+		default:
+			// Replace the state the subscriber has not picked up yet.
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- state
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestExtendAuction(t *testing.T) {
+	endsAt := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		endsAt   time.Time
+		now      time.Time
+		want     time.Time
+		extended bool
+	}{
+		{
+			name:   "well before the end",
+			endsAt: endsAt,
+			now:    endsAt.Add(-10 * time.Minute),
+			want:   endsAt,
+		},
+		{
+			name:   "exactly one minute left",
+			endsAt: endsAt,
+			now:    endsAt.Add(-time.Minute),
+			want:   endsAt,
+		},
+		{
+			name:     "inside the final minute",
+			endsAt:   endsAt,
+			now:      endsAt.Add(-59 * time.Second),
+			want:     endsAt.Add(time.Second),
+			extended: true,
+		},
+		{
+			name:     "last millisecond",
+			endsAt:   endsAt,
+			now:      endsAt.Add(-time.Millisecond),
+			want:     endsAt.Add(time.Minute - time.Millisecond),
+			extended: true,
+		},
+		{
+			name:     "offer inside an extension",
+			endsAt:   endsAt.Add(45 * time.Second),
+			now:      endsAt.Add(30 * time.Second),
+			want:     endsAt.Add(90 * time.Second),
+			extended: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, extended := extendAuction(tt.endsAt, tt.now)
+			if extended != tt.extended || !got.Equal(tt.want) {
+				t.Errorf("extendAuction() = %v, %v, want %v, %v", got, extended, tt.want, tt.extended)
+			}
+		})
+	}
+}
+
+func TestRankAuctionBids(t *testing.T) {
+	start := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	price := func(p string) *string { return &p }
+	bid := func(status string, p *string, createdAt time.Time) *entity.Bid {
+		return &entity.Bid{ID: uuid.New(), AuthorID: uuid.New(), Status: status, Price: p, CreatedAt: createdAt}
+	}
+
+	cheapest := bid(entity.BidStatusCreated, price("90.50"), start)
+	tieFirst := bid(entity.BidStatusCreated, price("100"), start.Add(2*time.Minute))
+	tieSecond := bid(entity.BidStatusCreated, price("100.00"), start)
+	expensive := bid(entity.BidStatusCreated, price("250"), start)
+	withdrawn := bid(entity.BidStatusWithdrawn, price("1"), start)
+	canceled := bid(entity.BidStatusCanceled, price("1"), start)
+	noPrice := bid(entity.BidStatusCreated, nil, start)
+	badPrice := bid(entity.BidStatusCreated, price("cheap"), start)
+
+	bids := []*entity.Bid{expensive, withdrawn, tieSecond, noPrice, tieFirst, canceled, badPrice, cheapest}
+	// tieSecond was created first but reached 100 after tieFirst did.
+	offers := []*entity.AuctionOffer{
+		{BidID: tieFirst.ID, Price: "100", CreatedAt: start.Add(3 * time.Minute)},
+		{BidID: tieSecond.ID, Price: "120", CreatedAt: start.Add(time.Minute)},
+		{BidID: tieSecond.ID, Price: "100.00", CreatedAt: start.Add(4 * time.Minute)},
+	}
+
+	got := rankAuctionBids(bids, offers)
+
+	want := []*entity.Bid{cheapest, tieFirst, tieSecond, expensive}
+	if len(got) != len(want) {
+		t.Fatalf("rankAuctionBids() returned %d bids, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Rank != i+1 || got[i].BidID != w.ID || got[i].Price != *w.Price {
+			t.Errorf("rank %d = %+v, want bid %s at %s", i+1, got[i], w.ID, *w.Price)
+		}
+	}
+}
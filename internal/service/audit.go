@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"github.com/google/uuid"
+)
+
+// SystemActor is recorded for the mutations made outside of a request.
+const SystemActor = "system"
+
+// RequestInfo describes the request a mutation comes from. Actor is the
+// username the request was made as.
+type RequestInfo struct {
+	ID    string
+	IP    string
+	Actor string
+}
+
+type requestInfoKey struct{}
+
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// WithActor replaces the actor of the request, for the requests naming their
+// user in the body rather than in the username parameter.
+func WithActor(ctx context.Context, actor string) context.Context {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	info.Actor = actor
+	return WithRequestInfo(ctx, info)
+}
+
+func requestInfo(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	if info.Actor == "" {
+		info.Actor = SystemActor
+	}
+	return info
+}
+
+// Auditor appends the events of the audit trail and the submitted bid
+// versions, each to its hash chain. Its methods are meant to run in the
+// transaction of the mutation they describe, so that an entry is stored
+// exactly when its change is committed. Appending locks the head of the
+// chain until then; a transaction appending to both chains links the bid
+// first, so that two of them never wait on each other.
+type Auditor struct {
+	auditRepo repo.Audit
+	bidRepo   repo.Bid
+	chainRepo repo.Chain
+}
+
+func NewAuditor(auditRepo repo.Audit, bidRepo repo.Bid, chainRepo repo.Chain) *Auditor {
+	return &Auditor{
+		auditRepo: auditRepo,
+		bidRepo:   bidRepo,
+		chainRepo: chainRepo,
+	}
+}
+
+// link appends content to the chain and returns its link.
+func (a *Auditor) link(ctx context.Context, chain string, content []byte) (entity.ChainLink, error) {
+	head, err := a.chainRepo.GetHeadForUpdate(ctx, chain)
+	if err != nil {
+		return entity.ChainLink{}, err
+	}
+	link := head.Next(content)
+	if err = a.chainRepo.UpdateHead(ctx, chain, link); err != nil {
+		return entity.ChainLink{}, err
+	}
+	return link, nil
+}
+
+// recordSubmission links the bid version as it was just submitted into the
+// bid chain.
+func (a *Auditor) recordSubmission(ctx context.Context, bid *entity.Bid) error {
+	submission := entity.NewBidSubmission(bid, time.Now())
+	content, err := submission.ChainContent()
+	if err != nil {
+		return err
+	}
+	if submission.ChainLink, err = a.link(ctx, entity.ChainBid, content); err != nil {
+		return err
+	}
+	_, err = a.bidRepo.CreateBidSubmission(ctx, submission)
+	return err
+}
+
+// record stores an event with the before and after states of the entity,
+// either of which may be nil.
+func (a *Auditor) record(ctx context.Context, action, entityType string, entityID uuid.UUID, before, after any) error {
+	beforeJSON, err := snapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	info := requestInfo(ctx)
+	event := &entity.AuditEvent{
+		ID:         uuid.New(),
+		Actor:      info.Actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		RequestID:  info.ID,
+		IP:         info.IP,
+		CreatedAt:  entity.ChainTime(time.Now()),
+	}
+	content, err := event.ChainContent()
+	if err != nil {
+		return err
+	}
+	link, err := a.link(ctx, entity.ChainAudit, content)
+	if err != nil {
+		return err
+	}
+	event.Seq, event.PrevHash, event.Hash = &link.Seq, link.PrevHash, link.Hash
+
+	_, err = a.auditRepo.CreateEvent(ctx, event)
+	return err
+}
+
+func snapshot(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(state)
+	if err != nil || string(b) == "null" {
+		return nil, err
+	}
+	return b, nil
+}
+
+type AuditService struct {
+	auditRepo repo.Audit
+}
+
+func NewAuditService(auditRepo repo.Audit) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (as *AuditService) GetAuditEvents(ctx context.Context, input *GetAuditEventsInput) ([]*AuditEventOutput, error) {
+	const op = "service - AuditService - GetAuditEvents"
+
+	events, err := as.auditRepo.GetEvents(ctx, &entity.AuditFilter{
+		Actor:      input.Actor,
+		Action:     input.Action,
+		EntityType: input.EntityType,
+		EntityID:   input.EntityID,
+		RequestID:  input.RequestID,
+		From:       input.From,
+		To:         input.To,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAuditEvents
+	}
+
+	output := make([]*AuditEventOutput, 0, len(events))
+	for _, e := range events {
+		output = append(output, &AuditEventOutput{
+			ID:         e.ID,
+			Actor:      e.Actor,
+			Action:     e.Action,
+			EntityType: e.EntityType,
+			EntityID:   e.EntityID,
+			Before:     e.Before,
+			After:      e.After,
+			RequestID:  e.RequestID,
+			IP:         e.IP,
+			CreatedAt:  e.CreatedAt,
+			Seq:        e.Seq,
+			PrevHash:   e.PrevHash,
+			Hash:       e.Hash,
+		})
+	}
+	return output, nil
+}
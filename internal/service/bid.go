@@ -2,37 +2,256 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	sl "log/slog"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
 
 	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/sealer"
+	"git.codenrock.com/tender/pkg/signer"
 	"github.com/google/uuid"
 )
 
+// sealedBidContent is the part of a bid that stays encrypted until the
+// submission deadline of a sealed tender passes.
+type sealedBidContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       string `json:"price"`
+}
+
 type BidService struct {
-	bidRepo repo.Bid
+	bidRepo          repo.Bid
+	tenderRepo       repo.Tender
+	evaluationRepo   repo.Evaluation
+	lotRepo          repo.Lot
+	auctionRepo      repo.Auction
+	invitationRepo   repo.Invitation
+	contractRepo     repo.Contract
+	organizationRepo repo.Organization
+	reputationRepo   repo.Reputation
+	debarmentRepo    repo.Debarment
+	employeeRepo     repo.Employee
+	conflicts        *ConflictChecker
+	opener           *BidOpener
+	audit            *Auditor
+	events           *EventPublisher
+	tx               repo.Transactor
+	sealer           *sealer.Sealer
 }
 
-func NewBidService(bidRepo repo.Bid) *BidService {
+func NewBidService(bidRepo repo.Bid, tenderRepo repo.Tender, evaluationRepo repo.Evaluation, lotRepo repo.Lot, auctionRepo repo.Auction, invitationRepo repo.Invitation, contractRepo repo.Contract, organizationRepo repo.Organization, reputationRepo repo.Reputation, debarmentRepo repo.Debarment, employeeRepo repo.Employee, conflicts *ConflictChecker, opener *BidOpener, audit *Auditor, events *EventPublisher, tx repo.Transactor, sealer *sealer.Sealer) *BidService {
 	return &BidService{
-		bidRepo: bidRepo,
+		bidRepo:          bidRepo,
+		tenderRepo:       tenderRepo,
+		evaluationRepo:   evaluationRepo,
+		lotRepo:          lotRepo,
+		auctionRepo:      auctionRepo,
+		invitationRepo:   invitationRepo,
+		contractRepo:     contractRepo,
+		organizationRepo: organizationRepo,
+		reputationRepo:   reputationRepo,
+		debarmentRepo:    debarmentRepo,
+		employeeRepo:     employeeRepo,
+		conflicts:        conflicts,
+		opener:           opener,
+		audit:            audit,
+		events:           events,
+		tx:               tx,
+		sealer:           sealer,
+	}
+}
+
+func newBidOutput(bid *entity.Bid) *BidOutput {
+	return &BidOutput{
+		ID:           bid.ID,
+		Name:         bid.Name,
+		Description:  bid.Description,
+		Status:       bid.Status,
+		TenderID:     bid.TenderID,
+		AuthorType:   bid.AuthorType,
+		AuthorID:     bid.AuthorID,
+		Version:      bid.Version,
+		Price:        bid.Price,
+		Currency:     bid.Currency,
+		DeliveryDays: bid.DeliveryDays,
+		ValidityDays: bid.ValidityDays,
+		TotalScore:   bid.TotalScore,
+		Sealed:       bid.IsSealed(),
+		NeedsAck:     bid.NeedsAck,
+		CreatedAt:    bid.CreatedAt,
 	}
 }
 
+func (bs *BidService) seal(content sealedBidContent) ([]byte, error) {
+	if bs.sealer == nil {
+		return nil, errors.New("sealer is not configured")
+	}
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return bs.sealer.Seal(plaintext)
+}
+
+func (bs *BidService) unseal(bid *entity.Bid) (sealedBidContent, error) {
+	return unsealBid(bs.sealer, bid)
+}
+
+// authorBidOutput decrypts a sealed bid for its own author.
+func (bs *BidService) authorBidOutput(bid *entity.Bid) *BidOutput {
+	output := newBidOutput(bid)
+	if !bid.IsSealed() {
+		return output
+	}
+
+	content, err := bs.unseal(bid)
+	if err != nil {
+		sl.Error("service - BidService - authorBidOutput", sl.Any("error", err.Error()))
+		return output
+	}
+	output.Name = content.Name
+	output.Description = content.Description
+	output.Price = optional(content.Price)
+	return output
+}
+
 func (bs *BidService) CreateBid(ctx context.Context, input *CreateBidInput) (*BidOutput, error) {
 	const op = "service - BidService - CreateBid"
 
+	tender, err := bs.tenderRepo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateBid
+	}
+
+	if tender.IsCanceled() || tender.SubmissionClosed(time.Now().UTC()) {
+		return nil, ErrSubmissionClosed
+	}
+
+	if err = bs.checkDebarment(ctx, tender, input.AuthorID); err != nil {
+		if errors.Is(err, ErrSupplierDebarred) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateBid
+	}
+
+	conflicts, err := bs.conflicts.screenBidCreation(ctx, input.AuthorID, tender)
+	if err != nil {
+		if errors.Is(err, ErrConflictOfInterest) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateBid
+	}
+
+	invited, err := isInvited(ctx, bs.invitationRepo, tender, input.AuthorID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateBid
+	}
+	if !invited {
+		return nil, ErrNotInvited
+	}
+
+	if tender.Currency != nil && *tender.Currency != input.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	lots, err := bs.lotRepo.GetLotsByTender(ctx, tender.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateBid
+	}
+	if len(lots) > 0 && len(input.LotIDs) == 0 {
+		return nil, ErrLotRequired
+	}
+	for _, lotID := range input.LotIDs {
+		if !slices.ContainsFunc(lots, func(l *entity.Lot) bool { return l.ID == lotID && l.Status == entity.LotStatusOpen }) {
+			return nil, ErrLotNotFound
+		}
+	}
+
+	var signature *entity.BidSignature
+	if input.Signature != nil {
+		signature, err = bs.checkSignature(ctx, input.AuthorID, input.Signature, &entity.BidDocument{
+			AuthorID:     input.AuthorID,
+			Currency:     input.Currency,
+			DeliveryDays: input.DeliveryDays,
+			Description:  input.Description,
+			Name:         input.Name,
+			Price:        input.Price,
+			TenderID:     input.TenderID,
+			ValidityDays: input.ValidityDays,
+			Version:      1,
+		})
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrKeyRevoked) || errors.Is(err, ErrInvalidSignature) {
+				return nil, err
+			}
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateBid
+		}
+	}
+
 	bid := &entity.Bid{
-		Name:        input.Name,
-		Description: input.Description,
-		TenderID:    input.TenderID,
-		AuthorType:  input.AuthorType,
-		AuthorID:    input.AuthorID,
+		Name:         input.Name,
+		Description:  input.Description,
+		TenderID:     input.TenderID,
+		AuthorType:   input.AuthorType,
+		AuthorID:     input.AuthorID,
+		Price:        optional(input.Price),
+		Currency:     optional(input.Currency),
+		DeliveryDays: &input.DeliveryDays,
+		ValidityDays: &input.ValidityDays,
 	}
 
-	createdBid, err := bs.bidRepo.CreateBid(ctx, bid)
+	if tender.IsSealed() {
+		payload, err := bs.seal(sealedBidContent{Name: input.Name, Description: input.Description, Price: input.Price})
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateBid
+		}
+		bid.Name, bid.Description, bid.Price, bid.SealedPayload = "", "", nil, payload
+	}
+
+	var createdBid *entity.Bid
+	err = bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		createdBid, err = bs.bidRepo.CreateBid(ctx, bid)
+		if err != nil {
+			return err
+		}
+		if err = bs.recordReputation(ctx, createdBid, entity.Reputation{BidsSubmitted: 1}); err != nil {
+			return err
+		}
+		if err = bs.conflicts.record(ctx, conflicts, createdBid); err != nil {
+			return err
+		}
+		if err = bs.lotRepo.SetBidLots(ctx, createdBid.ID, input.LotIDs); err != nil {
+			return err
+		}
+		if err = bs.storeSignature(ctx, signature, createdBid); err != nil {
+			return err
+		}
+		if err = bs.audit.recordSubmission(ctx, createdBid); err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidSubmitted, entity.AggregateBid, createdBid.ID, newBidOutput(createdBid)); err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.create", entity.AuditBid, createdBid.ID, nil, newBidOutput(createdBid))
+	})
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrAlreadyExists) {
 			return nil, ErrBidAlreadyExists
@@ -41,16 +260,36 @@ func (bs *BidService) CreateBid(ctx context.Context, input *CreateBidInput) (*Bi
 		return nil, ErrCannotCreateBid
 	}
 
-	return &BidOutput{
-		ID:         createdBid.ID,
-		Name:       createdBid.Name,
-		Status:     createdBid.Status,
-		AuthorType: createdBid.AuthorType,
-		AuthorID:   createdBid.AuthorID,
-		Version:    createdBid.Version,
-		CreatedAt:  createdBid.CreatedAt,
-	}, nil
+	output := bs.authorBidOutput(createdBid)
+	output.LotIDs = input.LotIDs
+	return output, nil
+}
+
+// checkDebarment rejects bids of debarred employees and organizations on
+// the tender. Every blocked attempt is logged against the debarment.
+func (bs *BidService) checkDebarment(ctx context.Context, tender *entity.Tender, authorID uuid.UUID) error {
+	const op = "service - BidService - checkDebarment"
+
+	debarment, err := bs.debarmentRepo.FindActiveDebarment(ctx, authorID, tender.OrganizationID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	sl.Warn(op, sl.Any("debarment_id", debarment.ID), sl.Any("tender_id", tender.ID), sl.Any("employee_id", authorID))
+	_, err = bs.debarmentRepo.CreateDebarmentBlock(ctx, &entity.DebarmentBlock{
+		DebarmentID: debarment.ID,
+		TenderID:    tender.ID,
+		EmployeeID:  authorID,
+	})
+	if err != nil {
+		return err
+	}
+	return ErrSupplierDebarred
 }
+
 func (bs *BidService) GetBidByTenderAndAuthor(ctx context.Context, tenderID uuid.UUID, authorID uuid.UUID) (*BidOutput, error) {
 	bid, err := bs.bidRepo.FindByTenderAndAuthor(ctx, tenderID, authorID)
 	if err != nil {
@@ -59,15 +298,7 @@ func (bs *BidService) GetBidByTenderAndAuthor(ctx context.Context, tenderID uuid
 		}
 		return nil, err
 	}
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	return newBidOutput(bid), nil
 }
 
 func (bs *BidService) GetUserBids(ctx context.Context, input *GetBidsByUsernameInput) ([]*BidOutput, error) {
@@ -84,23 +315,25 @@ func (bs *BidService) GetUserBids(ctx context.Context, input *GetBidsByUsernameI
 
 	var output []*BidOutput
 	for _, bid := range bids {
-		output = append(output, &BidOutput{
-			ID:         bid.ID,
-			Name:       bid.Name,
-			Status:     bid.Status,
-			AuthorType: bid.AuthorType,
-			AuthorID:   bid.AuthorID,
-			Version:    bid.Version,
-			CreatedAt:  bid.CreatedAt,
-		})
+		output = append(output, bs.authorBidOutput(bid))
 	}
 
 	return output, nil
 }
 
+// GetBidsByTender lists the tender bids. For a sealed tender only metadata is
+// returned until the tender is opened after its submission deadline.
 func (bs *BidService) GetBidsByTender(ctx context.Context, input *GetBidsByTenderInput) ([]*BidOutput, error) {
 	const op = "service - BidService - GetBidsByTender"
 
+	if _, err := bs.tenderRepo.GetTenderByID(ctx, input.TenderID); err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetBids
+	}
+
 	bids, err := bs.bidRepo.GetBidsByTender(ctx, input.Limit, input.Offset, input.TenderID)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
@@ -110,22 +343,114 @@ func (bs *BidService) GetBidsByTender(ctx context.Context, input *GetBidsByTende
 		return nil, ErrCannotGetBids
 	}
 
+	authorIDs := make([]uuid.UUID, 0, len(bids))
+	for _, bid := range bids {
+		authorIDs = append(authorIDs, bid.AuthorID)
+	}
+	reputations, err := bs.reputationRepo.GetReputations(ctx, entity.ReputationEmployee, authorIDs)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetBids
+	}
+	scores := make(map[uuid.UUID]*float64, len(reputations))
+	for _, reputation := range reputations {
+		scores[reputation.SubjectID] = reputation.Score
+	}
+
 	var output []*BidOutput
 	for _, bid := range bids {
-		output = append(output, &BidOutput{
-			ID:         bid.ID,
-			Name:       bid.Name,
-			Status:     bid.Status,
-			AuthorType: bid.AuthorType,
-			AuthorID:   bid.AuthorID,
-			Version:    bid.Version,
-			CreatedAt:  bid.CreatedAt,
-		})
+		bidOutput := newBidOutput(bid)
+		bidOutput.AuthorReputation = scores[bid.AuthorID]
+		output = append(output, bidOutput)
 	}
 
 	return output, nil
 }
 
+// GetBidComparison returns the active bids of a tender side by side, cheapest
+// first, with their deviation from the tender budget.
+func (bs *BidService) GetBidComparison(ctx context.Context, tenderID uuid.UUID) (*BidComparisonOutput, error) {
+	const op = "service - BidService - GetBidComparison"
+
+	tender, err := bs.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCompareBids
+	}
+
+	if tender.IsSealed() {
+		return nil, ErrBidSealed
+	}
+
+	bids, err := bs.bidRepo.GetAllBidsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCompareBids
+	}
+
+	var budget *big.Rat
+	if tender.Budget != nil {
+		budget, _ = new(big.Rat).SetString(*tender.Budget)
+	}
+
+	items := make([]*BidComparisonItem, 0, len(bids))
+	prices := make(map[uuid.UUID]*big.Rat, len(bids))
+	for _, bid := range bids {
+		if !bid.IsActive() {
+			continue
+		}
+
+		item := &BidComparisonItem{
+			BidID:        bid.ID,
+			Name:         bid.Name,
+			Status:       bid.Status,
+			AuthorType:   bid.AuthorType,
+			AuthorID:     bid.AuthorID,
+			Price:        bid.Price,
+			Currency:     bid.Currency,
+			DeliveryDays: bid.DeliveryDays,
+			ValidityDays: bid.ValidityDays,
+		}
+
+		if bid.Price != nil {
+			if price, ok := new(big.Rat).SetString(*bid.Price); ok {
+				prices[bid.ID] = price
+				if budget != nil && budget.Sign() > 0 {
+					deviation := new(big.Rat).Sub(price, budget)
+					percent := new(big.Rat).Mul(new(big.Rat).Quo(deviation, budget), big.NewRat(100, 1))
+					item.Deviation = optional(deviation.FloatString(2))
+					item.DeviationPercent = optional(percent.FloatString(2))
+				}
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	// Bids without a price (created before pricing existed) go last.
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, pj := prices[items[i].BidID], prices[items[j].BidID]
+		switch {
+		case pi == nil:
+			return false
+		case pj == nil:
+			return true
+		default:
+			return pi.Cmp(pj) < 0
+		}
+	})
+
+	return &BidComparisonOutput{
+		TenderID: tender.ID,
+		Budget:   tender.Budget,
+		Currency: tender.Currency,
+		Bids:     items,
+	}, nil
+}
+
 func (bs *BidService) GetBidByID(ctx context.Context, bidID uuid.UUID) (*BidOutput, error) {
 	const op = "service - BidService - GetBidByID"
 
@@ -138,22 +463,13 @@ func (bs *BidService) GetBidByID(ctx context.Context, bidID uuid.UUID) (*BidOutp
 		return nil, ErrCannotGetBid
 	}
 
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		TenderID:   bid.TenderID,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	return newBidOutput(bid), nil
 }
 
 func (bs *BidService) UpdateBidStatus(ctx context.Context, input *UpdateBidStatusInput) (*BidOutput, error) {
 	const op = "service - BidService - UpdateBidStatus"
 
-	bid, err := bs.bidRepo.UpdateBidStatus(ctx, input.BidID, input.Status)
+	current, err := bs.bidRepo.GetBidByID(ctx, input.BidID)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return nil, ErrBidNotFound
@@ -162,50 +478,575 @@ func (bs *BidService) UpdateBidStatus(ctx context.Context, input *UpdateBidStatu
 		return nil, ErrCannotUpdateBid
 	}
 
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	var bid *entity.Bid
+	err = bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locking the tender first orders the change after a cancellation
+		// of the tender, which cancels its bids.
+		tender, err := bs.tenderRepo.GetTenderForUpdate(ctx, current.TenderID)
+		if err != nil {
+			return err
+		}
+		if tender.IsCanceled() {
+			return ErrTenderCanceled
+		}
+
+		previous, err := bs.bidRepo.GetBidForUpdate(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+		// A withdrawn bid comes back through ResubmitBid only, and a canceled
+		// one not at all.
+		switch previous.Status {
+		case entity.BidStatusWithdrawn:
+			return ErrBidWithdrawn
+		case entity.BidStatusCanceled:
+			return ErrBidCanceled
+		}
+
+		bid, err = bs.bidRepo.UpdateBidStatus(ctx, input.BidID, input.Status)
+		if err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidStatusChanged, entity.AggregateBid, bid.ID, newBidOutput(bid)); err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.update_status", entity.AuditBid, bid.ID, newBidOutput(previous), newBidOutput(bid))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrBidNotFound
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrBidAlreadyExists
+		case errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrBidWithdrawn), errors.Is(err, ErrBidCanceled):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+
+	return newBidOutput(bid), nil
 }
 func (bs *BidService) UpdateBid(ctx context.Context, input *UpdateBidInput) (*BidOutput, error) {
 	const op = "service - BidService - UpdateBid"
 
+	current, err := bs.bidRepo.GetBidByID(ctx, input.BidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+	if current.Status == entity.BidStatusWithdrawn {
+		return nil, ErrBidWithdrawn
+	}
+
+	tender, err := bs.tenderRepo.GetTenderByID(ctx, current.TenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+	if tender.IsCanceled() {
+		return nil, ErrSubmissionClosed
+	}
+
+	updates, err := bs.revise(current, tender, input)
+	if err != nil {
+		if errors.Is(err, ErrSubmissionClosed) || errors.Is(err, ErrCurrencyMismatch) || errors.Is(err, ErrAuctionPriceLocked) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+
+	var signature *entity.BidSignature
+	if input.Signature != nil {
+		document, err := bs.revisedDocument(current, input)
+		if err == nil {
+			signature, err = bs.checkSignature(ctx, input.EditorID, input.Signature, document)
+		}
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrKeyRevoked) || errors.Is(err, ErrInvalidSignature) {
+				return nil, err
+			}
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotUpdateBid
+		}
+	}
+
+	var bid *entity.Bid
+	err = bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		bid, err = bs.bidRepo.UpdateBid(ctx, input.BidID, updates)
+		if err != nil {
+			return err
+		}
+		if err = bs.storeSignature(ctx, signature, bid); err != nil {
+			return err
+		}
+		if err = bs.audit.recordSubmission(ctx, bid); err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidRevised, entity.AggregateBid, bid.ID, newBidOutput(bid)); err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.update", entity.AuditBid, bid.ID, newBidOutput(current), newBidOutput(bid))
+	})
+	if err != nil {
+		if errors.Is(err, ErrSignedVersionConflict) {
+			return nil, err
+		}
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+
+	return bs.authorBidOutput(bid), nil
+}
+
+// revise turns a revision of the bid into column updates. The contents of a
+// sealed bid are re-sealed, which is only possible until the deadline.
+func (bs *BidService) revise(current *entity.Bid, tender *entity.Tender, input *UpdateBidInput) (map[string]interface{}, error) {
+	if input.Currency != "" && tender.Currency != nil && *tender.Currency != input.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if input.Price != "" && tender.IsAuction() && !time.Now().UTC().Before(*tender.AuctionStartsAt) {
+		return nil, ErrAuctionPriceLocked
+	}
+
 	updates := make(map[string]interface{})
+	if input.Currency != "" {
+		updates["currency"] = input.Currency
+	}
+	if input.DeliveryDays > 0 {
+		updates["delivery_days"] = input.DeliveryDays
+	}
+	if input.ValidityDays > 0 {
+		updates["validity_days"] = input.ValidityDays
+	}
+	// A revision by the author takes the latest tender amendment into account.
+	if current.NeedsAck && input.EditorID == current.AuthorID {
+		updates["needs_ack"] = false
+	}
+
+	if current.IsSealed() {
+		if tender.SubmissionClosed(time.Now().UTC()) {
+			return nil, ErrSubmissionClosed
+		}
+
+		content, err := bs.unseal(current)
+		if err != nil {
+			return nil, err
+		}
+		if input.Name != "" {
+			content.Name = input.Name
+		}
+		if input.Description != "" {
+			content.Description = input.Description
+		}
+		if input.Price != "" {
+			content.Price = input.Price
+		}
+
+		payload, err := bs.seal(content)
+		if err != nil {
+			return nil, err
+		}
+		updates["sealed_payload"] = payload
+	} else {
+		if input.Name != "" {
+			updates["name"] = input.Name
+		}
+		if input.Description != "" {
+			updates["Description"] = input.Description
+		}
+		if input.Price != "" {
+			updates["price"] = input.Price
+		}
+	}
+
+	return updates, nil
+}
+
+// revisedDocument returns the document of the bid version a revision
+// submits: the current contents with the revised fields replaced.
+func (bs *BidService) revisedDocument(current *entity.Bid, input *UpdateBidInput) (*entity.BidDocument, error) {
+	output := newBidOutput(current)
+	if current.IsSealed() {
+		content, err := bs.unseal(current)
+		if err != nil {
+			return nil, err
+		}
+		output.Name, output.Description, output.Price = content.Name, content.Description, optional(content.Price)
+	}
+
+	document := &entity.BidDocument{
+		AuthorID:    current.AuthorID,
+		Description: output.Description,
+		Name:        output.Name,
+		TenderID:    current.TenderID,
+		Version:     current.Version + 1,
+	}
+	if output.Currency != nil {
+		document.Currency = *output.Currency
+	}
+	if output.DeliveryDays != nil {
+		document.DeliveryDays = *output.DeliveryDays
+	}
+	if output.Price != nil {
+		document.Price = *output.Price
+	}
+	if output.ValidityDays != nil {
+		document.ValidityDays = *output.ValidityDays
+	}
+
 	if input.Name != "" {
-		updates["name"] = input.Name
+		document.Name = input.Name
 	}
 	if input.Description != "" {
-		updates["Description"] = input.Description
+		document.Description = input.Description
+	}
+	if input.Price != "" {
+		document.Price = input.Price
+	}
+	if input.Currency != "" {
+		document.Currency = input.Currency
+	}
+	if input.DeliveryDays > 0 {
+		document.DeliveryDays = input.DeliveryDays
 	}
+	if input.ValidityDays > 0 {
+		document.ValidityDays = input.ValidityDays
+	}
+	return document, nil
+}
+
+// checkSignature verifies the signature of the document with a key of the
+// signer and returns it ready to be stored with the bid version.
+func (bs *BidService) checkSignature(ctx context.Context, signerID uuid.UUID, input *BidSignatureInput, document *entity.BidDocument) (*entity.BidSignature, error) {
+	key, err := bs.employeeRepo.GetKeyByID(ctx, input.KeyID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	if key.EmployeeID != signerID {
+		return nil, ErrKeyNotFound
+	}
+	if key.IsRevoked() {
+		return nil, ErrKeyRevoked
+	}
+
+	publicKey, err := signer.ParsePublicKey(key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	canonical, err := document.Canonical()
+	if err != nil {
+		return nil, err
+	}
+	if !publicKey.Verify(canonical, input.Signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &entity.BidSignature{
+		Version:   document.Version,
+		KeyID:     key.ID,
+		Document:  canonical,
+		Signature: input.Signature,
+	}, nil
+}
+
+// storeSignature stores the signature, if any, with the bid version it
+// signs. The document of a sealed bid is sealed along with it.
+func (bs *BidService) storeSignature(ctx context.Context, signature *entity.BidSignature, bid *entity.Bid) error {
+	if signature == nil {
+		return nil
+	}
+	// Another revision was submitted in between, so the signed document
+	// is not the one stored.
+	if signature.Version != bid.Version {
+		return ErrSignedVersionConflict
+	}
+
+	signature.BidID = bid.ID
+	if bid.IsSealed() {
+		if bs.sealer == nil {
+			return errors.New("sealer is not configured")
+		}
+		document, err := bs.sealer.Seal(signature.Document)
+		if err != nil {
+			return err
+		}
+		signature.Document, signature.Sealed = document, true
+	}
+
+	_, err := bs.bidRepo.CreateBidSignature(ctx, signature)
+	return err
+}
+
+// biddingOpen reports whether bidders may still change their offers.
+func biddingOpen(tender *entity.Tender) bool {
+	return !tender.IsCanceled() && tender.Status != entity.TenderStatusClosed && !tender.SubmissionClosed(time.Now().UTC())
+}
+
+// WithdrawBid takes the bid out of the competition before the deadline. The
+// withdrawn offer is kept in the bid history.
+func (bs *BidService) WithdrawBid(ctx context.Context, input *WithdrawBidInput) (*BidOutput, error) {
+	const op = "service - BidService - WithdrawBid"
+
+	var withdrawn *entity.Bid
+	err := bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := bs.bidRepo.GetBidForUpdate(ctx, input.BidID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			return err
+		}
+		if current.AuthorID != input.AuthorID {
+			return ErrNotBidAuthor
+		}
+		switch current.Status {
+		case entity.BidStatusWithdrawn:
+			return ErrBidWithdrawn
+		case entity.BidStatusCanceled:
+			return ErrBidCanceled
+		}
+
+		tender, err := bs.tenderRepo.GetTenderByID(ctx, current.TenderID)
+		if err != nil {
+			return err
+		}
+		if !biddingOpen(tender) {
+			return ErrSubmissionClosed
+		}
+
+		if _, err = bs.bidRepo.CreateBidVersion(ctx, current); err != nil {
+			return err
+		}
+		withdrawn, err = bs.bidRepo.UpdateBidStatus(ctx, current.ID, entity.BidStatusWithdrawn)
+		if err != nil {
+			return err
+		}
+		if err = bs.recordReputation(ctx, withdrawn, entity.Reputation{BidsWithdrawn: 1}); err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidWithdrawn, entity.AggregateBid, withdrawn.ID, newBidOutput(withdrawn)); err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.withdraw", entity.AuditBid, withdrawn.ID, newBidOutput(current), newBidOutput(withdrawn))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBidNotFound), errors.Is(err, ErrNotBidAuthor), errors.Is(err, ErrBidWithdrawn),
+			errors.Is(err, ErrBidCanceled), errors.Is(err, ErrSubmissionClosed):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotWithdrawBid
+	}
+
+	return bs.authorBidOutput(withdrawn), nil
+}
+
+// ResubmitBid puts a withdrawn bid back into the competition, optionally
+// revised. The author may have submitted another bid in the meantime: the
+// unique index on active bids then rejects the resubmission.
+func (bs *BidService) ResubmitBid(ctx context.Context, input *ResubmitBidInput) (*BidOutput, error) {
+	const op = "service - BidService - ResubmitBid"
+
+	var resubmitted *entity.Bid
+	err := bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := bs.bidRepo.GetBidForUpdate(ctx, input.BidID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			return err
+		}
+		if current.AuthorID != input.AuthorID {
+			return ErrNotBidAuthor
+		}
+		if current.Status != entity.BidStatusWithdrawn {
+			return ErrBidNotWithdrawn
+		}
+
+		tender, err := bs.tenderRepo.GetTenderByID(ctx, current.TenderID)
+		if err != nil {
+			return err
+		}
+		if !biddingOpen(tender) {
+			return ErrSubmissionClosed
+		}
+
+		updates, err := bs.revise(current, tender, &UpdateBidInput{
+			BidID:        current.ID,
+			Name:         input.Name,
+			Description:  input.Description,
+			Price:        input.Price,
+			Currency:     input.Currency,
+			DeliveryDays: input.DeliveryDays,
+			ValidityDays: input.ValidityDays,
+			EditorID:     input.AuthorID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The bid gets back the status it had when it was withdrawn.
+		versions, err := bs.bidRepo.GetBidVersions(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+		updates["status"] = entity.BidStatusCreated
+		if len(versions) > 0 {
+			updates["status"] = versions[len(versions)-1].Status
+		}
 
-	bid, err := bs.bidRepo.UpdateBid(ctx, input.BidID, updates)
+		resubmitted, err = bs.bidRepo.UpdateBid(ctx, current.ID, updates)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrAlreadyExists) {
+				return ErrBidAlreadyExists
+			}
+			return err
+		}
+		if err = bs.audit.recordSubmission(ctx, resubmitted); err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidSubmitted, entity.AggregateBid, resubmitted.ID, newBidOutput(resubmitted)); err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.resubmit", entity.AuditBid, resubmitted.ID, newBidOutput(current), newBidOutput(resubmitted))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBidNotFound), errors.Is(err, ErrNotBidAuthor), errors.Is(err, ErrBidNotWithdrawn),
+			errors.Is(err, ErrSubmissionClosed), errors.Is(err, ErrCurrencyMismatch), errors.Is(err, ErrAuctionPriceLocked),
+			errors.Is(err, ErrBidAlreadyExists):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotResubmitBid
+	}
+
+	return bs.authorBidOutput(resubmitted), nil
+}
+
+// GetBidVersions returns the bid history. Sealed snapshots are decrypted for
+// the author, and for everybody else once the tender has been opened.
+func (bs *BidService) GetBidVersions(ctx context.Context, input *GetBidVersionsInput) ([]*BidVersionOutput, error) {
+	const op = "service - BidService - GetBidVersions"
+
+	bid, err := bs.bidRepo.GetBidByID(ctx, input.BidID)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return nil, ErrBidNotFound
 		}
 		sl.Error(op, sl.Any("error", err.Error()))
-		return nil, ErrCannotUpdateBid
+		return nil, ErrCannotGetBidVersions
+	}
+	tender, err := bs.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetBidVersions
 	}
+	reveal := bid.AuthorID == input.RequesterID || !tender.IsSealed()
 
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	versions, err := bs.bidRepo.GetBidVersions(ctx, bid.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetBidVersions
+	}
+
+	output := make([]*BidVersionOutput, 0, len(versions))
+	for _, v := range versions {
+		item := &BidVersionOutput{
+			Version:      v.Version,
+			Status:       v.Status,
+			Name:         v.Name,
+			Description:  v.Description,
+			Price:        v.Price,
+			Currency:     v.Currency,
+			DeliveryDays: v.DeliveryDays,
+			ValidityDays: v.ValidityDays,
+			Sealed:       v.IsSealed(),
+			CreatedAt:    v.CreatedAt,
+		}
+		if v.IsSealed() && reveal {
+			content, err := bs.unseal(&entity.Bid{SealedPayload: v.SealedPayload})
+			if err != nil {
+				sl.Error(op, sl.Any("error", err.Error()))
+				return nil, ErrCannotGetBidVersions
+			}
+			item.Name, item.Description, item.Price, item.Sealed = content.Name, content.Description, optional(content.Price), false
+		}
+		output = append(output, item)
+	}
+	return output, nil
+}
+
+func (bs *BidService) AcknowledgeBid(ctx context.Context, input *AcknowledgeBidInput) (*BidOutput, error) {
+	const op = "service - BidService - AcknowledgeBid"
+
+	current, err := bs.bidRepo.GetBidByID(ctx, input.BidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAcknowledgeBid
+	}
+	if current.AuthorID != input.AuthorID {
+		return nil, ErrNotBidAuthor
+	}
+	if !current.NeedsAck {
+		return bs.authorBidOutput(current), nil
+	}
+
+	var bid *entity.Bid
+	err = bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		bid, err = bs.bidRepo.AcknowledgeBid(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+		return bs.audit.record(ctx, "bid.acknowledge", entity.AuditBid, bid.ID, newBidOutput(current), newBidOutput(bid))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAcknowledgeBid
+	}
+
+	return bs.authorBidOutput(bid), nil
+}
+
+// approvable makes sure the bid still competes and its author accepted the
+// latest terms of the tender.
+func approvable(bid *entity.Bid) error {
+	switch bid.Status {
+	case entity.BidStatusCanceled:
+		return ErrBidCanceled
+	case entity.BidStatusWithdrawn:
+		return ErrBidWithdrawn
+	}
+	if bid.NeedsAck {
+		return ErrBidNeedsAcknowledgement
+	}
+	return nil
 }
+
 func (bs *BidService) UpdateBidDecision(ctx context.Context, input *UpdateBidDecisionInput) (*BidOutput, error) {
 	const op = "service - BidService - UpdateBidDecision"
 
-	bid, err := bs.bidRepo.UpdateBidDecision(ctx, input.BidID, input.Decision)
+	current, err := bs.bidRepo.GetBidByID(ctx, input.BidID)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return nil, ErrBidNotFound
@@ -213,37 +1054,318 @@ func (bs *BidService) UpdateBidDecision(ctx context.Context, input *UpdateBidDec
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, ErrCannotUpdateBid
 	}
+	if current, err = bs.opener.openBid(ctx, current); err != nil {
+		if errors.Is(err, ErrBidSealed) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotOpenBids
+	}
 
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	conflicts, err := bs.conflicts.screenReview(ctx, entity.ConflictStageDecision, input.DeciderID, current)
+	if err != nil {
+		if errors.Is(err, ErrConflictOfInterest) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+
+	if input.Decision == "Approved" {
+		if err = approvable(current); err != nil {
+			return nil, err
+		}
+
+		lots, err := bs.lotRepo.GetLotsByTender(ctx, current.TenderID)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotUpdateBid
+		}
+		if len(lots) > 0 {
+			return nil, ErrLotDecisionRequired
+		}
+
+		missing, err := bs.evaluationRepo.CountMissingScores(ctx, current.TenderID)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotUpdateBid
+		}
+		if missing > 0 {
+			return nil, ErrScoringIncomplete
+		}
+
+		if err = bs.checkAuctionWinner(ctx, current); err != nil {
+			if errors.Is(err, ErrAuctionNotFinished) || errors.Is(err, ErrNotAuctionWinner) {
+				return nil, err
+			}
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotUpdateBid
+		}
+	}
+
+	var bid *entity.Bid
+	err = bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// The tender lock, taken before the bid one like everywhere else,
+		// orders the decision after a cancellation and keeps two bids from
+		// being awarded at once. Approving the awarded bid again changes
+		// nothing.
+		tender, err := bs.tenderRepo.GetTenderForUpdate(ctx, current.TenderID)
+		if err != nil {
+			return err
+		}
+		if tender.IsCanceled() {
+			return ErrTenderCanceled
+		}
+		if input.Decision == "Approved" && current.Decision != "Approved" {
+			if err = checkAward(ctx, bs.contractRepo, tender, false); err != nil {
+				return err
+			}
+		}
+
+		previous, err := bs.bidRepo.GetBidForUpdate(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+		// The bid may have been withdrawn or canceled since it was checked.
+		if input.Decision == "Approved" {
+			if err = approvable(previous); err != nil {
+				return err
+			}
+		}
+		bid, err = bs.bidRepo.UpdateBidDecision(ctx, input.BidID, input.Decision)
+		if err != nil {
+			return err
+		}
+		if err = bs.recordReputation(ctx, bid, decisionDelta(previous.Decision, input.Decision)); err != nil {
+			return err
+		}
+		if err = bs.conflicts.record(ctx, conflicts, bid); err != nil {
+			return err
+		}
+		if err = bs.audit.record(ctx, "bid.decide", entity.AuditBid, bid.ID, newBidOutput(previous), newBidOutput(bid)); err != nil {
+			return err
+		}
+		if err = bs.events.publish(ctx, entity.EventBidDecided, entity.AggregateBid, bid.ID, newBidOutput(bid)); err != nil {
+			return err
+		}
+		if input.Decision != "Approved" {
+			return nil
+		}
+		return awardContract(ctx, bs.contractRepo, bs.organizationRepo, bs.tenderRepo, bs.audit, bs.events, bid, nil)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrBidNotFound
+		case errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrTenderNotPublished), errors.Is(err, ErrTenderAlreadyAwarded),
+			errors.Is(err, ErrBidCanceled), errors.Is(err, ErrBidWithdrawn), errors.Is(err, ErrBidNeedsAcknowledgement):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateBid
+	}
+
+	return newBidOutput(bid), nil
+}
+
+// recordReputation records an event of the bid in the reputation of its
+// author and of the organization the bid was made for.
+func (bs *BidService) recordReputation(ctx context.Context, bid *entity.Bid, delta entity.Reputation) error {
+	organizationID, err := bidOrganizationID(ctx, bs.organizationRepo, bid)
+	if err != nil {
+		return err
+	}
+	return recordReputation(ctx, bs.reputationRepo, delta, bid.AuthorID, organizationID)
+}
+
+// decisionDelta counts a decision in the approval rate, replacing the
+// previous decision of the bid if there was one.
+func decisionDelta(previous, decision string) entity.Reputation {
+	var delta entity.Reputation
+	if previous == decision {
+		return delta
+	}
+	count := func(decision string, n int) {
+		switch decision {
+		case "Approved":
+			delta.BidsApproved += n
+			delta.BidsDecided += n
+		case "Rejected":
+			delta.BidsDecided += n
+		}
+	}
+	count(previous, -1)
+	count(decision, 1)
+	return delta
+}
+
+// checkAuctionWinner makes sure that the award of a reverse auction tender
+// goes to the best ranked bid once the auction is over.
+func (bs *BidService) checkAuctionWinner(ctx context.Context, bid *entity.Bid) error {
+	tender, err := bs.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+	if !tender.IsAuction() {
+		return nil
+	}
+	if !tender.AuctionFinished(time.Now().UTC()) {
+		return ErrAuctionNotFinished
+	}
+
+	bids, err := bs.bidRepo.GetAllBidsByTender(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+	offers, err := bs.auctionRepo.GetOffersByTender(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+
+	ranking := rankAuctionBids(bids, offers)
+	if len(ranking) == 0 || ranking[0].BidID != bid.ID {
+		return ErrNotAuctionWinner
+	}
+	return nil
 }
 
 func (bs *BidService) UpdateBidFeedback(ctx context.Context, input *UpdateBidFeedbackInput) (*BidOutput, error) {
 	const op = "service - BidService - UpdateBidFeedback"
 
-	bid, err := bs.bidRepo.UpdateBidFeedback(ctx, input.BidID, input.Feedback)
+	var bid *entity.Bid
+	err := bs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		previous, err := bs.bidRepo.GetBidForUpdate(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+		conflicts, err := bs.conflicts.screenReview(ctx, entity.ConflictStageReview, input.ReviewerID, previous)
+		if err != nil {
+			return err
+		}
+		bid, err = bs.bidRepo.UpdateBidFeedback(ctx, input.BidID, input.Feedback, input.Rating)
+		if err != nil {
+			return err
+		}
+		if err = bs.conflicts.record(ctx, conflicts, bid); err != nil {
+			return err
+		}
+		if err = bs.audit.record(ctx, "bid.feedback", entity.AuditBid, bid.ID, newBidOutput(previous), newBidOutput(bid)); err != nil {
+			return err
+		}
+		if input.Rating == nil {
+			return nil
+		}
+
+		delta := entity.Reputation{RatingsCount: 1, RatingsSum: *input.Rating}
+		if previous.FeedbackRating != nil {
+			delta.RatingsCount--
+			delta.RatingsSum -= *previous.FeedbackRating
+		}
+		return bs.recordReputation(ctx, bid, delta)
+	})
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
 			return nil, ErrBidNotFound
+		case errors.Is(err, ErrConflictOfInterest):
+			return nil, err
 		}
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, ErrCannotUpdateBid
 	}
 
-	return &BidOutput{
-		ID:         bid.ID,
-		Name:       bid.Name,
-		Status:     bid.Status,
-		AuthorType: bid.AuthorType,
-		AuthorID:   bid.AuthorID,
-		Version:    bid.Version,
-		CreatedAt:  bid.CreatedAt,
-	}, nil
+	return newBidOutput(bid), nil
+}
+
+// VerifyBidSignature checks the signature of a bid version against the key
+// it was made with and the bid it was stored with. Anyone may verify a
+// signature, so the document of a sealed bid is only opened once the
+// submission deadline has passed.
+func (bs *BidService) VerifyBidSignature(ctx context.Context, bidID uuid.UUID, version int) (*BidSignatureOutput, error) {
+	const op = "service - BidService - VerifyBidSignature"
+
+	bid, err := bs.bidRepo.GetBidByID(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifySignature
+	}
+	if version < 1 || version > bid.Version {
+		return nil, ErrBidVersionNotFound
+	}
+
+	output := &BidSignatureOutput{BidID: bid.ID, Version: version}
+
+	signature, err := bs.bidRepo.GetBidSignature(ctx, bid.ID, version)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			output.Reason = "bid version is not signed"
+			return output, nil
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifySignature
+	}
+
+	document := signature.Document
+	if signature.Sealed {
+		tender, err := bs.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotVerifySignature
+		}
+		if !tender.SubmissionClosed(time.Now().UTC()) {
+			return nil, ErrBidSealed
+		}
+		if bs.sealer == nil {
+			sl.Error(op, sl.Any("error", "sealer is not configured"))
+			return nil, ErrCannotVerifySignature
+		}
+		if document, err = bs.sealer.Open(document); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotVerifySignature
+		}
+	}
+
+	key, err := bs.employeeRepo.GetKeyByID(ctx, signature.KeyID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifySignature
+	}
+	employee, err := bs.employeeRepo.GetByID(ctx, key.EmployeeID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifySignature
+	}
+
+	output.Signed = true
+	output.KeyID = key.ID
+	output.Algorithm = key.Algorithm
+	output.PublicKey = key.PublicKey
+	output.Fingerprint = key.Fingerprint
+	output.KeyRevokedAt = key.RevokedAt
+	output.SignerID = employee.ID
+	output.SignerUsername = employee.Username
+	output.Document = document
+	output.Signature = signature.Signature
+	output.SignedAt = signature.CreatedAt
+
+	publicKey, err := signer.ParsePublicKey(key.PublicKey)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifySignature
+	}
+	var signed entity.BidDocument
+	switch {
+	case !publicKey.Verify(document, signature.Signature):
+		output.Reason = "signature does not match the document"
+	case json.Unmarshal(document, &signed) != nil:
+		output.Reason = "document is not a bid document"
+	case signed.TenderID != bid.TenderID || signed.AuthorID != bid.AuthorID || signed.Version != version:
+		output.Reason = "document is not of this bid version"
+	default:
+		output.Valid = true
+	}
+	return output, nil
 }
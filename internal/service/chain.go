@@ -0,0 +1,308 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	sl "log/slog"
+	"slices"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/signer"
+	"github.com/google/uuid"
+)
+
+// chainPageSize is how many entries are read at once while verifying.
+const chainPageSize = 500
+
+// ChainService verifies the hash chains and signs checkpoints of their
+// heads. Only the checkpoints signed by its own key are trusted: after the
+// key is rotated, the checkpoints of the previous key are reported.
+type ChainService struct {
+	chainRepo repo.Chain
+	auditRepo repo.Audit
+	bidRepo   repo.Bid
+	signer    *signer.Signer
+}
+
+func NewChainService(chainRepo repo.Chain, auditRepo repo.Audit, bidRepo repo.Bid, signer *signer.Signer) *ChainService {
+	return &ChainService{
+		chainRepo: chainRepo,
+		auditRepo: auditRepo,
+		bidRepo:   bidRepo,
+		signer:    signer,
+	}
+}
+
+func newChainCheckpointOutput(c *entity.ChainCheckpoint) *ChainCheckpointOutput {
+	return &ChainCheckpointOutput{
+		ID:        c.ID,
+		Chain:     c.Chain,
+		Seq:       c.Seq,
+		Hash:      c.Hash,
+		PublicKey: c.PublicKey,
+		Signature: c.Signature,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// chainEntry is an entry of either chain, reduced to what verification
+// needs.
+type chainEntry struct {
+	link     entity.ChainLink
+	entityID uuid.UUID
+	content  []byte
+}
+
+// entries reads the page of the chain following afterSeq.
+func (cs *ChainService) entries(ctx context.Context, chain string, afterSeq int64) ([]chainEntry, error) {
+	var entries []chainEntry
+	switch chain {
+	case entity.ChainAudit:
+		events, err := cs.auditRepo.GetChainedEvents(ctx, afterSeq, chainPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			content, err := e.ChainContent()
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, chainEntry{
+				link:     entity.ChainLink{Seq: *e.Seq, PrevHash: e.PrevHash, Hash: e.Hash},
+				entityID: e.ID,
+				content:  content,
+			})
+		}
+	case entity.ChainBid:
+		submissions, err := cs.bidRepo.GetBidSubmissions(ctx, afterSeq, chainPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range submissions {
+			content, err := s.ChainContent()
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, chainEntry{
+				link:     s.ChainLink,
+				entityID: s.BidID,
+				content:  content,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// checkLink compares an entry with the link expected after prev.
+func checkLink(prev entity.ChainLink, e chainEntry) *ChainBreakOutput {
+	brk := &ChainBreakOutput{Seq: prev.Seq + 1}
+	switch {
+	case e.link.Seq != prev.Seq+1:
+		brk.Reason = "entry is missing"
+	case e.link.PrevHash != prev.Hash:
+		brk.EntityID = &e.entityID
+		brk.Reason = "previous hash does not match the previous entry"
+		brk.ExpectedHash, brk.ActualHash = prev.Hash, e.link.PrevHash
+	default:
+		if hash := entity.ChainHash(e.link.PrevHash, e.content); hash != e.link.Hash {
+			brk.EntityID = &e.entityID
+			brk.Reason = "content does not match the entry hash"
+			brk.ExpectedHash, brk.ActualHash = hash, e.link.Hash
+		} else {
+			return nil
+		}
+	}
+	return brk
+}
+
+// checkCheckpoint compares a checkpoint with the link it covers.
+func (cs *ChainService) checkCheckpoint(c *entity.ChainCheckpoint, link entity.ChainLink) *ChainBreakOutput {
+	brk := &ChainBreakOutput{Seq: c.Seq, ExpectedHash: c.Hash, ActualHash: link.Hash}
+	switch {
+	case c.Hash != link.Hash:
+		brk.Reason = "entry hash does not match the signed checkpoint"
+	case !signer.Verify(c.PublicKey, c.Message(), c.Signature):
+		brk.Reason = "checkpoint signature is invalid"
+	case cs.signer == nil || !bytes.Equal(c.PublicKey, cs.signer.PublicKey()):
+		brk.Reason = "checkpoint is signed by an unknown key"
+	default:
+		return nil
+	}
+	return brk
+}
+
+// VerifyChain recomputes the chain up to its current head and reports the
+// first broken link: a changed, missing or reordered entry, or a checkpoint
+// that does not match the chain or its signature.
+func (cs *ChainService) VerifyChain(ctx context.Context, chain string) (*ChainVerificationOutput, error) {
+	const op = "service - ChainService - VerifyChain"
+
+	if !slices.Contains(entity.Chains, chain) {
+		return nil, ErrChainNotFound
+	}
+
+	// Checkpoints are read before the head, so that none of them is ahead
+	// of it.
+	checkpoints, err := cs.chainRepo.GetCheckpoints(ctx, chain)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifyChain
+	}
+	head, err := cs.chainRepo.GetHead(ctx, chain)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotVerifyChain
+	}
+
+	output := &ChainVerificationOutput{
+		Chain:       chain,
+		Checkpoints: len(checkpoints),
+		VerifiedAt:  time.Now().UTC(),
+	}
+	fail := func(brk *ChainBreakOutput) (*ChainVerificationOutput, error) {
+		output.Break = brk
+		return output, nil
+	}
+
+	var prev entity.ChainLink
+	for prev.Seq < head.Seq {
+		entries, err := cs.entries(ctx, chain, prev.Seq)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotVerifyChain
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		for _, e := range entries {
+			if e.link.Seq > head.Seq {
+				break
+			}
+			if brk := checkLink(prev, e); brk != nil {
+				return fail(brk)
+			}
+			for len(checkpoints) > 0 && checkpoints[0].Seq == e.link.Seq {
+				if brk := cs.checkCheckpoint(checkpoints[0], e.link); brk != nil {
+					return fail(brk)
+				}
+				checkpoints = checkpoints[1:]
+			}
+			prev = e.link
+			output.Length++
+		}
+	}
+
+	if prev.Seq != head.Seq || prev.Hash != head.Hash {
+		return fail(&ChainBreakOutput{
+			Seq:          prev.Seq + 1,
+			Reason:       "chain ends before its head",
+			ExpectedHash: head.Hash,
+			ActualHash:   prev.Hash,
+		})
+	}
+	if len(checkpoints) > 0 {
+		return fail(&ChainBreakOutput{
+			Seq:          checkpoints[0].Seq,
+			Reason:       "checkpoint does not match any entry",
+			ExpectedHash: checkpoints[0].Hash,
+		})
+	}
+
+	output.Valid = true
+	output.HeadSeq, output.HeadHash = head.Seq, head.Hash
+	return output, nil
+}
+
+func (cs *ChainService) GetCheckpoints(ctx context.Context, chain string) ([]*ChainCheckpointOutput, error) {
+	const op = "service - ChainService - GetCheckpoints"
+
+	if !slices.Contains(entity.Chains, chain) {
+		return nil, ErrChainNotFound
+	}
+
+	checkpoints, err := cs.chainRepo.GetCheckpoints(ctx, chain)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetCheckpoints
+	}
+
+	output := make([]*ChainCheckpointOutput, 0, len(checkpoints))
+	for _, c := range checkpoints {
+		output = append(output, newChainCheckpointOutput(c))
+	}
+	return output, nil
+}
+
+// Checkpoint signs the head of every chain that moved since its latest
+// checkpoint and returns the new checkpoints.
+func (cs *ChainService) Checkpoint(ctx context.Context) ([]*ChainCheckpointOutput, error) {
+	const op = "service - ChainService - Checkpoint"
+
+	if cs.signer == nil {
+		return nil, ErrCheckpointKeyMissing
+	}
+
+	var output []*ChainCheckpointOutput
+	for _, chain := range entity.Chains {
+		head, err := cs.chainRepo.GetHead(ctx, chain)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateCheckpoint
+		}
+		if head.Seq == 0 {
+			continue
+		}
+
+		latest, err := cs.chainRepo.GetLatestCheckpoint(ctx, chain)
+		if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateCheckpoint
+		}
+		if latest != nil && latest.Seq == head.Seq {
+			continue
+		}
+
+		checkpoint := &entity.ChainCheckpoint{
+			Chain:     chain,
+			Seq:       head.Seq,
+			Hash:      head.Hash,
+			PublicKey: cs.signer.PublicKey(),
+			CreatedAt: entity.ChainTime(time.Now()),
+		}
+		checkpoint.Signature = cs.signer.Sign(checkpoint.Message())
+
+		checkpoint, err = cs.chainRepo.CreateCheckpoint(ctx, checkpoint)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateCheckpoint
+		}
+		sl.Info(op, sl.Any("chain", chain), sl.Any("seq", checkpoint.Seq), sl.Any("hash", checkpoint.Hash))
+		output = append(output, newChainCheckpointOutput(checkpoint))
+	}
+	return output, nil
+}
+
+// Run makes the checkpoints of the chains each period until ctx is done.
+func (cs *ChainService) Run(ctx context.Context, period time.Duration) {
+	const op = "service - ChainService - Run"
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := cs.Checkpoint(ctx); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/pkg/signer"
+	"github.com/google/uuid"
+)
+
+func TestCheckLink(t *testing.T) {
+	head := &entity.ChainHead{}
+	first := head.Next([]byte("first"))
+	head = &entity.ChainHead{Seq: first.Seq, Hash: first.Hash}
+	second := head.Next([]byte("second"))
+
+	tests := []struct {
+		name       string
+		prev       entity.ChainLink
+		link       entity.ChainLink
+		content    string
+		wantReason string
+	}{
+		{
+			name:    "first entry",
+			link:    first,
+			content: "first",
+		},
+		{
+			name:    "following entry",
+			prev:    first,
+			link:    second,
+			content: "second",
+		},
+		{
+			name:       "missing entry",
+			link:       second,
+			content:    "second",
+			wantReason: "entry is missing",
+		},
+		{
+			name:       "reordered entry",
+			prev:       first,
+			link:       entity.ChainLink{Seq: 2, PrevHash: entity.ChainHash("", []byte("other")), Hash: second.Hash},
+			content:    "second",
+			wantReason: "previous hash does not match the previous entry",
+		},
+		{
+			name:       "changed content",
+			prev:       first,
+			link:       second,
+			content:    "changed",
+			wantReason: "content does not match the entry hash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brk := checkLink(tt.prev, chainEntry{link: tt.link, entityID: uuid.New(), content: []byte(tt.content)})
+			switch {
+			case tt.wantReason == "" && brk != nil:
+				t.Errorf("checkLink() = %+v, want no break", brk)
+			case tt.wantReason != "" && (brk == nil || brk.Reason != tt.wantReason):
+				t.Errorf("checkLink() = %+v, want %q", brk, tt.wantReason)
+			case brk != nil && brk.Seq != tt.prev.Seq+1:
+				t.Errorf("checkLink() break at %d, want %d", brk.Seq, tt.prev.Seq+1)
+			}
+		})
+	}
+}
+
+func TestCheckCheckpoint(t *testing.T) {
+	current, err := signer.New(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("signer.New: %v", err)
+	}
+	previous, err := signer.New(bytes.Repeat([]byte{2}, 32))
+	if err != nil {
+		t.Fatalf("signer.New: %v", err)
+	}
+
+	link := entity.ChainLink{Seq: 7, Hash: entity.ChainHash("prev", []byte("content"))}
+	checkpoint := func(s *signer.Signer, hash string) *entity.ChainCheckpoint {
+		c := &entity.ChainCheckpoint{
+			Chain:     entity.ChainAudit,
+			Seq:       link.Seq,
+			Hash:      hash,
+			PublicKey: s.PublicKey(),
+			CreatedAt: entity.ChainTime(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)),
+		}
+		c.Signature = s.Sign(c.Message())
+		return c
+	}
+
+	tampered := checkpoint(current, link.Hash)
+	tampered.CreatedAt = tampered.CreatedAt.Add(time.Second)
+
+	tests := []struct {
+		name       string
+		signer     *signer.Signer
+		checkpoint *entity.ChainCheckpoint
+		wantReason string
+	}{
+		{
+			name:       "valid",
+			signer:     current,
+			checkpoint: checkpoint(current, link.Hash),
+		},
+		{
+			name:       "hash mismatch",
+			signer:     current,
+			checkpoint: checkpoint(current, entity.ChainHash("prev", []byte("rewritten"))),
+			wantReason: "entry hash does not match the signed checkpoint",
+		},
+		{
+			name:       "signed fields changed",
+			signer:     current,
+			checkpoint: tampered,
+			wantReason: "checkpoint signature is invalid",
+		},
+		{
+			name:       "rotated key",
+			signer:     current,
+			checkpoint: checkpoint(previous, link.Hash),
+			wantReason: "checkpoint is signed by an unknown key",
+		},
+		{
+			name:       "no key configured",
+			checkpoint: checkpoint(current, link.Hash),
+			wantReason: "checkpoint is signed by an unknown key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := &ChainService{signer: tt.signer}
+			brk := cs.checkCheckpoint(tt.checkpoint, link)
+			switch {
+			case tt.wantReason == "" && brk != nil:
+				t.Errorf("checkCheckpoint() = %+v, want no break", brk)
+			case tt.wantReason != "" && (brk == nil || brk.Reason != tt.wantReason):
+				t.Errorf("checkCheckpoint() = %+v, want %q", brk, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sl "log/slog"
+	"slices"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+const (
+	ConflictActionBlock = "block"
+	ConflictActionFlag  = "flag"
+)
+
+// ConflictPolicy tells for each conflict-of-interest rule whether a
+// violation blocks the action or lets it through flagged for review.
+// Anything but ConflictActionFlag blocks.
+type ConflictPolicy struct {
+	Membership string
+	Declared   string
+}
+
+func (p ConflictPolicy) blocks(rule string) bool {
+	switch rule {
+	case entity.ConflictRuleMembership:
+		return p.Membership != ConflictActionFlag
+	case entity.ConflictRuleDeclared:
+		return p.Declared != ConflictActionFlag
+	}
+	return true
+}
+
+// ConflictChecker screens bid creation, decisions and reviews for conflicts
+// of interest: organization memberships on both sides of a bid and the
+// relationships employees declared.
+type ConflictChecker struct {
+	organizationRepo repo.Organization
+	conflictRepo     repo.Conflict
+	policy           ConflictPolicy
+}
+
+func NewConflictChecker(organizationRepo repo.Organization, conflictRepo repo.Conflict, policy ConflictPolicy) *ConflictChecker {
+	return &ConflictChecker{
+		organizationRepo: organizationRepo,
+		conflictRepo:     conflictRepo,
+		policy:           policy,
+	}
+}
+
+// screenBidCreation screens the author of a new bid against the buyer
+// organization of the tender.
+func (cc *ConflictChecker) screenBidCreation(ctx context.Context, authorID uuid.UUID, tender *entity.Tender) ([]*entity.ConflictFlag, error) {
+	return cc.screen(ctx, entity.ConflictStageBidCreation, authorID, false, []uuid.UUID{tender.OrganizationID})
+}
+
+// screenReview screens the employee deciding on or reviewing a bid against
+// its author and the organizations the author is responsible for.
+func (cc *ConflictChecker) screenReview(ctx context.Context, stage string, reviewerID uuid.UUID, bid *entity.Bid) ([]*entity.ConflictFlag, error) {
+	organizationIDs, err := cc.organizationRepo.GetEmployeeOrganizationIDs(ctx, bid.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	return cc.screen(ctx, stage, reviewerID, reviewerID == bid.AuthorID, organizationIDs)
+}
+
+// screen returns ErrConflictOfInterest on the first violation the policy
+// blocks, otherwise the violations to flag.
+func (cc *ConflictChecker) screen(ctx context.Context, stage string, employeeID uuid.UUID, ownBid bool, counterparts []uuid.UUID) ([]*entity.ConflictFlag, error) {
+	const op = "service - ConflictChecker - screen"
+
+	var violations []*entity.ConflictFlag
+	if ownBid {
+		violations = append(violations, &entity.ConflictFlag{
+			Rule:   entity.ConflictRuleMembership,
+			Detail: "employee is the author of the bid",
+		})
+	}
+
+	organizationIDs, err := cc.organizationRepo.GetEmployeeOrganizationIDs(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, organizationID := range counterparts {
+		if slices.Contains(organizationIDs, organizationID) {
+			violations = append(violations, &entity.ConflictFlag{
+				OrganizationID: &organizationID,
+				Rule:           entity.ConflictRuleMembership,
+				Detail:         "employee is responsible for an organization on the other side of the bid",
+			})
+		}
+	}
+
+	declarations, err := cc.conflictRepo.GetDeclarationsByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, declaration := range declarations {
+		if slices.Contains(counterparts, declaration.OrganizationID) {
+			violations = append(violations, &entity.ConflictFlag{
+				OrganizationID: &declaration.OrganizationID,
+				Rule:           entity.ConflictRuleDeclared,
+				Detail:         fmt.Sprintf("employee declared a %s relationship with an organization on the other side of the bid", declaration.Relationship),
+			})
+		}
+	}
+
+	for _, violation := range violations {
+		violation.Stage = stage
+		violation.EmployeeID = employeeID
+		if cc.policy.blocks(violation.Rule) {
+			sl.Warn(op, sl.Any("employee_id", employeeID), sl.Any("stage", stage), sl.Any("rule", violation.Rule))
+			return nil, fmt.Errorf("%w: %s", ErrConflictOfInterest, violation.Detail)
+		}
+	}
+	return violations, nil
+}
+
+// record stores the flagged violations against the bid. It is meant to run
+// in the transaction of the screened action.
+func (cc *ConflictChecker) record(ctx context.Context, flags []*entity.ConflictFlag, bid *entity.Bid) error {
+	for _, flag := range flags {
+		flag.TenderID = bid.TenderID
+		flag.BidID = bid.ID
+		if _, err := cc.conflictRepo.CreateFlag(ctx, flag); err != nil {
+			return err
+		}
+		sl.Warn("service - ConflictChecker - record", sl.Any("bid_id", bid.ID), sl.Any("stage", flag.Stage), sl.Any("rule", flag.Rule))
+	}
+	return nil
+}
+
+type ConflictService struct {
+	conflictRepo repo.Conflict
+	audit        *Auditor
+	tx           repo.Transactor
+}
+
+func NewConflictService(conflictRepo repo.Conflict, audit *Auditor, tx repo.Transactor) *ConflictService {
+	return &ConflictService{
+		conflictRepo: conflictRepo,
+		audit:        audit,
+		tx:           tx,
+	}
+}
+
+func newConflictDeclarationOutput(d *entity.ConflictDeclaration) *ConflictDeclarationOutput {
+	return &ConflictDeclarationOutput{
+		ID:             d.ID,
+		OrganizationID: d.OrganizationID,
+		Relationship:   d.Relationship,
+		Description:    d.Description,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func (cs *ConflictService) DeclareRelationship(ctx context.Context, input *DeclareRelationshipInput) (*ConflictDeclarationOutput, error) {
+	const op = "service - ConflictService - DeclareRelationship"
+
+	var declaration *entity.ConflictDeclaration
+	err := cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		declaration, err = cs.conflictRepo.CreateDeclaration(ctx, &entity.ConflictDeclaration{
+			EmployeeID:     input.EmployeeID,
+			OrganizationID: input.OrganizationID,
+			Relationship:   input.Relationship,
+			Description:    input.Description,
+		})
+		if err != nil {
+			return err
+		}
+		return cs.audit.record(ctx, "conflict.declare", entity.AuditConflict, declaration.ID, nil, newConflictDeclarationOutput(declaration))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrDeclarationAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrOrganizationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotDeclareRelationship
+	}
+
+	return newConflictDeclarationOutput(declaration), nil
+}
+
+func (cs *ConflictService) GetDeclarations(ctx context.Context, employeeID uuid.UUID) ([]*ConflictDeclarationOutput, error) {
+	const op = "service - ConflictService - GetDeclarations"
+
+	declarations, err := cs.conflictRepo.GetDeclarationsByEmployee(ctx, employeeID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetDeclarations
+	}
+
+	output := make([]*ConflictDeclarationOutput, 0, len(declarations))
+	for _, declaration := range declarations {
+		output = append(output, newConflictDeclarationOutput(declaration))
+	}
+	return output, nil
+}
+
+func (cs *ConflictService) DeleteDeclaration(ctx context.Context, declarationID, employeeID uuid.UUID) error {
+	const op = "service - ConflictService - DeleteDeclaration"
+
+	err := cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := cs.conflictRepo.DeleteDeclaration(ctx, declarationID, employeeID); err != nil {
+			return err
+		}
+		return cs.audit.record(ctx, "conflict.delete", entity.AuditConflict, declarationID, nil, nil)
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrDeclarationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteDeclaration
+	}
+	return nil
+}
+
+func (cs *ConflictService) GetTenderConflicts(ctx context.Context, tenderID uuid.UUID) ([]*ConflictFlagOutput, error) {
+	const op = "service - ConflictService - GetTenderConflicts"
+
+	flags, err := cs.conflictRepo.GetFlagsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetConflicts
+	}
+
+	output := make([]*ConflictFlagOutput, 0, len(flags))
+	for _, flag := range flags {
+		output = append(output, &ConflictFlagOutput{
+			ID:             flag.ID,
+			BidID:          flag.BidID,
+			EmployeeID:     flag.EmployeeID,
+			OrganizationID: flag.OrganizationID,
+			Stage:          flag.Stage,
+			Rule:           flag.Rule,
+			Detail:         flag.Detail,
+			CreatedAt:      flag.CreatedAt,
+		})
+	}
+	return output, nil
+}
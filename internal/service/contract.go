@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+// checkAward makes sure the locked tender can still be awarded: it must be
+// published and, unless the award is for one of its lots, have no contract
+// yet. Lots are guarded by their own status.
+func checkAward(ctx context.Context, contractRepo repo.Contract, tender *entity.Tender, forLot bool) error {
+	switch {
+	case tender.IsCanceled():
+		return ErrTenderCanceled
+	case tender.Status != entity.TenderStatusPublished:
+		return ErrTenderNotPublished
+	case forLot:
+		return nil
+	}
+
+	awarded, err := contractRepo.CountTenderContracts(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+	if awarded > 0 {
+		return ErrTenderAlreadyAwarded
+	}
+	return nil
+}
+
+// awardContract makes the contract of an awarded bid, for the whole tender
+// or for the lot it won. Awarding the same bid again keeps the existing
+// contract.
+func awardContract(ctx context.Context, contractRepo repo.Contract, organizationRepo repo.Organization, tenderRepo repo.Tender, audit *Auditor, events *EventPublisher, bid *entity.Bid, lotID *uuid.UUID) error {
+	tender, err := tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	supplierOrganizationID, err := bidOrganizationID(ctx, organizationRepo, bid)
+	if err != nil {
+		return err
+	}
+
+	contract, err := contractRepo.CreateContract(ctx, &entity.Contract{
+		TenderID:               tender.ID,
+		BidID:                  bid.ID,
+		LotID:                  lotID,
+		BidVersion:             bid.Version,
+		BuyerOrganizationID:    tender.OrganizationID,
+		SupplierOrganizationID: supplierOrganizationID,
+		SupplierID:             bid.AuthorID,
+		Price:                  bid.Price,
+		Currency:               bid.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return nil
+		}
+		return err
+	}
+	if err = audit.record(ctx, "contract.award", entity.AuditContract, contract.ID, nil, newContractOutput(contract, nil)); err != nil {
+		return err
+	}
+	if err = events.publish(ctx, entity.EventContractAwarded, entity.AggregateContract, contract.ID, newContractOutput(contract, nil)); err != nil {
+		return err
+	}
+
+	sl.Info("service - awardContract", sl.Any("contract_id", contract.ID), sl.Any("bid_id", bid.ID))
+	return nil
+}
+
+type ContractService struct {
+	contractRepo     repo.Contract
+	organizationRepo repo.Organization
+	reputationRepo   repo.Reputation
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewContractService(contractRepo repo.Contract, organizationRepo repo.Organization, reputationRepo repo.Reputation, audit *Auditor, tx repo.Transactor) *ContractService {
+	return &ContractService{
+		contractRepo:     contractRepo,
+		organizationRepo: organizationRepo,
+		reputationRepo:   reputationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newContractOutput(c *entity.Contract, milestones []*entity.Milestone) *ContractOutput {
+	output := &ContractOutput{
+		ID:                     c.ID,
+		TenderID:               c.TenderID,
+		BidID:                  c.BidID,
+		LotID:                  c.LotID,
+		BidVersion:             c.BidVersion,
+		BuyerOrganizationID:    c.BuyerOrganizationID,
+		SupplierOrganizationID: c.SupplierOrganizationID,
+		SupplierID:             c.SupplierID,
+		Price:                  c.Price,
+		Currency:               c.Currency,
+		Status:                 c.Status,
+		CreatedAt:              c.CreatedAt,
+		CompletedAt:            c.CompletedAt,
+		Milestones:             make([]*MilestoneOutput, 0, len(milestones)),
+	}
+	for _, m := range milestones {
+		output.Milestones = append(output.Milestones, newMilestoneOutput(m))
+	}
+	return output
+}
+
+func newMilestoneOutput(m *entity.Milestone) *MilestoneOutput {
+	return &MilestoneOutput{
+		ID:                  m.ID,
+		Name:                m.Name,
+		Description:         m.Description,
+		DueDate:             m.DueDate,
+		SupplierConfirmedAt: m.SupplierConfirmedAt,
+		BuyerConfirmedAt:    m.BuyerConfirmedAt,
+		CompletedAt:         m.CompletedAt,
+		Overdue:             m.IsOverdue(time.Now().UTC()),
+		CreatedAt:           m.CreatedAt,
+	}
+}
+
+// sides tells whether the employee acts for the supplier or the buyer of the
+// contract. The supplier side is the bid author and the responsibles of
+// their organization.
+func (cs *ContractService) sides(ctx context.Context, contract *entity.Contract, employeeID uuid.UUID) (supplier, buyer bool, err error) {
+	isResponsible := func(organizationID uuid.UUID) (bool, error) {
+		_, err := cs.organizationRepo.GetOrganizationResponsible(ctx, organizationID, employeeID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	supplier = contract.SupplierID == employeeID
+	if !supplier && contract.SupplierOrganizationID != nil {
+		if supplier, err = isResponsible(*contract.SupplierOrganizationID); err != nil {
+			return false, false, err
+		}
+	}
+	if buyer, err = isResponsible(contract.BuyerOrganizationID); err != nil {
+		return false, false, err
+	}
+	return supplier, buyer, nil
+}
+
+func (cs *ContractService) GetContract(ctx context.Context, contractID, employeeID uuid.UUID) (*ContractOutput, error) {
+	const op = "service - ContractService - GetContract"
+
+	contract, err := cs.contractRepo.GetContractByID(ctx, contractID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrContractNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetContracts
+	}
+
+	supplier, buyer, err := cs.sides(ctx, contract, employeeID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetContracts
+	}
+	if !supplier && !buyer {
+		return nil, ErrNotContractParty
+	}
+
+	milestones, err := cs.contractRepo.GetMilestonesByContract(ctx, contract.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetContracts
+	}
+
+	return newContractOutput(contract, milestones), nil
+}
+
+func (cs *ContractService) GetUserContracts(ctx context.Context, input *GetContractsInput) ([]*ContractOutput, error) {
+	const op = "service - ContractService - GetUserContracts"
+
+	contracts, err := cs.contractRepo.GetUserContracts(ctx, input.Limit, input.Offset, input.EmployeeID, input.OverdueOnly)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetContracts
+	}
+
+	output := make([]*ContractOutput, 0, len(contracts))
+	for _, contract := range contracts {
+		milestones, err := cs.contractRepo.GetMilestonesByContract(ctx, contract.ID)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotGetContracts
+		}
+		output = append(output, newContractOutput(contract, milestones))
+	}
+
+	return output, nil
+}
+
+// AddMilestone plans a delivery of the contract. Milestones are planned by
+// the buyer.
+func (cs *ContractService) AddMilestone(ctx context.Context, input *AddMilestoneInput) (*MilestoneOutput, error) {
+	const op = "service - ContractService - AddMilestone"
+
+	var milestone *entity.Milestone
+	err := cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		contract, err := cs.contractRepo.GetContractForUpdate(ctx, input.ContractID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrContractNotFound
+			}
+			return err
+		}
+
+		_, buyer, err := cs.sides(ctx, contract, input.EmployeeID)
+		if err != nil {
+			return err
+		}
+		if !buyer {
+			return ErrNotContractParty
+		}
+		if contract.Status == entity.ContractCompleted {
+			return ErrContractCompleted
+		}
+
+		milestone, err = cs.contractRepo.CreateMilestone(ctx, &entity.Milestone{
+			ContractID:  contract.ID,
+			Name:        input.Name,
+			Description: input.Description,
+			DueDate:     input.DueDate.UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		return cs.audit.record(ctx, "contract.add_milestone", entity.AuditContract, contract.ID, nil, newMilestoneOutput(milestone))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrContractNotFound), errors.Is(err, ErrNotContractParty), errors.Is(err, ErrContractCompleted):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAddMilestone
+	}
+
+	return newMilestoneOutput(milestone), nil
+}
+
+// ConfirmMilestone records the confirmation of one side. The milestone is
+// completed once both sides have confirmed it, which counts towards the
+// supplier's reputation, and the contract once all of its milestones are
+// completed.
+func (cs *ContractService) ConfirmMilestone(ctx context.Context, input *ConfirmMilestoneInput) (*MilestoneOutput, error) {
+	const op = "service - ContractService - ConfirmMilestone"
+
+	var milestone *entity.Milestone
+	err := cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		contract, err := cs.contractRepo.GetContractForUpdate(ctx, input.ContractID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrContractNotFound
+			}
+			return err
+		}
+
+		milestone, err = cs.contractRepo.GetMilestoneForUpdate(ctx, input.MilestoneID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrMilestoneNotFound
+			}
+			return err
+		}
+		if milestone.ContractID != contract.ID {
+			return ErrMilestoneNotFound
+		}
+
+		supplier, buyer, err := cs.sides(ctx, contract, input.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		previous := *milestone
+		now := time.Now().UTC()
+		switch input.Side {
+		case entity.ContractSideSupplier:
+			if !supplier {
+				return ErrNotContractParty
+			}
+			if milestone.SupplierConfirmedAt != nil {
+				return ErrMilestoneConfirmed
+			}
+			milestone.SupplierConfirmedAt = &now
+		case entity.ContractSideBuyer:
+			if !buyer {
+				return ErrNotContractParty
+			}
+			if milestone.BuyerConfirmedAt != nil {
+				return ErrMilestoneConfirmed
+			}
+			milestone.BuyerConfirmedAt = &now
+		}
+		if milestone.SupplierConfirmedAt != nil && milestone.BuyerConfirmedAt != nil {
+			milestone.CompletedAt = &now
+		}
+
+		milestone, err = cs.contractRepo.ConfirmMilestone(ctx, milestone)
+		if err != nil {
+			return err
+		}
+		if err = cs.audit.record(ctx, "contract.confirm_milestone", entity.AuditContract, contract.ID, newMilestoneOutput(&previous), newMilestoneOutput(milestone)); err != nil {
+			return err
+		}
+		if milestone.CompletedAt == nil {
+			return nil
+		}
+
+		delta := entity.Reputation{MilestonesCompleted: 1}
+		if milestone.CompletedOnTime() {
+			delta.MilestonesOnTime = 1
+		}
+		if err = recordReputation(ctx, cs.reputationRepo, delta, contract.SupplierID, contract.SupplierOrganizationID); err != nil {
+			return err
+		}
+
+		milestones, err := cs.contractRepo.GetMilestonesByContract(ctx, contract.ID)
+		if err != nil {
+			return err
+		}
+		for _, m := range milestones {
+			if m.CompletedAt == nil {
+				return nil
+			}
+		}
+		_, err = cs.contractRepo.CompleteContract(ctx, contract.ID, now)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrContractNotFound), errors.Is(err, ErrMilestoneNotFound), errors.Is(err, ErrNotContractParty),
+			errors.Is(err, ErrMilestoneConfirmed):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotConfirmMilestone
+	}
+
+	return newMilestoneOutput(milestone), nil
+}
+
+// GetSupplierRating derives the rating of a supplier organization from its
+// milestones: late completions and overdue milestones count against it.
+func (cs *ContractService) GetSupplierRating(ctx context.Context, organizationID uuid.UUID) (*SupplierRatingOutput, error) {
+	const op = "service - ContractService - GetSupplierRating"
+
+	exists, err := cs.organizationRepo.OrganizationExists(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSupplierRating
+	}
+	if !exists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	record, err := cs.contractRepo.GetSupplierRecord(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSupplierRating
+	}
+
+	output := &SupplierRatingOutput{
+		OrganizationID:      organizationID,
+		CompletedMilestones: record.Completed,
+		OnTimeMilestones:    record.OnTime,
+		OverdueMilestones:   record.Overdue,
+	}
+	if due := record.Completed + record.Overdue; due > 0 {
+		rate := float64(record.OnTime) / float64(due)
+		output.OnTimeRate = &rate
+	}
+
+	return output, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type DebarmentService struct {
+	debarmentRepo repo.Debarment
+	audit         *Auditor
+	tx            repo.Transactor
+}
+
+func NewDebarmentService(debarmentRepo repo.Debarment, audit *Auditor, tx repo.Transactor) *DebarmentService {
+	return &DebarmentService{
+		debarmentRepo: debarmentRepo,
+		audit:         audit,
+		tx:            tx,
+	}
+}
+
+func newDebarmentOutput(d *entity.Debarment) *DebarmentOutput {
+	return &DebarmentOutput{
+		ID:                  d.ID,
+		OrganizationID:      d.OrganizationID,
+		EmployeeID:          d.EmployeeID,
+		BuyerOrganizationID: d.BuyerOrganizationID,
+		Reason:              d.Reason,
+		ExpiresAt:           d.ExpiresAt,
+		CreatedBy:           d.CreatedBy,
+		CreatedAt:           d.CreatedAt,
+		LiftedAt:            d.LiftedAt,
+		Active:              d.IsActive(time.Now().UTC()),
+	}
+}
+
+func (ds *DebarmentService) CreateDebarment(ctx context.Context, input *CreateDebarmentInput) (*DebarmentOutput, error) {
+	const op = "service - DebarmentService - CreateDebarment"
+
+	var debarment *entity.Debarment
+	err := ds.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		debarment, err = ds.debarmentRepo.CreateDebarment(ctx, &entity.Debarment{
+			OrganizationID:      input.OrganizationID,
+			EmployeeID:          input.EmployeeID,
+			BuyerOrganizationID: input.BuyerOrganizationID,
+			Reason:              input.Reason,
+			ExpiresAt:           input.ExpiresAt,
+			CreatedBy:           input.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		return ds.audit.record(ctx, "debarment.create", entity.AuditDebarment, debarment.ID, nil, newDebarmentOutput(debarment))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrDebarmentSubjectNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateDebarment
+	}
+
+	sl.Info(op, sl.Any("debarment_id", debarment.ID), sl.Any("created_by", debarment.CreatedBy))
+	return newDebarmentOutput(debarment), nil
+}
+
+func (ds *DebarmentService) GetDebarments(ctx context.Context, input *GetDebarmentsInput) ([]*DebarmentOutput, error) {
+	const op = "service - DebarmentService - GetDebarments"
+
+	debarments, err := ds.debarmentRepo.GetDebarments(ctx, input.Limit, input.Offset, input.ActiveOnly)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetDebarments
+	}
+
+	output := make([]*DebarmentOutput, 0, len(debarments))
+	for _, debarment := range debarments {
+		output = append(output, newDebarmentOutput(debarment))
+	}
+	return output, nil
+}
+
+// UpdateDebarment changes the reason or the expiry of a debarment that has
+// not been lifted.
+func (ds *DebarmentService) UpdateDebarment(ctx context.Context, input *UpdateDebarmentInput) (*DebarmentOutput, error) {
+	const op = "service - DebarmentService - UpdateDebarment"
+
+	updates := make(map[string]interface{})
+	if input.Reason != "" {
+		updates["reason"] = input.Reason
+	}
+	if input.ExpiresAt != nil {
+		updates["expires_at"] = input.ExpiresAt.UTC()
+	}
+
+	debarment, err := ds.changeDebarment(ctx, "debarment.update", input.DebarmentID, updates)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrDebarmentNotFound), errors.Is(err, ErrDebarmentLifted):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateDebarment
+	}
+
+	return newDebarmentOutput(debarment), nil
+}
+
+// LiftDebarment ends a debarment before its expiry. Lifted debarments are
+// kept as a record.
+func (ds *DebarmentService) LiftDebarment(ctx context.Context, debarmentID uuid.UUID) (*DebarmentOutput, error) {
+	const op = "service - DebarmentService - LiftDebarment"
+
+	debarment, err := ds.changeDebarment(ctx, "debarment.lift", debarmentID, map[string]interface{}{
+		"lifted_at": time.Now().UTC(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrDebarmentNotFound), errors.Is(err, ErrDebarmentLifted):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateDebarment
+	}
+
+	sl.Info(op, sl.Any("debarment_id", debarment.ID))
+	return newDebarmentOutput(debarment), nil
+}
+
+func (ds *DebarmentService) changeDebarment(ctx context.Context, action string, debarmentID uuid.UUID, updates map[string]interface{}) (*entity.Debarment, error) {
+	var debarment *entity.Debarment
+	err := ds.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ds.debarmentRepo.GetDebarmentForUpdate(ctx, debarmentID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrDebarmentNotFound
+			}
+			return err
+		}
+		if current.LiftedAt != nil {
+			return ErrDebarmentLifted
+		}
+		if len(updates) == 0 {
+			debarment = current
+			return nil
+		}
+
+		debarment, err = ds.debarmentRepo.UpdateDebarment(ctx, debarmentID, updates)
+		if err != nil {
+			return err
+		}
+		return ds.audit.record(ctx, action, entity.AuditDebarment, debarment.ID, newDebarmentOutput(current), newDebarmentOutput(debarment))
+	})
+	return debarment, err
+}
+
+func (ds *DebarmentService) GetDebarmentBlocks(ctx context.Context, limit, offset int) ([]*DebarmentBlockOutput, error) {
+	const op = "service - DebarmentService - GetDebarmentBlocks"
+
+	blocks, err := ds.debarmentRepo.GetDebarmentBlocks(ctx, limit, offset)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetDebarments
+	}
+
+	output := make([]*DebarmentBlockOutput, 0, len(blocks))
+	for _, block := range blocks {
+		output = append(output, &DebarmentBlockOutput{
+			ID:          block.ID,
+			DebarmentID: block.DebarmentID,
+			TenderID:    block.TenderID,
+			EmployeeID:  block.EmployeeID,
+			AttemptedAt: block.AttemptedAt,
+		})
+	}
+	return output, nil
+}
@@ -2,20 +2,35 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	sl "log/slog"
 
+	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/signer"
 	"github.com/google/uuid"
 )
 
 type EmployeeService struct {
 	employeeRepo repo.Employee
+	admins       map[string]bool
+	audit        *Auditor
+	tx           repo.Transactor
 }
 
-func NewEmployeeService(employeeRepo repo.Employee) *EmployeeService {
+func NewEmployeeService(employeeRepo repo.Employee, adminUsernames []string, audit *Auditor, tx repo.Transactor) *EmployeeService {
+	admins := make(map[string]bool, len(adminUsernames))
+	for _, username := range adminUsernames {
+		admins[username] = true
+	}
+
 	return &EmployeeService{
 		employeeRepo: employeeRepo,
+		admins:       admins,
+		audit:        audit,
+		tx:           tx,
 	}
 }
 
@@ -24,6 +39,9 @@ func (es *EmployeeService) GetByUsername(ctx context.Context, username string) (
 
 	employee, err := es.employeeRepo.GetByUsername(ctx, username)
 	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -42,6 +60,9 @@ func (es *EmployeeService) GetByID(ctx context.Context, employeeID uuid.UUID) (*
 
 	employee, err := es.employeeRepo.GetByID(ctx, employeeID)
 	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -65,3 +86,111 @@ func (es *EmployeeService) IsResponsible(ctx context.Context, employeeID uuid.UU
 	}
 	return isResponsible, nil
 }
+
+// IsAdmin reports whether the employee manages platform reference data.
+// Administrators are listed in the configuration.
+func (es *EmployeeService) IsAdmin(username string) bool {
+	return es.admins[username]
+}
+
+func newEmployeeKeyOutput(k *entity.EmployeeKey) *EmployeeKeyOutput {
+	return &EmployeeKeyOutput{
+		ID:          k.ID,
+		EmployeeID:  k.EmployeeID,
+		Algorithm:   k.Algorithm,
+		PublicKey:   k.PublicKey,
+		Fingerprint: k.Fingerprint,
+		CreatedAt:   k.CreatedAt,
+		RevokedAt:   k.RevokedAt,
+	}
+}
+
+// RegisterKey adds a public key the employee signs bids with.
+func (es *EmployeeService) RegisterKey(ctx context.Context, input *RegisterKeyInput) (*EmployeeKeyOutput, error) {
+	const op = "service - EmployeeService - RegisterKey"
+
+	publicKey, err := signer.ParsePublicKey(input.PublicKey)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	var key *entity.EmployeeKey
+	err = es.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		key, err = es.employeeRepo.CreateKey(ctx, &entity.EmployeeKey{
+			EmployeeID:  input.EmployeeID,
+			Algorithm:   publicKey.Algorithm,
+			PublicKey:   publicKey.DER,
+			Fingerprint: publicKey.Fingerprint(),
+		})
+		if err != nil {
+			return err
+		}
+		return es.audit.record(ctx, "employee_key.register", entity.AuditEmployeeKey, key.ID, nil, newEmployeeKeyOutput(key))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrKeyAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrEmployeeNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotRegisterKey
+	}
+
+	return newEmployeeKeyOutput(key), nil
+}
+
+func (es *EmployeeService) GetKeys(ctx context.Context, employeeID uuid.UUID) ([]*EmployeeKeyOutput, error) {
+	const op = "service - EmployeeService - GetKeys"
+
+	keys, err := es.employeeRepo.GetKeys(ctx, employeeID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetKeys
+	}
+
+	output := make([]*EmployeeKeyOutput, 0, len(keys))
+	for _, k := range keys {
+		output = append(output, newEmployeeKeyOutput(k))
+	}
+	return output, nil
+}
+
+// RevokeKey stops the key from signing new bids. The bids it signed before
+// keep verifying.
+func (es *EmployeeService) RevokeKey(ctx context.Context, employeeID, keyID uuid.UUID) (*EmployeeKeyOutput, error) {
+	const op = "service - EmployeeService - RevokeKey"
+
+	var key *entity.EmployeeKey
+	err := es.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := es.employeeRepo.GetKeyByID(ctx, keyID)
+		if err != nil {
+			return err
+		}
+		if current.EmployeeID != employeeID {
+			return ErrKeyNotFound
+		}
+		if current.IsRevoked() {
+			return ErrKeyRevoked
+		}
+
+		if key, err = es.employeeRepo.RevokeKey(ctx, keyID); err != nil {
+			return err
+		}
+		return es.audit.record(ctx, "employee_key.revoke", entity.AuditEmployeeKey, key.ID, newEmployeeKeyOutput(current), newEmployeeKeyOutput(key))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrKeyNotFound), errors.Is(err, ErrKeyRevoked):
+			return nil, err
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrKeyNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotRevokeKey
+	}
+
+	return newEmployeeKeyOutput(key), nil
+}
@@ -3,19 +3,174 @@ package service
 import "fmt"
 
 var (
-	ErrTenderAlreadyExists      = fmt.Errorf("tender already exists")
-	ErrCannotCreateTender       = fmt.Errorf("cannot create tender")
-	ErrCannotGetTenders         = fmt.Errorf("cannot get tenders")
-	ErrTenderNotFound           = fmt.Errorf("tender not found")
-	ErrCannotGetTender          = fmt.Errorf("cannot get tender")
-	ErrEmployeeNotFound         = fmt.Errorf("employee not found")
-	ErrCannotUpdateTenderStatus = fmt.Errorf("cannot update tender status")
-	ErrCannotUpdateTender       = fmt.Errorf("cannot update tender")
-	ErrCannotCreateBid          = fmt.Errorf("cannot create bid")
-	ErrBidAlreadyExists         = fmt.Errorf("bid already exists")
-	ErrBidsNotFound             = fmt.Errorf("bids not found")
-	ErrCannotGetBids            = fmt.Errorf("cannot get bids")
-	ErrBidNotFound              = fmt.Errorf("bid not found")
-	ErrCannotGetBid             = fmt.Errorf("cannot get bid")
-	ErrCannotUpdateBid          = fmt.Errorf("cannot update bid")
+	ErrTenderAlreadyExists        = fmt.Errorf("tender already exists")
+	ErrCannotCreateTender         = fmt.Errorf("cannot create tender")
+	ErrInvalidTender              = fmt.Errorf("invalid tender")
+	ErrCannotGetTenders           = fmt.Errorf("cannot get tenders")
+	ErrTenderNotFound             = fmt.Errorf("tender not found")
+	ErrCannotGetTender            = fmt.Errorf("cannot get tender")
+	ErrEmployeeNotFound           = fmt.Errorf("employee not found")
+	ErrCannotUpdateTenderStatus   = fmt.Errorf("cannot update tender status")
+	ErrCannotUpdateTender         = fmt.Errorf("cannot update tender")
+	ErrCannotCreateBid            = fmt.Errorf("cannot create bid")
+	ErrBidAlreadyExists           = fmt.Errorf("bid already exists")
+	ErrBidsNotFound               = fmt.Errorf("bids not found")
+	ErrCannotGetBids              = fmt.Errorf("cannot get bids")
+	ErrBidNotFound                = fmt.Errorf("bid not found")
+	ErrCannotGetBid               = fmt.Errorf("cannot get bid")
+	ErrCannotUpdateBid            = fmt.Errorf("cannot update bid")
+	ErrSubmissionClosed           = fmt.Errorf("bid submission is closed")
+	ErrCannotOpenBids             = fmt.Errorf("cannot open sealed bids")
+	ErrBidSealed                  = fmt.Errorf("bid is sealed until the submission deadline")
+	ErrCurrencyMismatch           = fmt.Errorf("bid currency does not match tender currency")
+	ErrCannotCompareBids          = fmt.Errorf("cannot compare bids")
+	ErrCriterionNotFound          = fmt.Errorf("criterion not found")
+	ErrCannotScoreBid             = fmt.Errorf("cannot score bid")
+	ErrCannotGetRanking           = fmt.Errorf("cannot get ranking")
+	ErrCannotGetCriteria          = fmt.Errorf("cannot get criteria")
+	ErrScoringIncomplete          = fmt.Errorf("bid scoring is not complete")
+	ErrLotNotFound                = fmt.Errorf("lot not found")
+	ErrLotRequired                = fmt.Errorf("bid must target at least one lot of the tender")
+	ErrLotAlreadyDecided          = fmt.Errorf("lot is already decided")
+	ErrBidNotForLot               = fmt.Errorf("bid does not target this lot")
+	ErrLotDecisionRequired        = fmt.Errorf("tender has lots, each lot must be decided separately")
+	ErrCannotGetLots              = fmt.Errorf("cannot get lots")
+	ErrCannotDecideLot            = fmt.Errorf("cannot decide lot")
+	ErrNotAuction                 = fmt.Errorf("tender is not a reverse auction")
+	ErrAuctionNotRunning          = fmt.Errorf("auction is not running")
+	ErrAuctionNotFinished         = fmt.Errorf("auction is still running")
+	ErrOfferNotLower              = fmt.Errorf("offer must be lower than the current bid price")
+	ErrAuctionPriceLocked         = fmt.Errorf("auction bid price can only be lowered through the auction")
+	ErrNotAuctionWinner           = fmt.Errorf("only the best ranked auction bid can be approved")
+	ErrCannotGetAuction           = fmt.Errorf("cannot get auction")
+	ErrCannotPlaceOffer           = fmt.Errorf("cannot place offer")
+	ErrInvalidServiceType         = fmt.Errorf("unknown or inactive service type")
+	ErrAuctionServiceType         = fmt.Errorf("reverse auctions are only available for delivery service types")
+	ErrServiceTypeNotFound        = fmt.Errorf("service type not found")
+	ErrServiceTypeAlreadyExists   = fmt.Errorf("service type with this name or code already exists")
+	ErrServiceTypeInUse           = fmt.Errorf("service type is used by tenders or has subcategories")
+	ErrServiceTypeCycle           = fmt.Errorf("service type cannot be moved under its own subcategory")
+	ErrCannotGetServiceTypes      = fmt.Errorf("cannot get service types")
+	ErrCannotCreateServiceType    = fmt.Errorf("cannot create service type")
+	ErrCannotUpdateServiceType    = fmt.Errorf("cannot update service type")
+	ErrCannotDeleteServiceType    = fmt.Errorf("cannot delete service type")
+	ErrNotBidAuthor               = fmt.Errorf("only the bid author can do this")
+	ErrAttachmentNotFound         = fmt.Errorf("attachment not found")
+	ErrAttachmentEmpty            = fmt.Errorf("attachment is empty")
+	ErrAttachmentTooLarge         = fmt.Errorf("attachment exceeds the maximum size")
+	ErrAttachmentTypeNotAllowed   = fmt.Errorf("attachment type is not allowed")
+	ErrCannotUploadAttachment     = fmt.Errorf("cannot upload attachment")
+	ErrCannotGetAttachments       = fmt.Errorf("cannot get attachments")
+	ErrCannotGetAttachment        = fmt.Errorf("cannot get attachment")
+	ErrTenderNotPublished         = fmt.Errorf("tender is not published")
+	ErrResponsibleCannotAsk       = fmt.Errorf("tender responsibles cannot ask questions about their own tender")
+	ErrQuestionNotFound           = fmt.Errorf("question not found")
+	ErrQuestionAlreadyAnswered    = fmt.Errorf("question is already answered")
+	ErrCannotAskQuestion          = fmt.Errorf("cannot ask question")
+	ErrCannotGetQuestions         = fmt.Errorf("cannot get questions")
+	ErrCannotAnswerQuestion       = fmt.Errorf("cannot answer question")
+	ErrNotInThread                = fmt.Errorf("only the bid author and tender responsibles can access the bid messages")
+	ErrCannotSendMessage          = fmt.Errorf("cannot send message")
+	ErrCannotGetMessages          = fmt.Errorf("cannot get messages")
+	ErrNotInvited                 = fmt.Errorf("tender is open to invited bidders only")
+	ErrTenderNotInvitationOnly    = fmt.Errorf("tender is open to every bidder")
+	ErrInvitationNotFound         = fmt.Errorf("invitation not found")
+	ErrInvitationAlreadyExists    = fmt.Errorf("organization or employee is already invited")
+	ErrInviteeNotFound            = fmt.Errorf("invited organization or employee not found")
+	ErrCannotInvite               = fmt.Errorf("cannot invite")
+	ErrCannotGetInvitations       = fmt.Errorf("cannot get invitations")
+	ErrCannotDeleteInvitation     = fmt.Errorf("cannot delete invitation")
+	ErrAmendmentReasonRequired    = fmt.Errorf("a reason is required to amend a published tender")
+	ErrBidNeedsAcknowledgement    = fmt.Errorf("bid author has not acknowledged the latest tender amendment")
+	ErrCannotGetAmendments        = fmt.Errorf("cannot get tender amendments")
+	ErrCannotAcknowledgeBid       = fmt.Errorf("cannot acknowledge bid")
+	ErrNotificationNotFound       = fmt.Errorf("notification not found")
+	ErrCannotGetNotifications     = fmt.Errorf("cannot get notifications")
+	ErrCannotUpdateNotification   = fmt.Errorf("cannot update notification")
+	ErrTenderCanceled             = fmt.Errorf("tender is canceled")
+	ErrTenderAlreadyClosed        = fmt.Errorf("tender is already closed")
+	ErrTenderAlreadyAwarded       = fmt.Errorf("tender is already awarded")
+	ErrCannotCancelTender         = fmt.Errorf("cannot cancel tender")
+	ErrBidCanceled                = fmt.Errorf("bid is canceled")
+	ErrBidWithdrawn               = fmt.Errorf("bid is withdrawn")
+	ErrBidNotWithdrawn            = fmt.Errorf("only a withdrawn bid can be resubmitted")
+	ErrCannotWithdrawBid          = fmt.Errorf("cannot withdraw bid")
+	ErrCannotResubmitBid          = fmt.Errorf("cannot resubmit bid")
+	ErrCannotGetBidVersions       = fmt.Errorf("cannot get bid versions")
+	ErrNegotiationRoundOpen       = fmt.Errorf("bid already has an open negotiation round")
+	ErrNoOpenNegotiationRound     = fmt.Errorf("bid has no open negotiation round")
+	ErrNegotiationClosed          = fmt.Errorf("tender no longer accepts negotiation")
+	ErrNegotiationNotAvailable    = fmt.Errorf("reverse auction bids are negotiated through the auction only")
+	ErrCannotProposeCounter       = fmt.Errorf("cannot propose counter-offer")
+	ErrCannotRespondCounter       = fmt.Errorf("cannot respond to counter-offer")
+	ErrCannotGetNegotiation       = fmt.Errorf("cannot get negotiation rounds")
+	ErrContractNotFound           = fmt.Errorf("contract not found")
+	ErrNotContractParty           = fmt.Errorf("user is not a party to the contract")
+	ErrContractCompleted          = fmt.Errorf("contract is already completed")
+	ErrMilestoneNotFound          = fmt.Errorf("milestone not found")
+	ErrMilestoneConfirmed         = fmt.Errorf("milestone is already confirmed by this side")
+	ErrOrganizationNotFound       = fmt.Errorf("organization not found")
+	ErrCannotGetContracts         = fmt.Errorf("cannot get contracts")
+	ErrCannotAddMilestone         = fmt.Errorf("cannot add milestone")
+	ErrCannotConfirmMilestone     = fmt.Errorf("cannot confirm milestone")
+	ErrCannotGetSupplierRating    = fmt.Errorf("cannot get supplier rating")
+	ErrCannotGetReputation        = fmt.Errorf("cannot get reputation")
+	ErrSupplierDebarred           = fmt.Errorf("supplier is debarred from bidding on this tender")
+	ErrDebarmentNotFound          = fmt.Errorf("debarment not found")
+	ErrDebarmentSubjectNotFound   = fmt.Errorf("debarred organization or employee not found")
+	ErrDebarmentLifted            = fmt.Errorf("debarment is already lifted")
+	ErrCannotCreateDebarment      = fmt.Errorf("cannot create debarment")
+	ErrCannotGetDebarments        = fmt.Errorf("cannot get debarments")
+	ErrCannotUpdateDebarment      = fmt.Errorf("cannot update debarment")
+	ErrConflictOfInterest         = fmt.Errorf("conflict of interest")
+	ErrDeclarationNotFound        = fmt.Errorf("declaration not found")
+	ErrDeclarationAlreadyExists   = fmt.Errorf("relationship is already declared")
+	ErrCannotDeclareRelationship  = fmt.Errorf("cannot declare relationship")
+	ErrCannotGetDeclarations      = fmt.Errorf("cannot get declarations")
+	ErrCannotDeleteDeclaration    = fmt.Errorf("cannot delete declaration")
+	ErrCannotGetConflicts         = fmt.Errorf("cannot get conflicts")
+	ErrApprovalPending            = fmt.Errorf("tender publication is already awaiting approval")
+	ErrBudgetNeedsApproval        = fmt.Errorf("new budget needs a sign-off the tender publication did not get")
+	ErrApprovalNotFound           = fmt.Errorf("tender has no pending approval")
+	ErrSelfApproval               = fmt.Errorf("the requester of a publication cannot approve it")
+	ErrAlreadyApproved            = fmt.Errorf("approver already acted on this approval")
+	ErrApprovalPolicyNotFound     = fmt.Errorf("approval policy not found")
+	ErrNotOrganizationResponsible = fmt.Errorf("user is not responsible for this organization")
+	ErrCannotCreateApprovalPolicy = fmt.Errorf("cannot create approval policy")
+	ErrCannotDeleteApprovalPolicy = fmt.Errorf("cannot delete approval policy")
+	ErrCannotDecideApproval       = fmt.Errorf("cannot decide approval")
+	ErrCannotGetApprovals         = fmt.Errorf("cannot get approvals")
+	ErrCloneScheduleRequired      = fmt.Errorf("a new submission deadline or auction schedule is required to clone this tender")
+	ErrTemplateNotFound           = fmt.Errorf("template not found")
+	ErrTemplateAlreadyExists      = fmt.Errorf("template with this name already exists")
+	ErrMissingPlaceholderValues   = fmt.Errorf("missing values for placeholders")
+	ErrCannotCreateTemplate       = fmt.Errorf("cannot create template")
+	ErrCannotGetTemplates         = fmt.Errorf("cannot get templates")
+	ErrCannotDeleteTemplate       = fmt.Errorf("cannot delete template")
+	ErrInvalidRecurrenceRule      = fmt.Errorf("invalid recurrence rule")
+	ErrRecurrenceEnded            = fmt.Errorf("recurrence rule has no occurrences left")
+	ErrSeriesDeadlineRequired     = fmt.Errorf("sealed tenders of a series need submission days")
+	ErrSeriesNotFound             = fmt.Errorf("series not found")
+	ErrSeriesNotActive            = fmt.Errorf("series is not active")
+	ErrSeriesNotPaused            = fmt.Errorf("series is not paused")
+	ErrCannotCreateSeries         = fmt.Errorf("cannot create series")
+	ErrCannotGetSeries            = fmt.Errorf("cannot get series")
+	ErrCannotUpdateSeries         = fmt.Errorf("cannot update series")
+	ErrCannotGetAuditEvents       = fmt.Errorf("cannot get audit events")
+	ErrChainNotFound              = fmt.Errorf("chain not found")
+	ErrCheckpointKeyMissing       = fmt.Errorf("checkpoint signing key is not configured")
+	ErrCannotVerifyChain          = fmt.Errorf("cannot verify chain")
+	ErrCannotGetCheckpoints       = fmt.Errorf("cannot get checkpoints")
+	ErrCannotCreateCheckpoint     = fmt.Errorf("cannot create checkpoint")
+	ErrInvalidPublicKey           = fmt.Errorf("invalid public key, Ed25519 or ECDSA P-256, P-384 or P-521 expected")
+	ErrKeyAlreadyExists           = fmt.Errorf("key is already registered")
+	ErrKeyNotFound                = fmt.Errorf("key not found")
+	ErrKeyRevoked                 = fmt.Errorf("key is revoked")
+	ErrInvalidSignature           = fmt.Errorf("signature does not match the bid")
+	ErrSignedVersionConflict      = fmt.Errorf("bid changed while it was being signed")
+	ErrBidVersionNotFound         = fmt.Errorf("bid version not found")
+	ErrCannotRegisterKey          = fmt.Errorf("cannot register key")
+	ErrCannotGetKeys              = fmt.Errorf("cannot get keys")
+	ErrCannotRevokeKey            = fmt.Errorf("cannot revoke key")
+	ErrCannotVerifySignature      = fmt.Errorf("cannot verify signature")
 )
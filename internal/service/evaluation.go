@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"math/big"
+	"sort"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type EvaluationService struct {
+	evaluationRepo repo.Evaluation
+	bidRepo        repo.Bid
+	tenderRepo     repo.Tender
+	conflicts      *ConflictChecker
+	opener         *BidOpener
+	audit          *Auditor
+	tx             repo.Transactor
+}
+
+func NewEvaluationService(evaluationRepo repo.Evaluation, bidRepo repo.Bid, tenderRepo repo.Tender, conflicts *ConflictChecker, opener *BidOpener, audit *Auditor, tx repo.Transactor) *EvaluationService {
+	return &EvaluationService{
+		evaluationRepo: evaluationRepo,
+		bidRepo:        bidRepo,
+		tenderRepo:     tenderRepo,
+		conflicts:      conflicts,
+		opener:         opener,
+		audit:          audit,
+		tx:             tx,
+	}
+}
+
+func newCriterionOutputs(criteria []*entity.Criterion) []*CriterionOutput {
+	output := make([]*CriterionOutput, 0, len(criteria))
+	for _, c := range criteria {
+		output = append(output, &CriterionOutput{
+			ID:     c.ID,
+			Name:   c.Name,
+			Weight: c.Weight,
+		})
+	}
+	return output
+}
+
+func (es *EvaluationService) GetCriteria(ctx context.Context, tenderID uuid.UUID) ([]*CriterionOutput, error) {
+	const op = "service - EvaluationService - GetCriteria"
+
+	criteria, err := es.evaluationRepo.GetCriteriaByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetCriteria
+	}
+
+	return newCriterionOutputs(criteria), nil
+}
+
+// ScoreBid stores the reviewer scores and recomputes the weighted total of the bid.
+func (es *EvaluationService) ScoreBid(ctx context.Context, input *ScoreBidInput) (*BidRankingOutput, error) {
+	const op = "service - EvaluationService - ScoreBid"
+
+	var result *BidRankingOutput
+	err := es.tx.WithinTx(ctx, func(ctx context.Context) error {
+		bid, err := es.bidRepo.GetBidByID(ctx, input.BidID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			return err
+		}
+		if bid, err = es.opener.openBid(ctx, bid); err != nil {
+			return err
+		}
+
+		conflicts, err := es.conflicts.screenReview(ctx, entity.ConflictStageReview, input.ReviewerID, bid)
+		if err != nil {
+			return err
+		}
+		if err = es.conflicts.record(ctx, conflicts, bid); err != nil {
+			return err
+		}
+
+		criteria, err := es.evaluationRepo.GetCriteriaByTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+		known := make(map[uuid.UUID]bool, len(criteria))
+		for _, c := range criteria {
+			known[c.ID] = true
+		}
+
+		for _, s := range input.Scores {
+			if !known[s.CriterionID] {
+				return ErrCriterionNotFound
+			}
+			_, err = es.evaluationRepo.UpsertBidScore(ctx, &entity.BidScore{
+				BidID:       bid.ID,
+				CriterionID: s.CriterionID,
+				ReviewerID:  input.ReviewerID,
+				Score:       s.Score,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		scores, err := es.evaluationRepo.GetScoresByTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		result = computeBidRanking(bid, criteria, scores)
+		bid, err = es.bidRepo.UpdateBidTotalScore(ctx, bid.ID, result.TotalScore)
+		if err != nil {
+			return err
+		}
+		result.Bid = newBidOutput(bid)
+		return es.audit.record(ctx, "evaluation.score", entity.AuditEvaluation, bid.ID, nil, map[string]any{
+			"ReviewerID": input.ReviewerID,
+			"Scores":     input.Scores,
+			"TotalScore": result.TotalScore,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrBidSealed) || errors.Is(err, ErrCriterionNotFound) ||
+			errors.Is(err, ErrConflictOfInterest) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotScoreBid
+	}
+
+	return result, nil
+}
+
+// GetTenderRanking ranks active bids by their weighted total, best first.
+func (es *EvaluationService) GetTenderRanking(ctx context.Context, tenderID uuid.UUID) (*TenderRankingOutput, error) {
+	const op = "service - EvaluationService - GetTenderRanking"
+
+	tender, err := es.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetRanking
+	}
+	if tender.IsSealed() {
+		return nil, ErrBidSealed
+	}
+
+	bids, err := es.bidRepo.GetAllBidsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetRanking
+	}
+
+	criteria, err := es.evaluationRepo.GetCriteriaByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetRanking
+	}
+
+	scores, err := es.evaluationRepo.GetScoresByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetRanking
+	}
+
+	output := &TenderRankingOutput{TenderID: tenderID, Complete: true}
+	for _, bid := range bids {
+		if !bid.IsActive() {
+			continue
+		}
+		ranking := computeBidRanking(bid, criteria, scores)
+		output.Complete = output.Complete && ranking.Complete
+		output.Bids = append(output.Bids, ranking)
+	}
+
+	sort.SliceStable(output.Bids, func(i, j int) bool {
+		return compareScores(output.Bids[i].TotalScore, output.Bids[j].TotalScore) > 0
+	})
+	for i, b := range output.Bids {
+		b.Rank = i + 1
+	}
+
+	return output, nil
+}
+
+// computeBidRanking averages the reviewer scores per criterion and sums them
+// weighted by the criterion weight. A bid is complete once every criterion has
+// at least one score.
+func computeBidRanking(bid *entity.Bid, criteria []*entity.Criterion, scores []*entity.BidScore) *BidRankingOutput {
+	sums := make(map[uuid.UUID]*big.Rat)
+	counts := make(map[uuid.UUID]int)
+	for _, s := range scores {
+		if s.BidID != bid.ID {
+			continue
+		}
+		value, ok := new(big.Rat).SetString(s.Score)
+		if !ok {
+			continue
+		}
+		if sums[s.CriterionID] == nil {
+			sums[s.CriterionID] = new(big.Rat)
+		}
+		sums[s.CriterionID].Add(sums[s.CriterionID], value)
+		counts[s.CriterionID]++
+	}
+
+	output := &BidRankingOutput{Bid: newBidOutput(bid), Complete: len(criteria) > 0}
+	total := new(big.Rat)
+	scored := false
+	for _, c := range criteria {
+		item := &CriterionScoreOutput{
+			CriterionID:   c.ID,
+			Name:          c.Name,
+			Weight:        c.Weight,
+			ReviewerCount: counts[c.ID],
+		}
+
+		if counts[c.ID] == 0 {
+			output.Complete = false
+		} else {
+			avg := new(big.Rat).Quo(sums[c.ID], big.NewRat(int64(counts[c.ID]), 1))
+			item.AverageScore = optional(avg.FloatString(2))
+
+			weight, _ := new(big.Rat).SetString(c.Weight)
+			total.Add(total, new(big.Rat).Mul(avg, new(big.Rat).Quo(weight, big.NewRat(100, 1))))
+			scored = true
+		}
+
+		output.Criteria = append(output.Criteria, item)
+	}
+
+	if scored {
+		output.TotalScore = optional(total.FloatString(2))
+	}
+	return output
+}
+
+// compareScores orders decimal scores, treating a missing score as the lowest.
+func compareScores(a, b *string) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	ra, _ := new(big.Rat).SetString(*a)
+	rb, _ := new(big.Rat).SetString(*b)
+	return ra.Cmp(rb)
+}
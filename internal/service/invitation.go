@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type InvitationService struct {
+	invitationRepo   repo.Invitation
+	tenderRepo       repo.Tender
+	organizationRepo repo.Organization
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewInvitationService(invitationRepo repo.Invitation, tenderRepo repo.Tender, organizationRepo repo.Organization, audit *Auditor, tx repo.Transactor) *InvitationService {
+	return &InvitationService{
+		invitationRepo:   invitationRepo,
+		tenderRepo:       tenderRepo,
+		organizationRepo: organizationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+// isInvited reports whether employeeID may take part in the tender: anybody
+// may in an open tender, only invited bidders in an invitation-only one.
+func isInvited(ctx context.Context, invitationRepo repo.Invitation, tender *entity.Tender, employeeID uuid.UUID) (bool, error) {
+	if !tender.IsInvitationOnly() {
+		return true, nil
+	}
+	return invitationRepo.IsInvited(ctx, tender.ID, employeeID)
+}
+
+func newInvitationOutput(i *entity.Invitation) *InvitationOutput {
+	return &InvitationOutput{
+		ID:             i.ID,
+		TenderID:       i.TenderID,
+		OrganizationID: i.OrganizationID,
+		EmployeeID:     i.EmployeeID,
+		CreatedAt:      i.CreatedAt,
+	}
+}
+
+// CreateInvitations adds organizations and employees to the invitation list
+// of an invitation-only tender. Either all of them are invited or none.
+func (is *InvitationService) CreateInvitations(ctx context.Context, input *CreateInvitationsInput) ([]*InvitationOutput, error) {
+	const op = "service - InvitationService - CreateInvitations"
+
+	var output []*InvitationOutput
+	err := is.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := is.tenderRepo.GetTenderByID(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		if !tender.IsInvitationOnly() {
+			return ErrTenderNotInvitationOnly
+		}
+
+		invitations := make([]*entity.Invitation, 0, len(input.OrganizationIDs)+len(input.EmployeeIDs))
+		for _, id := range input.OrganizationIDs {
+			invitations = append(invitations, &entity.Invitation{TenderID: tender.ID, OrganizationID: &id})
+		}
+		for _, id := range input.EmployeeIDs {
+			invitations = append(invitations, &entity.Invitation{TenderID: tender.ID, EmployeeID: &id})
+		}
+
+		output = make([]*InvitationOutput, 0, len(invitations))
+		for _, invitation := range invitations {
+			created, err := is.invitationRepo.CreateInvitation(ctx, invitation)
+			if err != nil {
+				return err
+			}
+			output = append(output, newInvitationOutput(created))
+			if err = is.audit.record(ctx, "invitation.create", entity.AuditInvitation, created.ID, nil, output[len(output)-1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrTenderNotInvitationOnly):
+			return nil, err
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrInvitationAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrInviteeNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotInvite
+	}
+
+	return output, nil
+}
+
+func (is *InvitationService) GetInvitations(ctx context.Context, tenderID uuid.UUID) ([]*InvitationOutput, error) {
+	const op = "service - InvitationService - GetInvitations"
+
+	invitations, err := is.invitationRepo.GetInvitationsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetInvitations
+	}
+
+	output := make([]*InvitationOutput, 0, len(invitations))
+	for _, i := range invitations {
+		output = append(output, newInvitationOutput(i))
+	}
+	return output, nil
+}
+
+// DeleteInvitation withdraws an invitation. Bids already submitted stay.
+func (is *InvitationService) DeleteInvitation(ctx context.Context, tenderID, invitationID uuid.UUID) error {
+	const op = "service - InvitationService - DeleteInvitation"
+
+	err := is.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := is.invitationRepo.DeleteInvitation(ctx, tenderID, invitationID); err != nil {
+			return err
+		}
+		return is.audit.record(ctx, "invitation.delete", entity.AuditInvitation, invitationID, nil, nil)
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrInvitationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteInvitation
+	}
+
+	return nil
+}
+
+// CanAccessTender reports whether the employee may see an invitation-only
+// tender: its organization responsibles and the invited bidders may.
+func (is *InvitationService) CanAccessTender(ctx context.Context, tenderID, employeeID uuid.UUID) (bool, error) {
+	const op = "service - InvitationService - CanAccessTender"
+
+	tender, err := is.tenderRepo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return false, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return false, ErrCannotGetInvitations
+	}
+
+	invited, err := isInvited(ctx, is.invitationRepo, tender, employeeID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return false, ErrCannotGetInvitations
+	}
+	if invited {
+		return true, nil
+	}
+
+	responsible, err := is.organizationRepo.IsResponsibleForTender(ctx, employeeID, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return false, ErrCannotGetInvitations
+	}
+	return responsible, nil
+}
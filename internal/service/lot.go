@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"slices"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type LotService struct {
+	lotRepo          repo.Lot
+	bidRepo          repo.Bid
+	tenderRepo       repo.Tender
+	evaluationRepo   repo.Evaluation
+	contractRepo     repo.Contract
+	organizationRepo repo.Organization
+	conflicts        *ConflictChecker
+	opener           *BidOpener
+	audit            *Auditor
+	events           *EventPublisher
+	tx               repo.Transactor
+}
+
+func NewLotService(lotRepo repo.Lot, bidRepo repo.Bid, tenderRepo repo.Tender, evaluationRepo repo.Evaluation, contractRepo repo.Contract, organizationRepo repo.Organization, conflicts *ConflictChecker, opener *BidOpener, audit *Auditor, events *EventPublisher, tx repo.Transactor) *LotService {
+	return &LotService{
+		lotRepo:          lotRepo,
+		bidRepo:          bidRepo,
+		tenderRepo:       tenderRepo,
+		evaluationRepo:   evaluationRepo,
+		contractRepo:     contractRepo,
+		organizationRepo: organizationRepo,
+		conflicts:        conflicts,
+		opener:           opener,
+		audit:            audit,
+		events:           events,
+		tx:               tx,
+	}
+}
+
+func newLotOutput(lot *entity.Lot) *LotOutput {
+	return &LotOutput{
+		ID:          lot.ID,
+		TenderID:    lot.TenderID,
+		Name:        lot.Name,
+		Description: lot.Description,
+		Budget:      lot.Budget,
+		Status:      lot.Status,
+		WinnerBidID: lot.WinnerBidID,
+		DecidedAt:   lot.DecidedAt,
+		CreatedAt:   lot.CreatedAt,
+	}
+}
+
+func newLotOutputs(lots []*entity.Lot) []*LotOutput {
+	output := make([]*LotOutput, 0, len(lots))
+	for _, lot := range lots {
+		output = append(output, newLotOutput(lot))
+	}
+	return output
+}
+
+func (ls *LotService) GetLots(ctx context.Context, tenderID uuid.UUID) ([]*LotOutput, error) {
+	const op = "service - LotService - GetLots"
+
+	lots, err := ls.lotRepo.GetLotsByTender(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetLots
+	}
+
+	return newLotOutputs(lots), nil
+}
+
+// DecideLot awards a lot to one of the bids targeting it or cancels it. An
+// award makes the contract of the lot. The tender is closed once none of its
+// lots is open anymore.
+func (ls *LotService) DecideLot(ctx context.Context, input *DecideLotInput) (*LotOutput, error) {
+	const op = "service - LotService - DecideLot"
+
+	var result *LotOutput
+	err := ls.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if input.Decision == entity.LotStatusAwarded {
+			tender, err := ls.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return ErrLotNotFound
+				}
+				return err
+			}
+			if err = checkAward(ctx, ls.contractRepo, tender, true); err != nil {
+				return err
+			}
+		}
+
+		lot, err := ls.lotRepo.GetLotForUpdate(ctx, input.LotID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrLotNotFound
+			}
+			return err
+		}
+		if lot.TenderID != input.TenderID {
+			return ErrLotNotFound
+		}
+		if lot.Status != entity.LotStatusOpen {
+			return ErrLotAlreadyDecided
+		}
+
+		var winnerBidID *uuid.UUID
+		if input.Decision == entity.LotStatusAwarded {
+			bid, err := ls.bidRepo.GetBidForUpdate(ctx, input.BidID)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return ErrBidNotFound
+				}
+				return err
+			}
+			if bid, err = ls.opener.openBid(ctx, bid); err != nil {
+				return err
+			}
+			if err = approvable(bid); err != nil {
+				return err
+			}
+
+			conflicts, err := ls.conflicts.screenReview(ctx, entity.ConflictStageDecision, input.DeciderID, bid)
+			if err != nil {
+				return err
+			}
+			if err = ls.conflicts.record(ctx, conflicts, bid); err != nil {
+				return err
+			}
+
+			lotIDs, err := ls.lotRepo.GetBidLotIDs(ctx, bid.ID)
+			if err != nil {
+				return err
+			}
+			if bid.TenderID != lot.TenderID || !slices.Contains(lotIDs, lot.ID) {
+				return ErrBidNotForLot
+			}
+
+			missing, err := ls.evaluationRepo.CountMissingScores(ctx, lot.TenderID)
+			if err != nil {
+				return err
+			}
+			if missing > 0 {
+				return ErrScoringIncomplete
+			}
+
+			if err = awardContract(ctx, ls.contractRepo, ls.organizationRepo, ls.tenderRepo, ls.audit, ls.events, bid, &lot.ID); err != nil {
+				return err
+			}
+			winnerBidID = &bid.ID
+		}
+
+		previous := newLotOutput(lot)
+		lot, err = ls.lotRepo.UpdateLotDecision(ctx, lot.ID, input.Decision, winnerBidID)
+		if err != nil {
+			return err
+		}
+		result = newLotOutput(lot)
+		if err = ls.audit.record(ctx, "lot.decide", entity.AuditLot, lot.ID, previous, result); err != nil {
+			return err
+		}
+		if err = ls.events.publish(ctx, entity.EventLotDecided, entity.AggregateLot, lot.ID, result); err != nil {
+			return err
+		}
+
+		open, err := ls.lotRepo.CountOpenLots(ctx, lot.TenderID)
+		if err != nil {
+			return err
+		}
+		if open == 0 {
+			closed, err := ls.tenderRepo.UpdateTenderStatus(ctx, lot.TenderID, entity.TenderStatusClosed)
+			if err != nil {
+				return err
+			}
+			if err = ls.audit.record(ctx, "tender.close", entity.AuditTender, lot.TenderID, nil, map[string]any{"Status": entity.TenderStatusClosed}); err != nil {
+				return err
+			}
+			if err = ls.events.publish(ctx, entity.EventTenderClosed, entity.AggregateTender, closed.ID, newTenderOutput(closed)); err != nil {
+				return err
+			}
+			sl.Info(op, sl.Any("tender_id", lot.TenderID), sl.Any("status", entity.TenderStatusClosed))
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrLotNotFound), errors.Is(err, ErrLotAlreadyDecided), errors.Is(err, ErrBidNotFound),
+			errors.Is(err, ErrBidSealed), errors.Is(err, ErrBidNotForLot), errors.Is(err, ErrScoringIncomplete),
+			errors.Is(err, ErrBidNeedsAcknowledgement), errors.Is(err, ErrBidCanceled), errors.Is(err, ErrConflictOfInterest),
+			errors.Is(err, ErrBidWithdrawn), errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrTenderNotPublished):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotDecideLot
+	}
+
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type MessageService struct {
+	messageRepo      repo.Message
+	bidRepo          repo.Bid
+	organizationRepo repo.Organization
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewMessageService(messageRepo repo.Message, bidRepo repo.Bid, organizationRepo repo.Organization, audit *Auditor, tx repo.Transactor) *MessageService {
+	return &MessageService{
+		messageRepo:      messageRepo,
+		bidRepo:          bidRepo,
+		organizationRepo: organizationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newMessageOutput(m *entity.Message, bid *entity.Bid) *MessageOutput {
+	return &MessageOutput{
+		ID:         m.ID,
+		BidID:      m.BidID,
+		SenderID:   m.SenderID,
+		FromAuthor: m.SenderID == bid.AuthorID,
+		Body:       m.Body,
+		CreatedAt:  m.CreatedAt,
+		ReadAt:     m.ReadAt,
+	}
+}
+
+// threadBid returns the bid of the thread if userID takes part in it: the bid
+// author on one side, the responsibles of the tender organization on the other.
+func (ms *MessageService) threadBid(ctx context.Context, bidID, userID uuid.UUID) (*entity.Bid, error) {
+	bid, err := ms.bidRepo.GetBidByID(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+		return nil, err
+	}
+	if bid.AuthorID == userID {
+		return bid, nil
+	}
+
+	responsible, err := ms.organizationRepo.IsResponsibleForTender(ctx, userID, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !responsible {
+		return nil, ErrNotInThread
+	}
+	return bid, nil
+}
+
+func (ms *MessageService) SendMessage(ctx context.Context, input *SendMessageInput) (*MessageOutput, error) {
+	const op = "service - MessageService - SendMessage"
+
+	bid, err := ms.threadBid(ctx, input.BidID, input.SenderID)
+	if err != nil {
+		if errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrNotInThread) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotSendMessage
+	}
+
+	var message *entity.Message
+	err = ms.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		message, err = ms.messageRepo.CreateMessage(ctx, &entity.Message{
+			BidID:    bid.ID,
+			SenderID: input.SenderID,
+			Body:     input.Body,
+		})
+		if err != nil {
+			return err
+		}
+		return ms.audit.record(ctx, "message.send", entity.AuditMessage, message.ID, nil, newMessageOutput(message, bid))
+	})
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotSendMessage
+	}
+
+	return newMessageOutput(message, bid), nil
+}
+
+// GetMessages returns a page of the thread, newest first. The messages of the
+// other side on that page are marked as read by the reader.
+func (ms *MessageService) GetMessages(ctx context.Context, input *GetMessagesInput) ([]*MessageOutput, error) {
+	const op = "service - MessageService - GetMessages"
+
+	bid, err := ms.threadBid(ctx, input.BidID, input.ReaderID)
+	if err != nil {
+		if errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrNotInThread) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetMessages
+	}
+
+	messages, err := ms.messageRepo.GetMessagesByBid(ctx, input.Limit, input.Offset, bid.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetMessages
+	}
+
+	readerIsAuthor := input.ReaderID == bid.AuthorID
+	var unread []*entity.Message
+	for _, m := range messages {
+		fromAuthor := m.SenderID == bid.AuthorID
+		if m.ReadAt == nil && fromAuthor != readerIsAuthor {
+			unread = append(unread, m)
+		}
+	}
+
+	if len(unread) > 0 {
+		ids := make([]uuid.UUID, 0, len(unread))
+		for _, m := range unread {
+			ids = append(ids, m.ID)
+		}
+
+		readAt, err := ms.messageRepo.MarkMessagesRead(ctx, ids)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotGetMessages
+		}
+		for _, m := range unread {
+			m.ReadAt = &readAt
+		}
+	}
+
+	output := make([]*MessageOutput, 0, len(messages))
+	for _, m := range messages {
+		output = append(output, newMessageOutput(m, bid))
+	}
+	return output, nil
+}
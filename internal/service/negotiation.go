@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sl "log/slog"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type NegotiationService struct {
+	negotiationRepo  repo.Negotiation
+	bidRepo          repo.Bid
+	tenderRepo       repo.Tender
+	notificationRepo repo.Notification
+	opener           *BidOpener
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewNegotiationService(negotiationRepo repo.Negotiation, bidRepo repo.Bid, tenderRepo repo.Tender, notificationRepo repo.Notification, opener *BidOpener, audit *Auditor, tx repo.Transactor) *NegotiationService {
+	return &NegotiationService{
+		negotiationRepo:  negotiationRepo,
+		bidRepo:          bidRepo,
+		tenderRepo:       tenderRepo,
+		notificationRepo: notificationRepo,
+		opener:           opener,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newNegotiationRoundOutput(n *entity.NegotiationRound) *NegotiationRoundOutput {
+	return &NegotiationRoundOutput{
+		ID:                 n.ID,
+		BidID:              n.BidID,
+		Round:              n.Round,
+		BidVersion:         n.BidVersion,
+		ProposedBy:         n.ProposedBy,
+		TargetPrice:        n.TargetPrice,
+		Comment:            n.Comment,
+		Status:             n.Status,
+		ResponseComment:    n.ResponseComment,
+		ResponseBidVersion: n.ResponseBidVersion,
+		CreatedAt:          n.CreatedAt,
+		RespondedAt:        n.RespondedAt,
+	}
+}
+
+// negotiableBid locks the bid and makes sure that its tender can still be
+// negotiated.
+func (ns *NegotiationService) negotiableBid(ctx context.Context, bidID uuid.UUID) (*entity.Bid, *entity.Tender, error) {
+	bid, err := ns.bidRepo.GetBidForUpdate(ctx, bidID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil, ErrBidNotFound
+		}
+		return nil, nil, err
+	}
+	switch bid.Status {
+	case entity.BidStatusCanceled:
+		return nil, nil, ErrBidCanceled
+	case entity.BidStatusWithdrawn:
+		return nil, nil, ErrBidWithdrawn
+	}
+	if bid, err = ns.opener.openBid(ctx, bid); err != nil {
+		return nil, nil, err
+	}
+
+	tender, err := ns.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tender.IsCanceled() || tender.Status == entity.TenderStatusClosed {
+		return nil, nil, ErrNegotiationClosed
+	}
+	if tender.IsAuction() {
+		return nil, nil, ErrNegotiationNotAvailable
+	}
+	return bid, tender, nil
+}
+
+// ProposeCounterOffer opens the next negotiation round of the bid on its
+// current version and tells the bidder about it.
+func (ns *NegotiationService) ProposeCounterOffer(ctx context.Context, input *ProposeCounterOfferInput) (*NegotiationRoundOutput, error) {
+	const op = "service - NegotiationService - ProposeCounterOffer"
+
+	var round *entity.NegotiationRound
+	err := ns.tx.WithinTx(ctx, func(ctx context.Context) error {
+		bid, tender, err := ns.negotiableBid(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+
+		rounds, err := ns.negotiationRepo.GetRoundsByBid(ctx, bid.ID)
+		if err != nil {
+			return err
+		}
+		if len(rounds) > 0 && rounds[len(rounds)-1].IsOpen() {
+			return ErrNegotiationRoundOpen
+		}
+
+		round, err = ns.negotiationRepo.CreateRound(ctx, &entity.NegotiationRound{
+			BidID:       bid.ID,
+			Round:       len(rounds) + 1,
+			BidVersion:  bid.Version,
+			ProposedBy:  input.ResponsibleID,
+			TargetPrice: optional(input.TargetPrice),
+			Comment:     input.Comment,
+		})
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrAlreadyExists) {
+				return ErrNegotiationRoundOpen
+			}
+			return err
+		}
+		if err = ns.audit.record(ctx, "negotiation.propose", entity.AuditNegotiation, round.ID, nil, newNegotiationRoundOutput(round)); err != nil {
+			return err
+		}
+
+		_, err = ns.notificationRepo.CreateNotification(ctx, &entity.Notification{
+			RecipientID: bid.AuthorID,
+			Type:        entity.NotificationCounterOffer,
+			TenderID:    &tender.ID,
+			BidID:       &bid.ID,
+			Message:     fmt.Sprintf("Tender %q: counter-offer on your bid (round %d): %s", tender.Name, round.Round, input.Comment),
+		})
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBidNotFound), errors.Is(err, ErrBidCanceled), errors.Is(err, ErrBidWithdrawn),
+			errors.Is(err, ErrBidSealed), errors.Is(err, ErrNegotiationClosed), errors.Is(err, ErrNegotiationNotAvailable),
+			errors.Is(err, ErrNegotiationRoundOpen):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotProposeCounter
+	}
+
+	return newNegotiationRoundOutput(round), nil
+}
+
+// RespondCounterOffer closes the open round of the bid. Accepting applies the
+// target price, revising applies the bidder's own changes; both make a new bid
+// version that the round records.
+func (ns *NegotiationService) RespondCounterOffer(ctx context.Context, input *RespondCounterOfferInput) (*NegotiationRoundOutput, error) {
+	const op = "service - NegotiationService - RespondCounterOffer"
+
+	var round *entity.NegotiationRound
+	err := ns.tx.WithinTx(ctx, func(ctx context.Context) error {
+		bid, tender, err := ns.negotiableBid(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+		if bid.AuthorID != input.AuthorID {
+			return ErrNotBidAuthor
+		}
+
+		open, err := ns.negotiationRepo.GetOpenRoundForUpdate(ctx, bid.ID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrNoOpenNegotiationRound
+			}
+			return err
+		}
+
+		updates := make(map[string]interface{})
+		switch input.Response {
+		case entity.NegotiationAccepted:
+			if open.TargetPrice != nil {
+				updates["price"] = *open.TargetPrice
+			}
+		case entity.NegotiationRevised:
+			if input.Name != "" {
+				updates["name"] = input.Name
+			}
+			if input.Description != "" {
+				updates["description"] = input.Description
+			}
+			if input.Price != "" {
+				updates["price"] = input.Price
+			}
+			if input.DeliveryDays > 0 {
+				updates["delivery_days"] = input.DeliveryDays
+			}
+			if input.ValidityDays > 0 {
+				updates["validity_days"] = input.ValidityDays
+			}
+		}
+
+		version := bid.Version
+		if len(updates) > 0 {
+			if bid.NeedsAck {
+				updates["needs_ack"] = false
+			}
+			updated, err := ns.bidRepo.UpdateBid(ctx, bid.ID, updates)
+			if err != nil {
+				return err
+			}
+			if err = ns.audit.recordSubmission(ctx, updated); err != nil {
+				return err
+			}
+			version = updated.Version
+		}
+
+		previous := newNegotiationRoundOutput(open)
+		open.Status = input.Response
+		open.ResponseComment = optional(input.Comment)
+		open.ResponseBidVersion = &version
+		if round, err = ns.negotiationRepo.CloseRound(ctx, open); err != nil {
+			return err
+		}
+		if err = ns.audit.record(ctx, "negotiation.respond", entity.AuditNegotiation, round.ID, previous, newNegotiationRoundOutput(round)); err != nil {
+			return err
+		}
+
+		_, err = ns.notificationRepo.CreateNotification(ctx, &entity.Notification{
+			RecipientID: open.ProposedBy,
+			Type:        entity.NotificationCounterReply,
+			TenderID:    &tender.ID,
+			BidID:       &bid.ID,
+			Message:     fmt.Sprintf("Tender %q: the bidder answered counter-offer round %d: %s", tender.Name, round.Round, round.Status),
+		})
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBidNotFound), errors.Is(err, ErrBidCanceled), errors.Is(err, ErrBidWithdrawn),
+			errors.Is(err, ErrBidSealed), errors.Is(err, ErrNegotiationClosed), errors.Is(err, ErrNegotiationNotAvailable),
+			errors.Is(err, ErrNotBidAuthor), errors.Is(err, ErrNoOpenNegotiationRound):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotRespondCounter
+	}
+
+	return newNegotiationRoundOutput(round), nil
+}
+
+func (ns *NegotiationService) GetNegotiationRounds(ctx context.Context, bidID uuid.UUID) ([]*NegotiationRoundOutput, error) {
+	const op = "service - NegotiationService - GetNegotiationRounds"
+
+	rounds, err := ns.negotiationRepo.GetRoundsByBid(ctx, bidID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetNegotiation
+	}
+
+	output := make([]*NegotiationRoundOutput, 0, len(rounds))
+	for _, r := range rounds {
+		output = append(output, newNegotiationRoundOutput(r))
+	}
+	return output, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type NotificationService struct {
+	notificationRepo repo.Notification
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewNotificationService(notificationRepo repo.Notification, audit *Auditor, tx repo.Transactor) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newNotificationOutput(n *entity.Notification) *NotificationOutput {
+	return &NotificationOutput{
+		ID:        n.ID,
+		Type:      n.Type,
+		TenderID:  n.TenderID,
+		BidID:     n.BidID,
+		Message:   n.Message,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+}
+
+func (ns *NotificationService) GetNotifications(ctx context.Context, input *GetNotificationsInput) ([]*NotificationOutput, error) {
+	const op = "service - NotificationService - GetNotifications"
+
+	notifications, err := ns.notificationRepo.GetNotifications(ctx, input.Limit, input.Offset, input.RecipientID, input.UnreadOnly)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetNotifications
+	}
+
+	output := make([]*NotificationOutput, 0, len(notifications))
+	for _, n := range notifications {
+		output = append(output, newNotificationOutput(n))
+	}
+	return output, nil
+}
+
+func (ns *NotificationService) MarkNotificationRead(ctx context.Context, notificationID, recipientID uuid.UUID) (*NotificationOutput, error) {
+	const op = "service - NotificationService - MarkNotificationRead"
+
+	var notification *entity.Notification
+	err := ns.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		notification, err = ns.notificationRepo.MarkNotificationRead(ctx, notificationID, recipientID)
+		if err != nil {
+			return err
+		}
+		return ns.audit.record(ctx, "notification.read", entity.AuditNotification, notification.ID, nil, newNotificationOutput(notification))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateNotification
+	}
+
+	return newNotificationOutput(notification), nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/sealer"
+	"github.com/google/uuid"
+)
+
+// BidOpener reveals the bids of sealed tenders once their submission
+// deadline has passed. Tenders are opened by Run shortly after their
+// deadline, and by the paths that act on the bid contents, such as
+// decisions and scoring, when they get there first.
+type BidOpener struct {
+	bidRepo    repo.Bid
+	tenderRepo repo.Tender
+	audit      *Auditor
+	tx         repo.Transactor
+	sealer     *sealer.Sealer
+}
+
+func NewBidOpener(bidRepo repo.Bid, tenderRepo repo.Tender, audit *Auditor, tx repo.Transactor, sealer *sealer.Sealer) *BidOpener {
+	return &BidOpener{
+		bidRepo:    bidRepo,
+		tenderRepo: tenderRepo,
+		audit:      audit,
+		tx:         tx,
+		sealer:     sealer,
+	}
+}
+
+// unsealBid decrypts the sealed contents of a bid.
+func unsealBid(s *sealer.Sealer, bid *entity.Bid) (sealedBidContent, error) {
+	var content sealedBidContent
+	if s == nil {
+		return content, errors.New("sealer is not configured")
+	}
+
+	plaintext, err := s.Open(bid.SealedPayload)
+	if err != nil {
+		return content, err
+	}
+	if err = json.Unmarshal(plaintext, &content); err != nil {
+		return content, err
+	}
+	return content, nil
+}
+
+// Run opens the sealed tenders whose deadline passed each period until ctx
+// is done.
+func (bo *BidOpener) Run(ctx context.Context, period time.Duration) {
+	const op = "service - BidOpener - Run"
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if err := bo.OpenDue(ctx, time.Now().UTC()); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// OpenDue opens every sealed tender whose submission deadline passed at
+// now. A tender whose bids cannot be revealed is logged and left sealed for
+// the next run, without holding back the others.
+func (bo *BidOpener) OpenDue(ctx context.Context, now time.Time) error {
+	const op = "service - BidOpener - OpenDue"
+
+	var failed []uuid.UUID
+	for ctx.Err() == nil {
+		var tender *entity.Tender
+		err := bo.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			tender, err = bo.tenderRepo.GetDueSealedTenderForUpdate(ctx, now, failed)
+			if err != nil {
+				return err
+			}
+			return bo.reveal(ctx, tender)
+		})
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			if tender == nil {
+				return err
+			}
+			sl.Warn(op, sl.Any("tender_id", tender.ID), sl.Any("error", err.Error()))
+			failed = append(failed, tender.ID)
+		}
+	}
+	return nil
+}
+
+// open opens the tender when it is still sealed and its deadline passed.
+func (bo *BidOpener) open(ctx context.Context, tenderID uuid.UUID) error {
+	return bo.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := bo.tenderRepo.GetTenderForUpdate(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		// Another request or the job may have opened the tender while we
+		// waited for the lock.
+		if !tender.IsSealed() || !tender.SubmissionClosed(time.Now().UTC()) {
+			return nil
+		}
+		return bo.reveal(ctx, tender)
+	})
+}
+
+// openBid returns the bid with its contents revealed, opening its tender
+// first when the deadline passed before the job got to it. A bid whose
+// deadline has not passed yet is ErrBidSealed.
+func (bo *BidOpener) openBid(ctx context.Context, bid *entity.Bid) (*entity.Bid, error) {
+	if !bid.IsSealed() {
+		return bid, nil
+	}
+
+	tender, err := bo.tenderRepo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.SubmissionClosed(time.Now().UTC()) {
+		return nil, ErrBidSealed
+	}
+	if err = bo.open(ctx, tender.ID); err != nil {
+		return nil, err
+	}
+
+	opened, err := bo.bidRepo.GetBidByID(ctx, bid.ID)
+	if err != nil {
+		return nil, err
+	}
+	if opened.IsSealed() {
+		return nil, ErrBidSealed
+	}
+	return opened, nil
+}
+
+// reveal decrypts every sealed bid of the locked tender and records the
+// opening.
+func (bo *BidOpener) reveal(ctx context.Context, tender *entity.Tender) error {
+	const op = "service - BidOpener - reveal"
+
+	bids, err := bo.bidRepo.GetAllBidsByTender(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, bid := range bids {
+		if !bid.IsSealed() {
+			continue
+		}
+
+		content, err := unsealBid(bo.sealer, bid)
+		if err != nil {
+			return fmt.Errorf("unseal bid %s: %w", bid.ID, err)
+		}
+
+		_, err = bo.bidRepo.RevealBid(ctx, bid.ID, map[string]interface{}{
+			"name":        content.Name,
+			"description": content.Description,
+			"price":       optional(content.Price),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	opening, err := bo.tenderRepo.CreateTenderOpening(ctx, tender.ID, len(bids))
+	if err != nil {
+		return err
+	}
+
+	sl.Info(op, sl.Any("tender_id", tender.ID), sl.Any("bid_count", opening.BidCount), sl.Any("opened_at", opening.OpenedAt))
+	return bo.audit.record(ctx, "tender.open_bids", entity.AuditTender, tender.ID, nil, map[string]any{
+		"BidCount": opening.BidCount,
+		"OpenedAt": opening.OpenedAt,
+	})
+}
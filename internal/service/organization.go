@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	sl "log/slog"
 
 	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
 	"github.com/google/uuid"
 )
 
@@ -38,3 +40,16 @@ func (os *OrganizationService) GetOrganizationResponsible(ctx context.Context, i
 func (os *OrganizationService) IsResponsibleForTender(ctx context.Context, userID uuid.UUID, tenderID uuid.UUID) (bool, error) {
 	return os.organizationRepo.IsResponsibleForTender(ctx, userID, tenderID)
 }
+
+// checkOrganizationResponsible makes sure the employee is a responsible of
+// the organization, who manages its settings.
+func checkOrganizationResponsible(ctx context.Context, organizationRepo repo.Organization, organizationID, employeeID uuid.UUID) error {
+	_, err := organizationRepo.GetOrganizationResponsible(ctx, organizationID, employeeID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrNotOrganizationResponsible
+		}
+		return err
+	}
+	return nil
+}
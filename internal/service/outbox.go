@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	sl "log/slog"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"git.codenrock.com/tender/pkg/eventsink"
+	"github.com/google/uuid"
+)
+
+// Delays between the delivery attempts of an event: doubling from the first
+// retry delay up to the longest one. Events are retried until delivered.
+const (
+	relayFirstRetryDelay = 5 * time.Second
+	relayMaxRetryDelay   = time.Hour
+)
+
+// maxRelayErrorLength bounds the delivery error kept with an event.
+const maxRelayErrorLength = 1000
+
+// EventPublisher writes the domain events to the outbox. Its methods are
+// meant to run in the transaction of the change an event describes, so that
+// the event is stored exactly when the change is committed.
+type EventPublisher struct {
+	outboxRepo repo.Outbox
+}
+
+func NewEventPublisher(outboxRepo repo.Outbox) *EventPublisher {
+	return &EventPublisher{
+		outboxRepo: outboxRepo,
+	}
+}
+
+// publish stores an event about the aggregate with payload as its JSON
+// body, usually the state of the aggregate after the change.
+func (p *EventPublisher) publish(ctx context.Context, eventType, aggregateType string, aggregateID uuid.UUID, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = p.outboxRepo.CreateEvent(ctx, &entity.OutboxEvent{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       body,
+		OccurredAt:    time.Now().UTC(),
+	})
+	return err
+}
+
+// OutboxService relays the events of the outbox to a sink, at least once
+// and in order for each aggregate. Several relays may run at once.
+type OutboxService struct {
+	outboxRepo repo.Outbox
+	tx         repo.Transactor
+	sink       eventsink.Sink
+}
+
+func NewOutboxService(outboxRepo repo.Outbox, tx repo.Transactor, sink eventsink.Sink) *OutboxService {
+	return &OutboxService{
+		outboxRepo: outboxRepo,
+		tx:         tx,
+		sink:       sink,
+	}
+}
+
+// Run relays the due events each period until ctx is done.
+func (ob *OutboxService) Run(ctx context.Context, period time.Duration) {
+	const op = "service - OutboxService - Run"
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if err := ob.RelayDue(ctx, time.Now().UTC()); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayDue delivers the events due at now, one transaction each. An event is
+// marked delivered in the transaction it is locked in, so an event whose
+// delivery is not recorded, because the relay stopped in between, is
+// delivered again. A failed delivery is retried later with a growing delay.
+func (ob *OutboxService) RelayDue(ctx context.Context, now time.Time) error {
+	const op = "service - OutboxService - RelayDue"
+
+	for ctx.Err() == nil {
+		err := ob.tx.WithinTx(ctx, func(ctx context.Context) error {
+			event, err := ob.outboxRepo.GetDueEventForUpdate(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			if err = ob.sink.Deliver(ctx, newSinkEvent(event)); err != nil {
+				retryAt := time.Now().UTC().Add(retryDelay(event.Attempts + 1))
+				sl.Warn(op, sl.Any("event_id", event.ID), sl.Any("type", event.Type), sl.Any("attempts", event.Attempts+1),
+					sl.Any("retry_at", retryAt), sl.Any("error", err.Error()))
+				return ob.outboxRepo.MarkFailed(ctx, event.ID, retryAt, truncateError(err.Error()))
+			}
+			return ob.outboxRepo.MarkDelivered(ctx, event.ID, time.Now().UTC())
+		})
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newSinkEvent(e *entity.OutboxEvent) eventsink.Event {
+	return eventsink.Event{
+		ID:            e.ID.String(),
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID.String(),
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt,
+	}
+}
+
+// retryDelay returns the delay before the attempt following the given
+// number of failed ones.
+func retryDelay(failures int) time.Duration {
+	delay := relayFirstRetryDelay
+	for i := 1; i < failures && delay < relayMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, relayMaxRetryDelay)
+}
+
+func truncateError(s string) string {
+	if len(s) <= maxRelayErrorLength {
+		return s
+	}
+	return strings.ToValidUTF8(s[:maxRelayErrorLength], "")
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type QuestionService struct {
+	questionRepo     repo.Question
+	tenderRepo       repo.Tender
+	organizationRepo repo.Organization
+	invitationRepo   repo.Invitation
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewQuestionService(questionRepo repo.Question, tenderRepo repo.Tender, organizationRepo repo.Organization, invitationRepo repo.Invitation, audit *Auditor, tx repo.Transactor) *QuestionService {
+	return &QuestionService{
+		questionRepo:     questionRepo,
+		tenderRepo:       tenderRepo,
+		organizationRepo: organizationRepo,
+		invitationRepo:   invitationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+// newQuestionOutput hides the asker unless showAuthor is set, so that public
+// answers do not reveal who is interested in the tender.
+func newQuestionOutput(q *entity.Question, showAuthor bool) *QuestionOutput {
+	output := &QuestionOutput{
+		ID:            q.ID,
+		TenderID:      q.TenderID,
+		Question:      q.Question,
+		Answer:        q.Answer,
+		Visibility:    q.Visibility,
+		TenderVersion: q.TenderVersion,
+		CreatedAt:     q.CreatedAt,
+		AnsweredAt:    q.AnsweredAt,
+	}
+	if showAuthor {
+		output.AuthorID = &q.AuthorID
+	}
+	return output
+}
+
+// AskQuestion lets a prospective bidder ask about a published tender while
+// it still accepts submissions.
+func (qs *QuestionService) AskQuestion(ctx context.Context, input *AskQuestionInput) (*QuestionOutput, error) {
+	const op = "service - QuestionService - AskQuestion"
+
+	tender, err := qs.tenderRepo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAskQuestion
+	}
+	if tender.Status != entity.TenderStatusPublished {
+		return nil, ErrTenderNotPublished
+	}
+	if tender.SubmissionClosed(time.Now().UTC()) {
+		return nil, ErrSubmissionClosed
+	}
+
+	responsible, err := qs.organizationRepo.IsResponsibleForTender(ctx, input.AuthorID, tender.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAskQuestion
+	}
+	if responsible {
+		return nil, ErrResponsibleCannotAsk
+	}
+
+	invited, err := isInvited(ctx, qs.invitationRepo, tender, input.AuthorID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAskQuestion
+	}
+	if !invited {
+		return nil, ErrNotInvited
+	}
+
+	var question *entity.Question
+	err = qs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		question, err = qs.questionRepo.CreateQuestion(ctx, &entity.Question{
+			TenderID: tender.ID,
+			AuthorID: input.AuthorID,
+			Question: input.Question,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.audit.record(ctx, "question.ask", entity.AuditQuestion, question.ID, nil, newQuestionOutput(question, true))
+	})
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAskQuestion
+	}
+
+	return newQuestionOutput(question, true), nil
+}
+
+// GetQuestions lists every question to tender responsibles. Anybody else sees
+// the publicly answered questions, anonymized, and their own ones.
+func (qs *QuestionService) GetQuestions(ctx context.Context, input *GetQuestionsInput) ([]*QuestionOutput, error) {
+	const op = "service - QuestionService - GetQuestions"
+
+	responsible := false
+	if input.RequesterID != uuid.Nil {
+		var err error
+		responsible, err = qs.organizationRepo.IsResponsibleForTender(ctx, input.RequesterID, input.TenderID)
+		if err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotGetQuestions
+		}
+	}
+
+	questions, err := qs.questionRepo.GetQuestionsByTender(ctx, input.TenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetQuestions
+	}
+
+	output := make([]*QuestionOutput, 0, len(questions))
+	for _, q := range questions {
+		own := input.RequesterID != uuid.Nil && q.AuthorID == input.RequesterID
+		switch {
+		case responsible || own:
+			output = append(output, newQuestionOutput(q, true))
+		case q.IsPublic():
+			output = append(output, newQuestionOutput(q, false))
+		}
+	}
+	return output, nil
+}
+
+// AnswerQuestion answers a question once. A public answer amends the tender,
+// so it bumps the tender version and is recorded against the new version.
+func (qs *QuestionService) AnswerQuestion(ctx context.Context, input *AnswerQuestionInput) (*QuestionOutput, error) {
+	const op = "service - QuestionService - AnswerQuestion"
+
+	var answered *entity.Question
+	err := qs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tender, err := qs.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+
+		question, err := qs.questionRepo.GetQuestionForUpdate(ctx, input.QuestionID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrQuestionNotFound
+			}
+			return err
+		}
+		if question.TenderID != tender.ID {
+			return ErrQuestionNotFound
+		}
+		if question.Answer != nil {
+			return ErrQuestionAlreadyAnswered
+		}
+
+		previous := newQuestionOutput(question, true)
+		question.Answer = &input.Answer
+		question.AnsweredBy = &input.ResponderID
+		question.Visibility = &input.Visibility
+		if question.IsPublic() {
+			if tender, err = qs.tenderRepo.IncrementTenderVersion(ctx, tender.ID); err != nil {
+				return err
+			}
+			question.TenderVersion = &tender.Version
+		}
+
+		answered, err = qs.questionRepo.AnswerQuestion(ctx, question)
+		if err != nil {
+			return err
+		}
+		return qs.audit.record(ctx, "question.answer", entity.AuditQuestion, answered.ID, previous, newQuestionOutput(answered, true))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrQuestionNotFound), errors.Is(err, ErrQuestionAlreadyAnswered):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotAnswerQuestion
+	}
+
+	return newQuestionOutput(answered, true), nil
+}
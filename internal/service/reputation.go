@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+// bidOrganizationID returns the organization a bid was made for, or nil for
+// bids made by a user on their own behalf.
+func bidOrganizationID(ctx context.Context, organizationRepo repo.Organization, bid *entity.Bid) (*uuid.UUID, error) {
+	if bid.AuthorType != "Organization" {
+		return nil, nil
+	}
+	organizationID, err := organizationRepo.GetEmployeeOrganizationID(ctx, bid.AuthorID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &organizationID, nil
+}
+
+// recordReputation adds the counters of delta to the reputation of the
+// employee and, when given, of their organization, and recomputes their
+// scores. It is meant to run in the transaction of the event it records.
+func recordReputation(ctx context.Context, reputationRepo repo.Reputation, delta entity.Reputation, employeeID uuid.UUID, organizationID *uuid.UUID) error {
+	subjects := []struct {
+		subjectType string
+		subjectID   uuid.UUID
+	}{{entity.ReputationEmployee, employeeID}}
+	if organizationID != nil {
+		subjects = append(subjects, struct {
+			subjectType string
+			subjectID   uuid.UUID
+		}{entity.ReputationOrganization, *organizationID})
+	}
+
+	for _, subject := range subjects {
+		delta.SubjectType = subject.subjectType
+		delta.SubjectID = subject.subjectID
+		reputation, err := reputationRepo.AddReputation(ctx, &delta)
+		if err != nil {
+			return err
+		}
+		if err = reputationRepo.UpdateReputationScore(ctx, reputation.SubjectType, reputation.SubjectID, reputation.ComputeScore()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ReputationService struct {
+	reputationRepo   repo.Reputation
+	organizationRepo repo.Organization
+}
+
+func NewReputationService(reputationRepo repo.Reputation, organizationRepo repo.Organization) *ReputationService {
+	return &ReputationService{
+		reputationRepo:   reputationRepo,
+		organizationRepo: organizationRepo,
+	}
+}
+
+func newReputationOutput(r *entity.Reputation) *ReputationOutput {
+	return &ReputationOutput{
+		SubjectType:         r.SubjectType,
+		SubjectID:           r.SubjectID,
+		Score:               r.Score,
+		ApprovalRate:        r.ApprovalRate(),
+		AverageRating:       r.AverageRating(),
+		OnTimeRate:          r.OnTimeRate(),
+		WithdrawalRate:      r.WithdrawalRate(),
+		BidsSubmitted:       r.BidsSubmitted,
+		BidsWithdrawn:       r.BidsWithdrawn,
+		BidsDecided:         r.BidsDecided,
+		BidsApproved:        r.BidsApproved,
+		Ratings:             r.RatingsCount,
+		MilestonesCompleted: r.MilestonesCompleted,
+		MilestonesOnTime:    r.MilestonesOnTime,
+	}
+}
+
+// GetOrganizationReputation returns the reputation of an organization. An
+// organization without any recorded event has no score yet.
+func (rs *ReputationService) GetOrganizationReputation(ctx context.Context, organizationID uuid.UUID) (*ReputationOutput, error) {
+	const op = "service - ReputationService - GetOrganizationReputation"
+
+	exists, err := rs.organizationRepo.OrganizationExists(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetReputation
+	}
+	if !exists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	reputation, err := rs.reputationRepo.GetReputation(ctx, entity.ReputationOrganization, organizationID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return newReputationOutput(&entity.Reputation{
+				SubjectType: entity.ReputationOrganization,
+				SubjectID:   organizationID,
+			}), nil
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetReputation
+	}
+
+	return newReputationOutput(reputation), nil
+}
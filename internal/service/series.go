@@ -0,0 +1,560 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sl "log/slog"
+	"strings"
+	"time"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type SeriesService struct {
+	seriesRepo       repo.Series
+	templateRepo     repo.Template
+	organizationRepo repo.Organization
+	employeeRepo     repo.Employee
+	tenderService    Tender
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewSeriesService(seriesRepo repo.Series, templateRepo repo.Template, organizationRepo repo.Organization, employeeRepo repo.Employee, tenderService Tender, audit *Auditor, tx repo.Transactor) *SeriesService {
+	return &SeriesService{
+		seriesRepo:       seriesRepo,
+		templateRepo:     templateRepo,
+		organizationRepo: organizationRepo,
+		employeeRepo:     employeeRepo,
+		tenderService:    tenderService,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newSeriesOutput(s *entity.TenderSeries) *SeriesOutput {
+	return &SeriesOutput{
+		ID:             s.ID,
+		OrganizationID: s.OrganizationID,
+		TemplateID:     s.TemplateID,
+		Rule:           s.Rule,
+		StartsAt:       s.StartsAt,
+		Values:         s.Values,
+		SubmissionDays: s.SubmissionDays,
+		AutoPublish:    s.AutoPublish,
+		Status:         s.Status,
+		NextRunAt:      s.NextRunAt,
+		LastError:      s.LastError,
+		CreatedBy:      s.CreatedBy,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func parseRule(rule string) (*entity.RecurrenceRule, error) {
+	r, err := entity.ParseRecurrenceRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRecurrenceRule, err)
+	}
+	return r, nil
+}
+
+// checkValues makes sure the values fill every placeholder of the template.
+func checkValues(template *entity.TenderTemplate, values map[string]string) error {
+	if _, missing := template.Content.Fill(values); len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingPlaceholderValues, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// schedule returns the updates that point the series at the first occurrence
+// of its rule after now, or end it when there is none.
+func schedule(rule *entity.RecurrenceRule, startsAt, now time.Time) map[string]interface{} {
+	n, next, ok := rule.After(startsAt, now)
+	if !ok {
+		return map[string]interface{}{
+			"status":      entity.SeriesStatusEnded,
+			"next_run_at": nil,
+		}
+	}
+	return map[string]interface{}{
+		"next_occurrence": n,
+		"next_run_at":     next,
+	}
+}
+
+func (ss *SeriesService) getTemplate(ctx context.Context, organizationID, templateID uuid.UUID) (*entity.TenderTemplate, error) {
+	template, err := ss.templateRepo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	if template.OrganizationID != organizationID {
+		return nil, ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+// CreateSeries starts a series of tenders made from a template of the
+// organization. The first tender is created on the first occurrence of the
+// rule after now.
+func (ss *SeriesService) CreateSeries(ctx context.Context, input *CreateSeriesInput) (*SeriesOutput, error) {
+	const op = "service - SeriesService - CreateSeries"
+
+	rule, err := parseRule(input.Rule)
+	if err != nil {
+		return nil, err
+	}
+	n, next, ok := rule.After(input.StartsAt, time.Now())
+	if !ok {
+		return nil, ErrRecurrenceEnded
+	}
+
+	if err = checkOrganizationResponsible(ctx, ss.organizationRepo, input.OrganizationID, input.CreatedBy); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateSeries
+	}
+
+	template, err := ss.getTemplate(ctx, input.OrganizationID, input.TemplateID)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateSeries
+	}
+	if err = checkValues(template, input.Values); err != nil {
+		return nil, err
+	}
+	if template.Content.Sealed && input.SubmissionDays == nil {
+		return nil, ErrSeriesDeadlineRequired
+	}
+
+	var series *entity.TenderSeries
+	err = ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		series, err = ss.seriesRepo.CreateSeries(ctx, &entity.TenderSeries{
+			OrganizationID: input.OrganizationID,
+			TemplateID:     input.TemplateID,
+			Rule:           input.Rule,
+			StartsAt:       input.StartsAt,
+			Values:         input.Values,
+			SubmissionDays: input.SubmissionDays,
+			AutoPublish:    input.AutoPublish,
+			Status:         entity.SeriesStatusActive,
+			NextOccurrence: n,
+			NextRunAt:      &next,
+			CreatedBy:      input.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		return ss.audit.record(ctx, "series.create", entity.AuditSeries, series.ID, nil, newSeriesOutput(series))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateSeries
+	}
+
+	sl.Info(op, sl.Any("series_id", series.ID), sl.Any("next_run_at", next))
+	return newSeriesOutput(series), nil
+}
+
+func (ss *SeriesService) GetSeries(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*SeriesOutput, error) {
+	const op = "service - SeriesService - GetSeries"
+
+	if err := checkOrganizationResponsible(ctx, ss.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSeries
+	}
+
+	series, err := ss.seriesRepo.GetSeriesByOrganization(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSeries
+	}
+
+	output := make([]*SeriesOutput, 0, len(series))
+	for _, s := range series {
+		output = append(output, newSeriesOutput(s))
+	}
+	return output, nil
+}
+
+func (ss *SeriesService) GetSeriesInstances(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) ([]*SeriesInstanceOutput, error) {
+	const op = "service - SeriesService - GetSeriesInstances"
+
+	if err := checkOrganizationResponsible(ctx, ss.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSeries
+	}
+
+	series, err := ss.seriesRepo.GetSeriesByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSeries
+	}
+	if series.OrganizationID != organizationID {
+		return nil, ErrSeriesNotFound
+	}
+
+	instances, err := ss.seriesRepo.GetInstancesBySeries(ctx, seriesID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetSeries
+	}
+
+	output := make([]*SeriesInstanceOutput, 0, len(instances))
+	for _, i := range instances {
+		output = append(output, &SeriesInstanceOutput{
+			TenderID:    i.TenderID,
+			Occurrence:  i.Occurrence,
+			ScheduledAt: i.ScheduledAt,
+			CreatedAt:   i.CreatedAt,
+		})
+	}
+	return output, nil
+}
+
+// updateSeries locks a series of the organization and applies the changes
+// returned by change, recorded as action.
+func (ss *SeriesService) updateSeries(ctx context.Context, action string, organizationID, seriesID, employeeID uuid.UUID, change func(*entity.TenderSeries) (map[string]interface{}, error)) (*entity.TenderSeries, error) {
+	if err := checkOrganizationResponsible(ctx, ss.organizationRepo, organizationID, employeeID); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.TenderSeries
+	err := ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+		series, err := ss.seriesRepo.GetSeriesForUpdate(ctx, seriesID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrSeriesNotFound
+			}
+			return err
+		}
+		if series.OrganizationID != organizationID {
+			return ErrSeriesNotFound
+		}
+
+		previous := newSeriesOutput(series)
+		updates, err := change(series)
+		if err != nil {
+			return err
+		}
+		updated, err = ss.seriesRepo.UpdateSeries(ctx, series.ID, updates)
+		if err != nil {
+			return err
+		}
+		return ss.audit.record(ctx, action, entity.AuditSeries, updated.ID, previous, newSeriesOutput(updated))
+	})
+	return updated, err
+}
+
+// UpdateSeries edits the schedule, the placeholder values or the publication
+// of a series. A new schedule applies from now on; an ended series whose new
+// rule has occurrences left becomes active again.
+func (ss *SeriesService) UpdateSeries(ctx context.Context, input *UpdateSeriesInput) (*SeriesOutput, error) {
+	const op = "service - SeriesService - UpdateSeries"
+
+	series, err := ss.updateSeries(ctx, "series.update", input.OrganizationID, input.SeriesID, input.EmployeeID, func(series *entity.TenderSeries) (map[string]interface{}, error) {
+		updates := make(map[string]interface{})
+
+		if input.Values != nil || input.SubmissionDays != nil {
+			if input.Values != nil {
+				series.Values = input.Values
+				updates["placeholder_values"] = input.Values
+			}
+			if input.SubmissionDays != nil {
+				series.SubmissionDays = input.SubmissionDays
+				updates["submission_days"] = *input.SubmissionDays
+			}
+			// The tenders of the series must stay valid, rather than fail
+			// and pause the series when it runs.
+			draft, err := ss.seriesTender(ctx, series, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if err = validateTender(ctx, draft); err != nil {
+				return nil, err
+			}
+		}
+		if input.AutoPublish != nil {
+			updates["auto_publish"] = *input.AutoPublish
+		}
+
+		if input.Rule != nil || input.StartsAt != nil {
+			if input.Rule != nil {
+				series.Rule = *input.Rule
+				updates["rule"] = series.Rule
+			}
+			if input.StartsAt != nil {
+				series.StartsAt = *input.StartsAt
+				updates["starts_at"] = series.StartsAt
+			}
+			rule, err := parseRule(series.Rule)
+			if err != nil {
+				return nil, err
+			}
+			if series.Status != entity.SeriesStatusPaused {
+				if _, _, ok := rule.After(series.StartsAt, time.Now()); !ok {
+					return nil, ErrRecurrenceEnded
+				}
+				for column, value := range schedule(rule, series.StartsAt, time.Now()) {
+					updates[column] = value
+				}
+				updates["status"] = entity.SeriesStatusActive
+			}
+		}
+
+		return updates, nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSeriesNotFound), errors.Is(err, ErrNotOrganizationResponsible), errors.Is(err, ErrTemplateNotFound),
+			errors.Is(err, ErrMissingPlaceholderValues), errors.Is(err, ErrInvalidRecurrenceRule), errors.Is(err, ErrRecurrenceEnded),
+			errors.Is(err, ErrSeriesDeadlineRequired), errors.Is(err, ErrInvalidTender):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateSeries
+	}
+
+	return newSeriesOutput(series), nil
+}
+
+func (ss *SeriesService) PauseSeries(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) (*SeriesOutput, error) {
+	const op = "service - SeriesService - PauseSeries"
+
+	series, err := ss.updateSeries(ctx, "series.pause", organizationID, seriesID, employeeID, func(series *entity.TenderSeries) (map[string]interface{}, error) {
+		if series.Status != entity.SeriesStatusActive {
+			return nil, ErrSeriesNotActive
+		}
+		return map[string]interface{}{"status": entity.SeriesStatusPaused}, nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSeriesNotFound), errors.Is(err, ErrNotOrganizationResponsible), errors.Is(err, ErrSeriesNotActive):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateSeries
+	}
+
+	return newSeriesOutput(series), nil
+}
+
+// ResumeSeries reactivates a paused series. The occurrences missed while it
+// was paused are skipped.
+func (ss *SeriesService) ResumeSeries(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) (*SeriesOutput, error) {
+	const op = "service - SeriesService - ResumeSeries"
+
+	series, err := ss.updateSeries(ctx, "series.resume", organizationID, seriesID, employeeID, func(series *entity.TenderSeries) (map[string]interface{}, error) {
+		if series.Status != entity.SeriesStatusPaused {
+			return nil, ErrSeriesNotPaused
+		}
+		rule, err := parseRule(series.Rule)
+		if err != nil {
+			return nil, err
+		}
+
+		updates := map[string]interface{}{
+			"status":     entity.SeriesStatusActive,
+			"last_error": nil,
+		}
+		for column, value := range schedule(rule, series.StartsAt, time.Now()) {
+			updates[column] = value
+		}
+		return updates, nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSeriesNotFound), errors.Is(err, ErrNotOrganizationResponsible), errors.Is(err, ErrSeriesNotPaused):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateSeries
+	}
+
+	return newSeriesOutput(series), nil
+}
+
+// Run creates the due tenders of every series each period until ctx is done.
+func (ss *SeriesService) Run(ctx context.Context, period time.Duration) {
+	const op = "service - SeriesService - Run"
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if err := ss.RunDue(ctx, time.Now()); err != nil {
+			sl.Error(op, sl.Any("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue creates a tender for every series whose next run is due at now. A
+// series whose tender cannot be created is paused with the error, so that
+// it can be fixed and resumed.
+func (ss *SeriesService) RunDue(ctx context.Context, now time.Time) error {
+	const op = "service - SeriesService - RunDue"
+
+	for ctx.Err() == nil {
+		var series *entity.TenderSeries
+		var failure error
+		err := ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			series, err = ss.seriesRepo.GetDueSeriesForUpdate(ctx, now)
+			if err != nil {
+				return err
+			}
+			failure = ss.runSeries(ctx, series, now)
+			return failure
+		})
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil
+		}
+		if failure == nil && err != nil {
+			return err
+		}
+
+		if failure != nil {
+			sl.Warn(op, sl.Any("series_id", series.ID), sl.Any("error", failure.Error()))
+			err = ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+				paused, err := ss.seriesRepo.UpdateSeries(ctx, series.ID, map[string]interface{}{
+					"status":     entity.SeriesStatusPaused,
+					"last_error": failure.Error(),
+				})
+				if err != nil {
+					return err
+				}
+				return ss.audit.record(ctx, "series.pause", entity.AuditSeries, paused.ID, newSeriesOutput(series), newSeriesOutput(paused))
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runSeries creates the tender of the due occurrence of a series, publishes
+// it when the series asks so, and moves the series to its next occurrence.
+// seriesTender builds the tender the series creates when it runs at now.
+func (ss *SeriesService) seriesTender(ctx context.Context, series *entity.TenderSeries, now time.Time) (*CreateTenderInput, error) {
+	template, err := ss.getTemplate(ctx, series.OrganizationID, series.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	content, missing := template.Content.Fill(series.Values)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPlaceholderValues, strings.Join(missing, ", "))
+	}
+	creator, err := ss.employeeRepo.GetByID(ctx, series.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &CreateTenderInput{
+		Name:            content.Name,
+		Description:     content.Description,
+		ServiceType:     content.ServiceType,
+		OrganizationID:  series.OrganizationID,
+		CreatorUsername: creator.Username,
+		Sealed:          content.Sealed,
+		Budget:          content.Budget,
+		Currency:        content.Currency,
+		AccessMode:      content.AccessMode,
+	}
+	if series.SubmissionDays != nil {
+		deadline := now.AddDate(0, 0, *series.SubmissionDays)
+		input.SubmissionDeadline = &deadline
+	} else if content.Sealed {
+		return nil, ErrSeriesDeadlineRequired
+	}
+	for _, c := range content.Criteria {
+		input.Criteria = append(input.Criteria, CriterionInput{Name: c.Name, Weight: c.Weight})
+	}
+	for _, l := range content.Lots {
+		input.Lots = append(input.Lots, LotInput{Name: l.Name, Description: l.Description, Budget: l.Budget})
+	}
+	return input, nil
+}
+
+func (ss *SeriesService) runSeries(ctx context.Context, series *entity.TenderSeries, now time.Time) error {
+	const op = "service - SeriesService - runSeries"
+
+	rule, err := parseRule(series.Rule)
+	if err != nil {
+		return err
+	}
+	input, err := ss.seriesTender(ctx, series, now)
+	if err != nil {
+		return err
+	}
+
+	scheduledAt := *series.NextRunAt
+	tender, err := ss.tenderService.CreateTender(ctx, input)
+	if err != nil {
+		return err
+	}
+	_, err = ss.seriesRepo.CreateInstance(ctx, &entity.SeriesInstance{
+		SeriesID:    series.ID,
+		TenderID:    tender.ID,
+		Occurrence:  series.NextOccurrence,
+		ScheduledAt: scheduledAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if series.AutoPublish {
+		_, err = ss.tenderService.UpdateTenderStatus(ctx, &UpdateTenderStatusInput{
+			TenderID:    tender.ID,
+			Status:      entity.TenderStatusPublished,
+			RequesterID: series.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	updates := schedule(rule, series.StartsAt, now)
+	updates["last_error"] = nil
+	advanced, err := ss.seriesRepo.UpdateSeries(ctx, series.ID, updates)
+	if err != nil {
+		return err
+	}
+	if err = ss.audit.record(ctx, "series.run", entity.AuditSeries, series.ID, newSeriesOutput(series), newSeriesOutput(advanced)); err != nil {
+		return err
+	}
+
+	sl.Info(op, sl.Any("series_id", series.ID), sl.Any("tender_id", tender.ID), sl.Any("occurrence", series.NextOccurrence))
+	return nil
+}
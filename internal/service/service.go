@@ -2,35 +2,67 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"time"
 
+	"git.codenrock.com/tender/internal/entity"
 	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/pkg/blobstore"
+	"git.codenrock.com/tender/pkg/eventsink"
+	"git.codenrock.com/tender/pkg/sealer"
+	"git.codenrock.com/tender/pkg/signer"
 	"github.com/google/uuid"
 )
 
 type TenderOutput struct {
-	ID              uuid.UUID
-	Name            string
-	Description     string
-	ServiceType     string
-	Status          string
-	Version         int
-	OrganizationID  uuid.UUID
-	CreatorUsername string
-	CreatedAt       time.Time
+	ID                   uuid.UUID
+	Name                 string
+	Description          string
+	ServiceType          string
+	Status               string
+	Version              int
+	OrganizationID       uuid.UUID
+	CreatorUsername      string
+	Sealed               bool
+	SubmissionDeadline   *time.Time
+	OpenedAt             *time.Time
+	Budget               *string
+	Currency             *string
+	Criteria             []*CriterionOutput
+	Lots                 []*LotOutput
+	AuctionStartsAt      *time.Time
+	AuctionEndsAt        *time.Time
+	AccessMode           string
+	CancellationCategory *string
+	CancellationReason   *string
+	CanceledAt           *time.Time
+	// ApprovalRequestID is set when a publication awaits approval.
+	ApprovalRequestID *uuid.UUID
+	CreatedAt         time.Time
 }
 type CreateTenderInput struct {
-	Name            string
-	Description     string
-	ServiceType     string
-	OrganizationID  uuid.UUID
-	CreatorUsername string
+	Name               string
+	Description        string
+	ServiceType        string
+	OrganizationID     uuid.UUID
+	CreatorUsername    string
+	Sealed             bool
+	SubmissionDeadline *time.Time
+	Budget             string
+	Currency           string
+	Criteria           []CriterionInput
+	Lots               []LotInput
+	AuctionStartsAt    *time.Time
+	AuctionEndsAt      *time.Time
+	AccessMode         string
 }
 
 type GetTendersInput struct {
 	Limit        int
 	Offset       int
 	ServiceTypes []string
+	ViewerID     uuid.UUID
 }
 
 type GetUserTendersInput struct {
@@ -52,6 +84,8 @@ type GetTenderByIDOutput struct {
 type UpdateTenderStatusInput struct {
 	TenderID uuid.UUID
 	Status   string
+	// RequesterID opens the approval chain when publication needs one.
+	RequesterID uuid.UUID
 }
 
 type UpdateTenderInput struct {
@@ -59,6 +93,38 @@ type UpdateTenderInput struct {
 	Name        string
 	Description string
 	ServiceType string
+	Budget      string
+	Currency    string
+	// Reason is required when a published tender is amended.
+	Reason         string
+	EditorUsername string
+}
+
+type CancelTenderInput struct {
+	TenderID uuid.UUID
+	Category string
+	Reason   string
+}
+
+type TenderAmendmentOutput struct {
+	ID        uuid.UUID
+	TenderID  uuid.UUID
+	Version   int
+	Reason    string
+	Changes   map[string]entity.AmendmentChange
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// CloneTenderInput copies a tender into a new draft. An empty Name keeps the
+// name of the source.
+type CloneTenderInput struct {
+	TenderID           uuid.UUID
+	CreatorUsername    string
+	Name               string
+	SubmissionDeadline *time.Time
+	AuctionStartsAt    *time.Time
+	AuctionEndsAt      *time.Time
 }
 
 type RollbackTenderInput struct {
@@ -72,6 +138,10 @@ type Tender interface {
 	GetTenderByID(ctx context.Context, tenderID uuid.UUID) (*TenderOutput, error)
 	UpdateTenderStatus(ctx context.Context, input *UpdateTenderStatusInput) (*TenderOutput, error)
 	UpdateTender(ctx context.Context, input *UpdateTenderInput) (*TenderOutput, error)
+	GetTenderAmendments(ctx context.Context, tenderID uuid.UUID) ([]*TenderAmendmentOutput, error)
+	CancelTender(ctx context.Context, input *CancelTenderInput) (*TenderOutput, error)
+	DraftClone(ctx context.Context, input *CloneTenderInput) (*CreateTenderInput, error)
+	CloneTender(ctx context.Context, sourceID uuid.UUID, input *CreateTenderInput) (*TenderOutput, error)
 }
 
 type EmployeeOutput struct {
@@ -82,10 +152,32 @@ type EmployeeOutput struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// EmployeeKeyOutput holds the public key in the DER encoded PKIX format.
+type EmployeeKeyOutput struct {
+	ID          uuid.UUID
+	EmployeeID  uuid.UUID
+	Algorithm   string
+	PublicKey   []byte
+	Fingerprint string
+	CreatedAt   time.Time
+	RevokedAt   *time.Time
+}
+
+// RegisterKeyInput holds an Ed25519 or ECDSA public key, PEM or DER encoded.
+type RegisterKeyInput struct {
+	EmployeeID uuid.UUID
+	PublicKey  []byte
+}
+
 type Employee interface {
 	GetByUsername(ctx context.Context, username string) (*EmployeeOutput, error)
 	GetByID(ctx context.Context, employeeID uuid.UUID) (*EmployeeOutput, error)
 	IsResponsible(ctx context.Context, employeeID uuid.UUID) (bool, error)
+	IsAdmin(username string) bool
+	RegisterKey(ctx context.Context, input *RegisterKeyInput) (*EmployeeKeyOutput, error)
+	GetKeys(ctx context.Context, employeeID uuid.UUID) ([]*EmployeeKeyOutput, error)
+	RevokeKey(ctx context.Context, employeeID, keyID uuid.UUID) (*EmployeeKeyOutput, error)
 }
 
 type OrganizationResponsibleInput struct {
@@ -104,22 +196,69 @@ type Organization interface {
 	IsResponsibleForTender(ctx context.Context, userID uuid.UUID, tenderID uuid.UUID) (bool, error)
 }
 type BidOutput struct {
-	ID         uuid.UUID
-	Name       string
-	Status     string
-	TenderID   uuid.UUID
-	AuthorType string
-	AuthorID   uuid.UUID
-	Version    int
-	CreatedAt  time.Time
+	ID           uuid.UUID
+	Name         string
+	Description  string
+	Status       string
+	TenderID     uuid.UUID
+	AuthorType   string
+	AuthorID     uuid.UUID
+	Version      int
+	Price        *string
+	Currency     *string
+	DeliveryDays *int
+	ValidityDays *int
+	TotalScore   *string
+	LotIDs       []uuid.UUID
+	Sealed       bool
+	NeedsAck     bool
+	// AuthorReputation is the reputation score of the author, set on the
+	// bid listings of a tender.
+	AuthorReputation *float64
+	CreatedAt        time.Time
 }
 
 type CreateBidInput struct {
-	Name        string
-	Description string
-	TenderID    uuid.UUID
-	AuthorType  string
-	AuthorID    uuid.UUID
+	Name         string
+	Description  string
+	TenderID     uuid.UUID
+	AuthorType   string
+	AuthorID     uuid.UUID
+	Price        string
+	Currency     string
+	DeliveryDays int
+	ValidityDays int
+	LotIDs       []uuid.UUID
+	Signature    *BidSignatureInput
+}
+
+// BidSignatureInput is a detached signature of the canonical document of
+// the bid version being submitted, made with a registered key of the
+// submitting employee.
+type BidSignatureInput struct {
+	KeyID     uuid.UUID
+	Signature []byte
+}
+
+// BidSignatureOutput is the verification of the signature of a bid version.
+// Document is the canonical document as it was signed; it is withheld, and
+// the signature not verified, while a sealed bid is sealed.
+type BidSignatureOutput struct {
+	BidID          uuid.UUID
+	Version        int
+	Signed         bool
+	Valid          bool
+	Reason         string
+	KeyID          uuid.UUID
+	Algorithm      string
+	PublicKey      []byte
+	Fingerprint    string
+	KeyRevokedAt   *time.Time
+	SignerID       uuid.UUID
+	SignerUsername string
+	Document       []byte
+	Signature      []byte
+	SignedAt       time.Time
 }
 
 type GetBidsByUsernameInput struct {
@@ -140,17 +279,90 @@ type UpdateBidStatusInput struct {
 }
 
 type UpdateBidInput struct {
+	BidID        uuid.UUID
+	Name         string
+	Description  string
+	Price        string
+	Currency     string
+	DeliveryDays int
+	ValidityDays int
+	EditorID     uuid.UUID
+	Signature    *BidSignatureInput
+}
+
+type WithdrawBidInput struct {
+	BidID    uuid.UUID
+	AuthorID uuid.UUID
+}
+
+// ResubmitBidInput revises a withdrawn bid; empty fields keep the withdrawn values.
+type ResubmitBidInput struct {
+	BidID        uuid.UUID
+	AuthorID     uuid.UUID
+	Name         string
+	Description  string
+	Price        string
+	Currency     string
+	DeliveryDays int
+	ValidityDays int
+}
+
+type BidVersionOutput struct {
+	Version      int
+	Status       string
+	Name         string
+	Description  string
+	Price        *string
+	Currency     *string
+	DeliveryDays *int
+	ValidityDays *int
+	Sealed       bool
+	CreatedAt    time.Time
+}
+
+type GetBidVersionsInput struct {
 	BidID       uuid.UUID
-	Name        string
-	Description string
+	RequesterID uuid.UUID
 }
-type UpdateBidDecisionInput struct {
+
+type AcknowledgeBidInput struct {
 	BidID    uuid.UUID
-	Decision string
+	AuthorID uuid.UUID
 }
+
+type BidComparisonItem struct {
+	BidID            uuid.UUID
+	Name             string
+	Status           string
+	AuthorType       string
+	AuthorID         uuid.UUID
+	Price            *string
+	Currency         *string
+	DeliveryDays     *int
+	ValidityDays     *int
+	Deviation        *string
+	DeviationPercent *string
+}
+
+type BidComparisonOutput struct {
+	TenderID uuid.UUID
+	Budget   *string
+	Currency *string
+	Bids     []*BidComparisonItem
+}
+type UpdateBidDecisionInput struct {
+	BidID     uuid.UUID
+	DeciderID uuid.UUID
+	Decision  string
+}
+
+// UpdateBidFeedbackInput reviews a bid. Rating is an optional star rating
+// from 1 to 5.
 type UpdateBidFeedbackInput struct {
-	BidID    uuid.UUID
-	Feedback string
+	BidID      uuid.UUID
+	ReviewerID uuid.UUID
+	Feedback   string
+	Rating     *int
 }
 type Bid interface {
 	CreateBid(ctx context.Context, input *CreateBidInput) (*BidOutput, error)
@@ -160,25 +372,826 @@ type Bid interface {
 	GetBidByID(ctx context.Context, bidID uuid.UUID) (*BidOutput, error)
 	UpdateBidStatus(ctx context.Context, input *UpdateBidStatusInput) (*BidOutput, error)
 	UpdateBid(ctx context.Context, input *UpdateBidInput) (*BidOutput, error)
+	VerifyBidSignature(ctx context.Context, bidID uuid.UUID, version int) (*BidSignatureOutput, error)
 	UpdateBidDecision(ctx context.Context, input *UpdateBidDecisionInput) (*BidOutput, error)
 	UpdateBidFeedback(ctx context.Context, input *UpdateBidFeedbackInput) (*BidOutput, error)
+	GetBidComparison(ctx context.Context, tenderID uuid.UUID) (*BidComparisonOutput, error)
+	AcknowledgeBid(ctx context.Context, input *AcknowledgeBidInput) (*BidOutput, error)
+	WithdrawBid(ctx context.Context, input *WithdrawBidInput) (*BidOutput, error)
+	ResubmitBid(ctx context.Context, input *ResubmitBidInput) (*BidOutput, error)
+	GetBidVersions(ctx context.Context, input *GetBidVersionsInput) ([]*BidVersionOutput, error)
+}
+type CriterionInput struct {
+	Name   string
+	Weight string
+}
+
+type CriterionOutput struct {
+	ID     uuid.UUID
+	Name   string
+	Weight string
+}
+
+type BidScoreInput struct {
+	CriterionID uuid.UUID
+	Score       string
+}
+
+type ScoreBidInput struct {
+	BidID      uuid.UUID
+	ReviewerID uuid.UUID
+	Scores     []BidScoreInput
+}
+
+type CriterionScoreOutput struct {
+	CriterionID   uuid.UUID
+	Name          string
+	Weight        string
+	AverageScore  *string
+	ReviewerCount int
 }
+
+type BidRankingOutput struct {
+	Rank       int
+	Bid        *BidOutput
+	TotalScore *string
+	Complete   bool
+	Criteria   []*CriterionScoreOutput
+}
+
+type TenderRankingOutput struct {
+	TenderID uuid.UUID
+	Complete bool
+	Bids     []*BidRankingOutput
+}
+
+type Evaluation interface {
+	GetCriteria(ctx context.Context, tenderID uuid.UUID) ([]*CriterionOutput, error)
+	ScoreBid(ctx context.Context, input *ScoreBidInput) (*BidRankingOutput, error)
+	GetTenderRanking(ctx context.Context, tenderID uuid.UUID) (*TenderRankingOutput, error)
+}
+type LotInput struct {
+	Name        string
+	Description string
+	Budget      string
+}
+
+type LotOutput struct {
+	ID          uuid.UUID
+	TenderID    uuid.UUID
+	Name        string
+	Description string
+	Budget      *string
+	Status      string
+	WinnerBidID *uuid.UUID
+	DecidedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+type DecideLotInput struct {
+	TenderID  uuid.UUID
+	LotID     uuid.UUID
+	BidID     uuid.UUID
+	DeciderID uuid.UUID
+	Decision  string
+}
+
+type Lot interface {
+	GetLots(ctx context.Context, tenderID uuid.UUID) ([]*LotOutput, error)
+	DecideLot(ctx context.Context, input *DecideLotInput) (*LotOutput, error)
+}
+type AuctionRankOutput struct {
+	Rank       int
+	BidID      uuid.UUID
+	AuthorType string
+	AuthorID   uuid.UUID
+	Price      string
+}
+
+type AuctionStateOutput struct {
+	TenderID  uuid.UUID
+	Currency  *string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Running   bool
+	Finished  bool
+	BestPrice *string
+	Ranking   []*AuctionRankOutput
+}
+
+type PlaceOfferInput struct {
+	TenderID uuid.UUID
+	BidID    uuid.UUID
+	AuthorID uuid.UUID
+	Price    string
+}
+
+type Auction interface {
+	GetAuctionState(ctx context.Context, tenderID uuid.UUID) (*AuctionStateOutput, error)
+	PlaceOffer(ctx context.Context, input *PlaceOfferInput) (*AuctionStateOutput, error)
+	Subscribe(tenderID uuid.UUID) (<-chan *AuctionStateOutput, func())
+}
+type ServiceTypeOutput struct {
+	ID        uuid.UUID
+	Name      string
+	Code      string
+	ParentID  *uuid.UUID
+	Label     string
+	Labels    map[string]string
+	Active    bool
+	CreatedAt time.Time
+}
+
+type CreateServiceTypeInput struct {
+	Name     string
+	Code     string
+	ParentID *uuid.UUID
+	Labels   map[string]string
+}
+
+type UpdateServiceTypeInput struct {
+	ID       uuid.UUID
+	Name     string
+	Code     string
+	ParentID *uuid.UUID
+	Labels   map[string]string
+	Active   *bool
+}
+
+type ServiceType interface {
+	GetServiceTypes(ctx context.Context, lang string) ([]*ServiceTypeOutput, error)
+	CreateServiceType(ctx context.Context, input *CreateServiceTypeInput) (*ServiceTypeOutput, error)
+	UpdateServiceType(ctx context.Context, input *UpdateServiceTypeInput) (*ServiceTypeOutput, error)
+	DeleteServiceType(ctx context.Context, id uuid.UUID) error
+}
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+type AttachmentOutput struct {
+	ID          uuid.UUID
+	TenderID    *uuid.UUID
+	BidID       *uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	UploadedBy  uuid.UUID
+	CreatedAt   time.Time
+}
+
+type AttachmentContentOutput struct {
+	*AttachmentOutput
+	Content io.ReadCloser
+}
+
+type UploadAttachmentInput struct {
+	ParentID   uuid.UUID
+	UploaderID uuid.UUID
+	FileName   string
+	Content    io.Reader
+}
+
+type Attachment interface {
+	UploadTenderAttachment(ctx context.Context, input *UploadAttachmentInput) (*AttachmentOutput, error)
+	UploadBidAttachment(ctx context.Context, input *UploadAttachmentInput) (*AttachmentOutput, error)
+	GetTenderAttachments(ctx context.Context, tenderID uuid.UUID) ([]*AttachmentOutput, error)
+	GetBidAttachments(ctx context.Context, bidID, requesterID uuid.UUID) ([]*AttachmentOutput, error)
+	DownloadTenderAttachment(ctx context.Context, tenderID, attachmentID uuid.UUID) (*AttachmentContentOutput, error)
+	DownloadBidAttachment(ctx context.Context, bidID, attachmentID, requesterID uuid.UUID) (*AttachmentContentOutput, error)
+}
+type QuestionOutput struct {
+	ID            uuid.UUID
+	TenderID      uuid.UUID
+	AuthorID      *uuid.UUID
+	Question      string
+	Answer        *string
+	Visibility    *string
+	TenderVersion *int
+	CreatedAt     time.Time
+	AnsweredAt    *time.Time
+}
+
+type AskQuestionInput struct {
+	TenderID uuid.UUID
+	AuthorID uuid.UUID
+	Question string
+}
+
+type GetQuestionsInput struct {
+	TenderID    uuid.UUID
+	RequesterID uuid.UUID
+}
+
+type AnswerQuestionInput struct {
+	TenderID    uuid.UUID
+	QuestionID  uuid.UUID
+	ResponderID uuid.UUID
+	Answer      string
+	Visibility  string
+}
+
+type Question interface {
+	AskQuestion(ctx context.Context, input *AskQuestionInput) (*QuestionOutput, error)
+	GetQuestions(ctx context.Context, input *GetQuestionsInput) ([]*QuestionOutput, error)
+	AnswerQuestion(ctx context.Context, input *AnswerQuestionInput) (*QuestionOutput, error)
+}
+type MessageOutput struct {
+	ID         uuid.UUID
+	BidID      uuid.UUID
+	SenderID   uuid.UUID
+	FromAuthor bool
+	Body       string
+	CreatedAt  time.Time
+	ReadAt     *time.Time
+}
+
+type SendMessageInput struct {
+	BidID    uuid.UUID
+	SenderID uuid.UUID
+	Body     string
+}
+
+type GetMessagesInput struct {
+	BidID    uuid.UUID
+	ReaderID uuid.UUID
+	Limit    int
+	Offset   int
+}
+
+type Message interface {
+	SendMessage(ctx context.Context, input *SendMessageInput) (*MessageOutput, error)
+	GetMessages(ctx context.Context, input *GetMessagesInput) ([]*MessageOutput, error)
+}
+type InvitationOutput struct {
+	ID             uuid.UUID
+	TenderID       uuid.UUID
+	OrganizationID *uuid.UUID
+	EmployeeID     *uuid.UUID
+	CreatedAt      time.Time
+}
+
+type CreateInvitationsInput struct {
+	TenderID        uuid.UUID
+	OrganizationIDs []uuid.UUID
+	EmployeeIDs     []uuid.UUID
+}
+
+type Invitation interface {
+	CreateInvitations(ctx context.Context, input *CreateInvitationsInput) ([]*InvitationOutput, error)
+	GetInvitations(ctx context.Context, tenderID uuid.UUID) ([]*InvitationOutput, error)
+	DeleteInvitation(ctx context.Context, tenderID, invitationID uuid.UUID) error
+	CanAccessTender(ctx context.Context, tenderID, employeeID uuid.UUID) (bool, error)
+}
+type NegotiationRoundOutput struct {
+	ID                 uuid.UUID
+	BidID              uuid.UUID
+	Round              int
+	BidVersion         int
+	ProposedBy         uuid.UUID
+	TargetPrice        *string
+	Comment            string
+	Status             string
+	ResponseComment    *string
+	ResponseBidVersion *int
+	CreatedAt          time.Time
+	RespondedAt        *time.Time
+}
+
+type ProposeCounterOfferInput struct {
+	BidID         uuid.UUID
+	ResponsibleID uuid.UUID
+	TargetPrice   string
+	Comment       string
+}
+
+// RespondCounterOfferInput answers the open round of a bid. The revision
+// fields are only used by a Revised response.
+type RespondCounterOfferInput struct {
+	BidID        uuid.UUID
+	AuthorID     uuid.UUID
+	Response     string
+	Comment      string
+	Name         string
+	Description  string
+	Price        string
+	DeliveryDays int
+	ValidityDays int
+}
+
+type Negotiation interface {
+	ProposeCounterOffer(ctx context.Context, input *ProposeCounterOfferInput) (*NegotiationRoundOutput, error)
+	RespondCounterOffer(ctx context.Context, input *RespondCounterOfferInput) (*NegotiationRoundOutput, error)
+	GetNegotiationRounds(ctx context.Context, bidID uuid.UUID) ([]*NegotiationRoundOutput, error)
+}
+type NotificationOutput struct {
+	ID        uuid.UUID
+	Type      string
+	TenderID  *uuid.UUID
+	BidID     *uuid.UUID
+	Message   string
+	CreatedAt time.Time
+	ReadAt    *time.Time
+}
+
+type GetNotificationsInput struct {
+	RecipientID uuid.UUID
+	UnreadOnly  bool
+	Limit       int
+	Offset      int
+}
+
+type Notification interface {
+	GetNotifications(ctx context.Context, input *GetNotificationsInput) ([]*NotificationOutput, error)
+	MarkNotificationRead(ctx context.Context, notificationID, recipientID uuid.UUID) (*NotificationOutput, error)
+}
+type ContractOutput struct {
+	ID                     uuid.UUID
+	TenderID               uuid.UUID
+	BidID                  uuid.UUID
+	LotID                  *uuid.UUID
+	BidVersion             int
+	BuyerOrganizationID    uuid.UUID
+	SupplierOrganizationID *uuid.UUID
+	SupplierID             uuid.UUID
+	Price                  *string
+	Currency               *string
+	Status                 string
+	CreatedAt              time.Time
+	CompletedAt            *time.Time
+	Milestones             []*MilestoneOutput
+}
+
+type MilestoneOutput struct {
+	ID                  uuid.UUID
+	Name                string
+	Description         string
+	DueDate             time.Time
+	SupplierConfirmedAt *time.Time
+	BuyerConfirmedAt    *time.Time
+	CompletedAt         *time.Time
+	Overdue             bool
+	CreatedAt           time.Time
+}
+
+type GetContractsInput struct {
+	EmployeeID  uuid.UUID
+	OverdueOnly bool
+	Limit       int
+	Offset      int
+}
+
+type AddMilestoneInput struct {
+	ContractID  uuid.UUID
+	EmployeeID  uuid.UUID
+	Name        string
+	Description string
+	DueDate     time.Time
+}
+
+// ConfirmMilestoneInput confirms a milestone for one side of the contract:
+// the supplier confirms the delivery, the buyer its acceptance.
+type ConfirmMilestoneInput struct {
+	ContractID  uuid.UUID
+	MilestoneID uuid.UUID
+	EmployeeID  uuid.UUID
+	Side        string
+}
+
+// SupplierRatingOutput rates a supplier organization by the share of its
+// due milestones completed on time. OnTimeRate is nil until a milestone is
+// completed or overdue.
+type SupplierRatingOutput struct {
+	OrganizationID      uuid.UUID
+	CompletedMilestones int
+	OnTimeMilestones    int
+	OverdueMilestones   int
+	OnTimeRate          *float64
+}
+
+type Contract interface {
+	GetContract(ctx context.Context, contractID, employeeID uuid.UUID) (*ContractOutput, error)
+	GetUserContracts(ctx context.Context, input *GetContractsInput) ([]*ContractOutput, error)
+	AddMilestone(ctx context.Context, input *AddMilestoneInput) (*MilestoneOutput, error)
+	ConfirmMilestone(ctx context.Context, input *ConfirmMilestoneInput) (*MilestoneOutput, error)
+	GetSupplierRating(ctx context.Context, organizationID uuid.UUID) (*SupplierRatingOutput, error)
+}
+
+// ReputationOutput scores an employee or an organization from 0 to 100 on
+// its approval rate, review ratings, on-time delivery and withdrawals. The
+// score and the rates are nil until there is data for them.
+type ReputationOutput struct {
+	SubjectType         string
+	SubjectID           uuid.UUID
+	Score               *float64
+	ApprovalRate        *float64
+	AverageRating       *float64
+	OnTimeRate          *float64
+	WithdrawalRate      *float64
+	BidsSubmitted       int
+	BidsWithdrawn       int
+	BidsDecided         int
+	BidsApproved        int
+	Ratings             int
+	MilestonesCompleted int
+	MilestonesOnTime    int
+}
+
+type Reputation interface {
+	GetOrganizationReputation(ctx context.Context, organizationID uuid.UUID) (*ReputationOutput, error)
+}
+type DebarmentOutput struct {
+	ID                  uuid.UUID
+	OrganizationID      *uuid.UUID
+	EmployeeID          *uuid.UUID
+	BuyerOrganizationID *uuid.UUID
+	Reason              string
+	ExpiresAt           *time.Time
+	CreatedBy           uuid.UUID
+	CreatedAt           time.Time
+	LiftedAt            *time.Time
+	Active              bool
+}
+
+// CreateDebarmentInput debars either an organization or an employee. A nil
+// BuyerOrganizationID debars them from every tender, a nil ExpiresAt until
+// the debarment is lifted.
+type CreateDebarmentInput struct {
+	OrganizationID      *uuid.UUID
+	EmployeeID          *uuid.UUID
+	BuyerOrganizationID *uuid.UUID
+	Reason              string
+	ExpiresAt           *time.Time
+	CreatedBy           uuid.UUID
+}
+
+type UpdateDebarmentInput struct {
+	DebarmentID uuid.UUID
+	Reason      string
+	ExpiresAt   *time.Time
+}
+
+type GetDebarmentsInput struct {
+	ActiveOnly bool
+	Limit      int
+	Offset     int
+}
+
+type DebarmentBlockOutput struct {
+	ID          uuid.UUID
+	DebarmentID uuid.UUID
+	TenderID    uuid.UUID
+	EmployeeID  uuid.UUID
+	AttemptedAt time.Time
+}
+
+type Debarment interface {
+	CreateDebarment(ctx context.Context, input *CreateDebarmentInput) (*DebarmentOutput, error)
+	GetDebarments(ctx context.Context, input *GetDebarmentsInput) ([]*DebarmentOutput, error)
+	UpdateDebarment(ctx context.Context, input *UpdateDebarmentInput) (*DebarmentOutput, error)
+	LiftDebarment(ctx context.Context, debarmentID uuid.UUID) (*DebarmentOutput, error)
+	GetDebarmentBlocks(ctx context.Context, limit, offset int) ([]*DebarmentBlockOutput, error)
+}
+
+type DeclareRelationshipInput struct {
+	EmployeeID     uuid.UUID
+	OrganizationID uuid.UUID
+	Relationship   string
+	Description    string
+}
+
+type ConflictDeclarationOutput struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Relationship   string
+	Description    string
+	CreatedAt      time.Time
+}
+
+type ConflictFlagOutput struct {
+	ID             uuid.UUID
+	BidID          uuid.UUID
+	EmployeeID     uuid.UUID
+	OrganizationID *uuid.UUID
+	Stage          string
+	Rule           string
+	Detail         string
+	CreatedAt      time.Time
+}
+
+type Conflict interface {
+	DeclareRelationship(ctx context.Context, input *DeclareRelationshipInput) (*ConflictDeclarationOutput, error)
+	GetDeclarations(ctx context.Context, employeeID uuid.UUID) ([]*ConflictDeclarationOutput, error)
+	DeleteDeclaration(ctx context.Context, declarationID, employeeID uuid.UUID) error
+	GetTenderConflicts(ctx context.Context, tenderID uuid.UUID) ([]*ConflictFlagOutput, error)
+}
+
+type ApprovalPolicyOutput struct {
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	Name              string
+	MinBudget         *string
+	RequiredApprovals int
+	CreatedBy         uuid.UUID
+	CreatedAt         time.Time
+}
+
+// CreateApprovalPolicyInput requires RequiredApprovals sign-offs before the
+// organization's tenders with at least MinBudget are published. An empty
+// MinBudget applies the policy to every tender.
+type CreateApprovalPolicyInput struct {
+	OrganizationID    uuid.UUID
+	Name              string
+	MinBudget         string
+	RequiredApprovals int
+	CreatedBy         uuid.UUID
+}
+
+type ApprovalStepOutput struct {
+	ApproverID uuid.UUID
+	Decision   string
+	Comment    string
+	CreatedAt  time.Time
+}
+
+type ApprovalRequestOutput struct {
+	ID                uuid.UUID
+	TenderID          uuid.UUID
+	PolicyID          *uuid.UUID
+	RequiredApprovals int
+	RequestedBy       uuid.UUID
+	Status            string
+	CreatedAt         time.Time
+	DecidedAt         *time.Time
+	Steps             []*ApprovalStepOutput
+}
+
+type DecideApprovalInput struct {
+	TenderID   uuid.UUID
+	ApproverID uuid.UUID
+	Decision   string
+	Comment    string
+}
+
+type Approval interface {
+	CreatePolicy(ctx context.Context, input *CreateApprovalPolicyInput) (*ApprovalPolicyOutput, error)
+	GetPolicies(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*ApprovalPolicyOutput, error)
+	DeletePolicy(ctx context.Context, organizationID, policyID, employeeID uuid.UUID) error
+	DecideApproval(ctx context.Context, input *DecideApprovalInput) (*ApprovalRequestOutput, error)
+	GetTenderApprovals(ctx context.Context, tenderID uuid.UUID) ([]*ApprovalRequestOutput, error)
+}
+
+type TemplateOutput struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Content        entity.TenderTemplateContent
+	Placeholders   []string
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+}
+
+type CreateTemplateInput struct {
+	OrganizationID uuid.UUID
+	Name           string
+	Content        entity.TenderTemplateContent
+	CreatedBy      uuid.UUID
+}
+
+type RenderTemplateInput struct {
+	OrganizationID uuid.UUID
+	TemplateID     uuid.UUID
+	EmployeeID     uuid.UUID
+	Values         map[string]string
+}
+
+type Template interface {
+	CreateTemplate(ctx context.Context, input *CreateTemplateInput) (*TemplateOutput, error)
+	GetTemplates(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*TemplateOutput, error)
+	DeleteTemplate(ctx context.Context, organizationID, templateID, employeeID uuid.UUID) error
+	RenderTemplate(ctx context.Context, input *RenderTemplateInput) (*TemplateOutput, error)
+}
+
+type SeriesOutput struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	TemplateID     uuid.UUID
+	Rule           string
+	StartsAt       time.Time
+	Values         map[string]string
+	SubmissionDays *int
+	AutoPublish    bool
+	Status         string
+	NextRunAt      *time.Time
+	LastError      *string
+	CreatedBy      uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type SeriesInstanceOutput struct {
+	TenderID    uuid.UUID
+	Occurrence  int
+	ScheduledAt time.Time
+	CreatedAt   time.Time
+}
+
+// CreateSeriesInput starts a recurring tender. SubmissionDays, when set,
+// gives each tender a submission deadline that many days after its creation.
+type CreateSeriesInput struct {
+	OrganizationID uuid.UUID
+	TemplateID     uuid.UUID
+	Rule           string
+	StartsAt       time.Time
+	Values         map[string]string
+	SubmissionDays *int
+	AutoPublish    bool
+	CreatedBy      uuid.UUID
+}
+
+// UpdateSeriesInput edits a series; nil fields are left unchanged.
+type UpdateSeriesInput struct {
+	OrganizationID uuid.UUID
+	SeriesID       uuid.UUID
+	EmployeeID     uuid.UUID
+	Rule           *string
+	StartsAt       *time.Time
+	Values         map[string]string
+	SubmissionDays *int
+	AutoPublish    *bool
+}
+
+type Series interface {
+	CreateSeries(ctx context.Context, input *CreateSeriesInput) (*SeriesOutput, error)
+	GetSeries(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*SeriesOutput, error)
+	GetSeriesInstances(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) ([]*SeriesInstanceOutput, error)
+	UpdateSeries(ctx context.Context, input *UpdateSeriesInput) (*SeriesOutput, error)
+	PauseSeries(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) (*SeriesOutput, error)
+	ResumeSeries(ctx context.Context, organizationID, seriesID, employeeID uuid.UUID) (*SeriesOutput, error)
+	RunDue(ctx context.Context, now time.Time) error
+	Run(ctx context.Context, period time.Duration)
+}
+
+type AuditEventOutput struct {
+	ID         uuid.UUID
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	IP         string
+	CreatedAt  time.Time
+	Seq        *int64
+	PrevHash   string
+	Hash       string
+}
+
+type GetAuditEventsInput struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   *uuid.UUID
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type Audit interface {
+	GetAuditEvents(ctx context.Context, input *GetAuditEventsInput) ([]*AuditEventOutput, error)
+}
+
+// ChainBreakOutput is the first link of a chain that does not verify.
+// EntityID is the audit event or the bid of the entry, when there is one.
+type ChainBreakOutput struct {
+	Seq          int64
+	EntityID     *uuid.UUID
+	Reason       string
+	ExpectedHash string
+	ActualHash   string
+}
+
+type ChainVerificationOutput struct {
+	Chain       string
+	Valid       bool
+	Length      int64
+	HeadSeq     int64
+	HeadHash    string
+	Checkpoints int
+	Break       *ChainBreakOutput
+	VerifiedAt  time.Time
+}
+
+type ChainCheckpointOutput struct {
+	ID        uuid.UUID
+	Chain     string
+	Seq       int64
+	Hash      string
+	PublicKey []byte
+	Signature []byte
+	CreatedAt time.Time
+}
+
+type Chain interface {
+	VerifyChain(ctx context.Context, chain string) (*ChainVerificationOutput, error)
+	GetCheckpoints(ctx context.Context, chain string) ([]*ChainCheckpointOutput, error)
+	Checkpoint(ctx context.Context) ([]*ChainCheckpointOutput, error)
+	Run(ctx context.Context, period time.Duration)
+}
+
+type Opening interface {
+	OpenDue(ctx context.Context, now time.Time) error
+	Run(ctx context.Context, period time.Duration)
+}
+
+type Outbox interface {
+	RelayDue(ctx context.Context, now time.Time) error
+	Run(ctx context.Context, period time.Duration)
+}
+
 type Services struct {
 	Tender
 	Employee
 	Organization
 	Bid
+	Evaluation
+	Lot
+	Auction
+	ServiceType
+	Attachment
+	Question
+	Message
+	Invitation
+	Notification
+	Negotiation
+	Contract
+	Reputation
+	Debarment
+	Conflict
+	Approval
+	Template
+	Series
+	Audit
+	Chain
+	Opening
+	Outbox
 }
 
 type ServicesDependencies struct {
-	Repos *repo.Repositories
+	Repos          *repo.Repositories
+	Sealer         *sealer.Sealer
+	Signer         *signer.Signer
+	AdminUsernames []string
+	Blobs          blobstore.BlobStore
+	Attachments    AttachmentLimits
+	Conflicts      ConflictPolicy
+	Sink           eventsink.Sink
 }
 
 func NewServices(deps ServicesDependencies) *Services {
+	conflicts := NewConflictChecker(deps.Repos.Organization, deps.Repos.Conflict, deps.Conflicts)
+	audit := NewAuditor(deps.Repos.Audit, deps.Repos.Bid, deps.Repos.Chain)
+	events := NewEventPublisher(deps.Repos.Outbox)
+	opener := NewBidOpener(deps.Repos.Bid, deps.Repos.Tender, audit, deps.Repos.Transactor, deps.Sealer)
+	tender := NewTenderService(deps.Repos.Tender, deps.Repos.Evaluation, deps.Repos.Lot, deps.Repos.ServiceType, deps.Repos.Bid, deps.Repos.Notification, deps.Repos.Approval, deps.Repos.Attachment, audit, events, deps.Repos.Transactor)
+
 	return &Services{
-		Tender:       NewTenderService(deps.Repos.Tender),
-		Employee:     NewEmployeeService(deps.Repos.Employee),
+		Tender:       tender,
+		Employee:     NewEmployeeService(deps.Repos.Employee, deps.AdminUsernames, audit, deps.Repos.Transactor),
 		Organization: NewOrganizationService(deps.Repos.Organization),
-		Bid:          NewBidService(deps.Repos.Bid),
+		Bid:          NewBidService(deps.Repos.Bid, deps.Repos.Tender, deps.Repos.Evaluation, deps.Repos.Lot, deps.Repos.Auction, deps.Repos.Invitation, deps.Repos.Contract, deps.Repos.Organization, deps.Repos.Reputation, deps.Repos.Debarment, deps.Repos.Employee, conflicts, opener, audit, events, deps.Repos.Transactor, deps.Sealer),
+		Evaluation:   NewEvaluationService(deps.Repos.Evaluation, deps.Repos.Bid, deps.Repos.Tender, conflicts, opener, audit, deps.Repos.Transactor),
+		Lot:          NewLotService(deps.Repos.Lot, deps.Repos.Bid, deps.Repos.Tender, deps.Repos.Evaluation, deps.Repos.Contract, deps.Repos.Organization, conflicts, opener, audit, events, deps.Repos.Transactor),
+		Auction:      NewAuctionService(deps.Repos.Auction, deps.Repos.Bid, deps.Repos.Tender, audit, deps.Repos.Transactor),
+		ServiceType:  NewServiceTypeService(deps.Repos.ServiceType, audit, deps.Repos.Transactor),
+		Attachment:   NewAttachmentService(deps.Repos.Attachment, deps.Repos.Tender, deps.Repos.Bid, deps.Blobs, deps.Attachments, audit, deps.Repos.Transactor),
+		Question:     NewQuestionService(deps.Repos.Question, deps.Repos.Tender, deps.Repos.Organization, deps.Repos.Invitation, audit, deps.Repos.Transactor),
+		Message:      NewMessageService(deps.Repos.Message, deps.Repos.Bid, deps.Repos.Organization, audit, deps.Repos.Transactor),
+		Invitation:   NewInvitationService(deps.Repos.Invitation, deps.Repos.Tender, deps.Repos.Organization, audit, deps.Repos.Transactor),
+		Notification: NewNotificationService(deps.Repos.Notification, audit, deps.Repos.Transactor),
+		Negotiation:  NewNegotiationService(deps.Repos.Negotiation, deps.Repos.Bid, deps.Repos.Tender, deps.Repos.Notification, opener, audit, deps.Repos.Transactor),
+		Reputation:   NewReputationService(deps.Repos.Reputation, deps.Repos.Organization),
+		Debarment:    NewDebarmentService(deps.Repos.Debarment, audit, deps.Repos.Transactor),
+		Contract:     NewContractService(deps.Repos.Contract, deps.Repos.Organization, deps.Repos.Reputation, audit, deps.Repos.Transactor),
+		Conflict:     NewConflictService(deps.Repos.Conflict, audit, deps.Repos.Transactor),
+		Approval:     NewApprovalService(deps.Repos.Approval, deps.Repos.Tender, deps.Repos.Organization, audit, events, deps.Repos.Transactor),
+		Template:     NewTemplateService(deps.Repos.Template, deps.Repos.Organization, audit, deps.Repos.Transactor),
+		Series:       NewSeriesService(deps.Repos.Series, deps.Repos.Template, deps.Repos.Organization, deps.Repos.Employee, tender, audit, deps.Repos.Transactor),
+		Audit:        NewAuditService(deps.Repos.Audit),
+		Chain:        NewChainService(deps.Repos.Chain, deps.Repos.Audit, deps.Repos.Bid, deps.Signer),
+		Opening:      opener,
+		Outbox:       NewOutboxService(deps.Repos.Outbox, deps.Repos.Transactor, deps.Sink),
+	}
+}
+
+// optional converts an empty string into a NULL column value.
+func optional(s string) *string {
+	if s == "" {
+		return nil
 	}
+	return &s
 }
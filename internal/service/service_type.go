@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	sl "log/slog"
+	"slices"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+// defaultLabelLanguage is used when a service type has no label in the
+// requested language.
+const defaultLabelLanguage = "en"
+
+type ServiceTypeService struct {
+	serviceTypeRepo repo.ServiceType
+	audit           *Auditor
+	tx              repo.Transactor
+}
+
+func NewServiceTypeService(serviceTypeRepo repo.ServiceType, audit *Auditor, tx repo.Transactor) *ServiceTypeService {
+	return &ServiceTypeService{
+		serviceTypeRepo: serviceTypeRepo,
+		audit:           audit,
+		tx:              tx,
+	}
+}
+
+func newServiceTypeOutput(st *entity.ServiceType, lang string) *ServiceTypeOutput {
+	label, ok := st.Labels[lang]
+	if !ok {
+		label, ok = st.Labels[defaultLabelLanguage]
+	}
+	if !ok {
+		label = st.Name
+	}
+
+	return &ServiceTypeOutput{
+		ID:        st.ID,
+		Name:      st.Name,
+		Code:      st.Code,
+		ParentID:  st.ParentID,
+		Label:     label,
+		Labels:    st.Labels,
+		Active:    st.Active,
+		CreatedAt: st.CreatedAt,
+	}
+}
+
+// GetServiceTypes lists the whole taxonomy with labels in the requested language.
+func (ss *ServiceTypeService) GetServiceTypes(ctx context.Context, lang string) ([]*ServiceTypeOutput, error) {
+	const op = "service - ServiceTypeService - GetServiceTypes"
+
+	serviceTypes, err := ss.serviceTypeRepo.GetServiceTypes(ctx)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetServiceTypes
+	}
+
+	output := make([]*ServiceTypeOutput, 0, len(serviceTypes))
+	for _, st := range serviceTypes {
+		output = append(output, newServiceTypeOutput(st, lang))
+	}
+	return output, nil
+}
+
+func (ss *ServiceTypeService) CreateServiceType(ctx context.Context, input *CreateServiceTypeInput) (*ServiceTypeOutput, error) {
+	const op = "service - ServiceTypeService - CreateServiceType"
+
+	if input.ParentID != nil {
+		if _, err := ss.serviceTypeRepo.GetServiceTypeByID(ctx, *input.ParentID); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return nil, ErrServiceTypeNotFound
+			}
+			sl.Error(op, sl.Any("error", err.Error()))
+			return nil, ErrCannotCreateServiceType
+		}
+	}
+
+	labels := input.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	var created *entity.ServiceType
+	err := ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = ss.serviceTypeRepo.CreateServiceType(ctx, &entity.ServiceType{
+			Name:     input.Name,
+			Code:     input.Code,
+			ParentID: input.ParentID,
+			Labels:   labels,
+		})
+		if err != nil {
+			return err
+		}
+		return ss.audit.record(ctx, "service_type.create", entity.AuditServiceType, created.ID, nil, newServiceTypeOutput(created, defaultLabelLanguage))
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return nil, ErrServiceTypeAlreadyExists
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateServiceType
+	}
+
+	return newServiceTypeOutput(created, defaultLabelLanguage), nil
+}
+
+// UpdateServiceType edits a node of the taxonomy. Renaming it renames the
+// service type of its tenders as well, and a node cannot be moved below one
+// of its own descendants.
+func (ss *ServiceTypeService) UpdateServiceType(ctx context.Context, input *UpdateServiceTypeInput) (*ServiceTypeOutput, error) {
+	const op = "service - ServiceTypeService - UpdateServiceType"
+
+	var updated *entity.ServiceType
+	err := ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ss.serviceTypeRepo.GetServiceTypeByID(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrServiceTypeNotFound
+			}
+			return err
+		}
+
+		updates := make(map[string]interface{})
+		if input.Name != "" {
+			updates["name"] = input.Name
+		}
+		if input.Code != "" {
+			updates["code"] = input.Code
+		}
+		if input.Labels != nil {
+			updates["labels"] = input.Labels
+		}
+		if input.Active != nil {
+			updates["active"] = *input.Active
+		}
+
+		if input.ParentID != nil {
+			parent, err := ss.serviceTypeRepo.GetServiceTypeByID(ctx, *input.ParentID)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return ErrServiceTypeNotFound
+				}
+				return err
+			}
+
+			descendants, err := ss.serviceTypeRepo.GetDescendantNames(ctx, current.Name)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(descendants, parent.Name) {
+				return ErrServiceTypeCycle
+			}
+			updates["parent_id"] = parent.ID
+		}
+
+		if len(updates) == 0 {
+			updated = current
+			return nil
+		}
+
+		updated, err = ss.serviceTypeRepo.UpdateServiceType(ctx, current.ID, updates)
+		if err != nil {
+			return err
+		}
+		return ss.audit.record(ctx, "service_type.update", entity.AuditServiceType, updated.ID,
+			newServiceTypeOutput(current, defaultLabelLanguage), newServiceTypeOutput(updated, defaultLabelLanguage))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrServiceTypeNotFound), errors.Is(err, ErrServiceTypeCycle):
+			return nil, err
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrServiceTypeAlreadyExists
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateServiceType
+	}
+
+	return newServiceTypeOutput(updated, defaultLabelLanguage), nil
+}
+
+// DeleteServiceType removes a service type nothing refers to. Types still in
+// use should be deactivated instead.
+func (ss *ServiceTypeService) DeleteServiceType(ctx context.Context, id uuid.UUID) error {
+	const op = "service - ServiceTypeService - DeleteServiceType"
+
+	err := ss.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ss.serviceTypeRepo.GetServiceTypeByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err = ss.serviceTypeRepo.DeleteServiceType(ctx, id); err != nil {
+			return err
+		}
+		return ss.audit.record(ctx, "service_type.delete", entity.AuditServiceType, id, newServiceTypeOutput(current, defaultLabelLanguage), nil)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrServiceTypeNotFound
+		case errors.Is(err, repoerrs.ErrInUse):
+			return ErrServiceTypeInUse
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteServiceType
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sl "log/slog"
+	"strings"
+
+	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/repo"
+	"git.codenrock.com/tender/internal/repo/repoerrs"
+	"github.com/google/uuid"
+)
+
+type TemplateService struct {
+	templateRepo     repo.Template
+	organizationRepo repo.Organization
+	audit            *Auditor
+	tx               repo.Transactor
+}
+
+func NewTemplateService(templateRepo repo.Template, organizationRepo repo.Organization, audit *Auditor, tx repo.Transactor) *TemplateService {
+	return &TemplateService{
+		templateRepo:     templateRepo,
+		organizationRepo: organizationRepo,
+		audit:            audit,
+		tx:               tx,
+	}
+}
+
+func newTemplateOutput(t *entity.TenderTemplate) *TemplateOutput {
+	return &TemplateOutput{
+		ID:             t.ID,
+		OrganizationID: t.OrganizationID,
+		Name:           t.Name,
+		Content:        t.Content,
+		Placeholders:   t.Content.Placeholders(),
+		CreatedBy:      t.CreatedBy,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+func (ts *TemplateService) CreateTemplate(ctx context.Context, input *CreateTemplateInput) (*TemplateOutput, error) {
+	const op = "service - TemplateService - CreateTemplate"
+
+	if err := checkOrganizationResponsible(ctx, ts.organizationRepo, input.OrganizationID, input.CreatedBy); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTemplate
+	}
+
+	var template *entity.TenderTemplate
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		template, err = ts.templateRepo.CreateTemplate(ctx, &entity.TenderTemplate{
+			OrganizationID: input.OrganizationID,
+			Name:           input.Name,
+			Content:        input.Content,
+			CreatedBy:      input.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		return ts.audit.record(ctx, "template.create", entity.AuditTemplate, template.ID, nil, newTemplateOutput(template))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return nil, ErrTemplateAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrOrganizationNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTemplate
+	}
+
+	return newTemplateOutput(template), nil
+}
+
+func (ts *TemplateService) GetTemplates(ctx context.Context, organizationID, employeeID uuid.UUID) ([]*TemplateOutput, error) {
+	const op = "service - TemplateService - GetTemplates"
+
+	if err := checkOrganizationResponsible(ctx, ts.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetTemplates
+	}
+
+	templates, err := ts.templateRepo.GetTemplatesByOrganization(ctx, organizationID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetTemplates
+	}
+
+	output := make([]*TemplateOutput, 0, len(templates))
+	for _, template := range templates {
+		output = append(output, newTemplateOutput(template))
+	}
+	return output, nil
+}
+
+func (ts *TemplateService) DeleteTemplate(ctx context.Context, organizationID, templateID, employeeID uuid.UUID) error {
+	const op = "service - TemplateService - DeleteTemplate"
+
+	if err := checkOrganizationResponsible(ctx, ts.organizationRepo, organizationID, employeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteTemplate
+	}
+
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := ts.templateRepo.DeleteTemplate(ctx, templateID, organizationID); err != nil {
+			return err
+		}
+		return ts.audit.record(ctx, "template.delete", entity.AuditTemplate, templateID, nil, nil)
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrTemplateNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return ErrCannotDeleteTemplate
+	}
+	return nil
+}
+
+// RenderTemplate fills the placeholders of a template of the organization.
+// Every placeholder needs a value; the rendered content is then created as a
+// tender like any other.
+func (ts *TemplateService) RenderTemplate(ctx context.Context, input *RenderTemplateInput) (*TemplateOutput, error) {
+	const op = "service - TemplateService - RenderTemplate"
+
+	if err := checkOrganizationResponsible(ctx, ts.organizationRepo, input.OrganizationID, input.EmployeeID); err != nil {
+		if errors.Is(err, ErrNotOrganizationResponsible) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetTemplates
+	}
+
+	template, err := ts.templateRepo.GetTemplateByID(ctx, input.TemplateID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetTemplates
+	}
+	if template.OrganizationID != input.OrganizationID {
+		return nil, ErrTemplateNotFound
+	}
+
+	content, missing := template.Content.Fill(input.Values)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPlaceholderValues, strings.Join(missing, ", "))
+	}
+
+	output := newTemplateOutput(template)
+	output.Content = content
+	return output, nil
+}
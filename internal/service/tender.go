@@ -2,36 +2,215 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	sl "log/slog"
+	"maps"
+	"slices"
+	"strings"
 
 	"git.codenrock.com/tender/internal/entity"
+	"git.codenrock.com/tender/internal/model"
 	"git.codenrock.com/tender/internal/repo"
 	"git.codenrock.com/tender/internal/repo/repoerrs"
 	"github.com/google/uuid"
 )
 
 type TenderService struct {
-	tenderRepo repo.Tender
+	tenderRepo       repo.Tender
+	evaluationRepo   repo.Evaluation
+	lotRepo          repo.Lot
+	serviceTypeRepo  repo.ServiceType
+	bidRepo          repo.Bid
+	notificationRepo repo.Notification
+	approvalRepo     repo.Approval
+	attachmentRepo   repo.Attachment
+	audit            *Auditor
+	events           *EventPublisher
+	tx               repo.Transactor
 }
 
-func NewTenderService(tenderRepo repo.Tender) *TenderService {
+func NewTenderService(tenderRepo repo.Tender, evaluationRepo repo.Evaluation, lotRepo repo.Lot, serviceTypeRepo repo.ServiceType, bidRepo repo.Bid, notificationRepo repo.Notification, approvalRepo repo.Approval, attachmentRepo repo.Attachment, audit *Auditor, events *EventPublisher, tx repo.Transactor) *TenderService {
 	return &TenderService{
-		tenderRepo: tenderRepo,
+		tenderRepo:       tenderRepo,
+		evaluationRepo:   evaluationRepo,
+		lotRepo:          lotRepo,
+		serviceTypeRepo:  serviceTypeRepo,
+		bidRepo:          bidRepo,
+		notificationRepo: notificationRepo,
+		approvalRepo:     approvalRepo,
+		attachmentRepo:   attachmentRepo,
+		audit:            audit,
+		events:           events,
+		tx:               tx,
 	}
 }
+
+func newTenderOutput(t *entity.Tender) *TenderOutput {
+	return &TenderOutput{
+		ID:                   t.ID,
+		Name:                 t.Name,
+		Description:          t.Description,
+		ServiceType:          t.ServiceType,
+		Status:               t.Status,
+		Version:              t.Version,
+		OrganizationID:       t.OrganizationID,
+		CreatorUsername:      t.CreatorUsername,
+		Sealed:               t.Sealed,
+		SubmissionDeadline:   t.SubmissionDeadline,
+		OpenedAt:             t.OpenedAt,
+		Budget:               t.Budget,
+		Currency:             t.Currency,
+		AuctionStartsAt:      t.AuctionStartsAt,
+		AuctionEndsAt:        t.AuctionEndsAt,
+		AccessMode:           t.AccessMode,
+		CancellationCategory: t.CancellationCategory,
+		CancellationReason:   t.CancellationReason,
+		CanceledAt:           t.CanceledAt,
+		CreatedAt:            t.CreatedAt,
+	}
+}
+
+// checkServiceType makes sure that a tender uses an active service type and
+// that a reverse auction is about deliveries.
+func (ts *TenderService) checkServiceType(ctx context.Context, name string, auction bool) error {
+	serviceType, err := ts.serviceTypeRepo.GetServiceTypeByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrInvalidServiceType
+		}
+		return err
+	}
+	if !serviceType.Active {
+		return ErrInvalidServiceType
+	}
+	if !auction {
+		return nil
+	}
+
+	deliveryTypes, err := ts.serviceTypeRepo.GetDescendantNames(ctx, auctionServiceType)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(deliveryTypes, name) {
+		return ErrAuctionServiceType
+	}
+	return nil
+}
+
+// validateTender holds a new tender to the rules of the tender requests, so
+// that the tenders created without one, such as the runs of a series, meet
+// them too.
+func validateTender(ctx context.Context, input *CreateTenderInput) error {
+	tender := model.Tender{
+		Name:               input.Name,
+		Description:        input.Description,
+		ServiceType:        input.ServiceType,
+		OrganizationID:     input.OrganizationID.String(),
+		CreatorUsername:    input.CreatorUsername,
+		Sealed:             input.Sealed,
+		SubmissionDeadline: input.SubmissionDeadline,
+		Budget:             input.Budget,
+		Currency:           input.Currency,
+		AuctionStartsAt:    input.AuctionStartsAt,
+		AuctionEndsAt:      input.AuctionEndsAt,
+		AccessMode:         input.AccessMode,
+	}
+	for _, c := range input.Criteria {
+		tender.Criteria = append(tender.Criteria, model.Criterion{Name: c.Name, Weight: json.Number(c.Weight)})
+	}
+	for _, l := range input.Lots {
+		tender.Lots = append(tender.Lots, model.Lot{Name: l.Name, Description: l.Description, Budget: l.Budget})
+	}
+
+	problems := tender.Valid(ctx)
+	if len(problems) == 0 {
+		return nil
+	}
+	fields := slices.Sorted(maps.Keys(problems))
+	for i, field := range fields {
+		fields[i] = field + ": " + problems[field]
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidTender, strings.Join(fields, "; "))
+}
+
 func (ts *TenderService) CreateTender(ctx context.Context, input *CreateTenderInput) (*TenderOutput, error) {
 	const op = "service - TenderService - CreateTender"
 
+	if err := validateTender(ctx, input); err != nil {
+		return nil, err
+	}
+	if err := ts.checkServiceType(ctx, input.ServiceType, input.AuctionStartsAt != nil); err != nil {
+		if errors.Is(err, ErrInvalidServiceType) || errors.Is(err, ErrAuctionServiceType) {
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+
 	tender := &entity.Tender{
 		Name:            input.Name,
 		Description:     input.Description,
 		ServiceType:     input.ServiceType,
 		OrganizationID:  input.OrganizationID,
 		CreatorUsername: input.CreatorUsername,
+		Sealed:          input.Sealed,
+		Budget:          optional(input.Budget),
+		Currency:        optional(input.Currency),
+		AccessMode:      input.AccessMode,
 	}
+	if tender.AccessMode == "" {
+		tender.AccessMode = entity.TenderAccessOpen
+	}
+	if input.SubmissionDeadline != nil {
+		deadline := input.SubmissionDeadline.UTC()
+		tender.SubmissionDeadline = &deadline
+	}
+	if input.AuctionStartsAt != nil && input.AuctionEndsAt != nil {
+		startsAt, endsAt := input.AuctionStartsAt.UTC(), input.AuctionEndsAt.UTC()
+		tender.AuctionStartsAt, tender.AuctionEndsAt = &startsAt, &endsAt
+	}
+
+	var result *TenderOutput
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		output, err := ts.tenderRepo.CreateTender(ctx, tender)
+		if err != nil {
+			return err
+		}
+		result = newTenderOutput(output)
 
-	output, err := ts.tenderRepo.CreateTender(ctx, tender)
+		if len(input.Criteria) > 0 {
+			criteria := make([]*entity.Criterion, 0, len(input.Criteria))
+			for _, c := range input.Criteria {
+				criteria = append(criteria, &entity.Criterion{Name: c.Name, Weight: c.Weight})
+			}
+
+			created, err := ts.evaluationRepo.CreateCriteria(ctx, output.ID, criteria)
+			if err != nil {
+				return err
+			}
+			result.Criteria = newCriterionOutputs(created)
+		}
+
+		if len(input.Lots) > 0 {
+			lots := make([]*entity.Lot, 0, len(input.Lots))
+			for _, l := range input.Lots {
+				lots = append(lots, &entity.Lot{Name: l.Name, Description: l.Description, Budget: optional(l.Budget)})
+			}
+
+			created, err := ts.lotRepo.CreateLots(ctx, output.ID, lots)
+			if err != nil {
+				return err
+			}
+			result.Lots = newLotOutputs(created)
+		}
+
+		if err = ts.events.publish(ctx, entity.EventTenderCreated, entity.AggregateTender, result.ID, result); err != nil {
+			return err
+		}
+		return ts.audit.record(ctx, "tender.create", entity.AuditTender, result.ID, nil, result)
+	})
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrAlreadyExists) {
 			return nil, ErrTenderAlreadyExists
@@ -40,21 +219,133 @@ func (ts *TenderService) CreateTender(ctx context.Context, input *CreateTenderIn
 		return nil, ErrCannotCreateTender
 	}
 
-	return &TenderOutput{
-		ID:          output.ID,
-		Name:        output.Name,
-		Description: output.Description,
-		Status:      output.Status,
-		ServiceType: output.ServiceType,
-		Version:     output.Version,
-		CreatedAt:   output.CreatedAt,
-	}, nil
+	return result, nil
+}
+
+// DraftClone builds the tender input of a copy of a tender, with its
+// criteria and lots, the overrides of input applied. A sealed tender or a
+// reverse auction needs a new schedule, since the one of the source is
+// usually over. The draft is meant to be validated like any new tender and
+// then given to CloneTender.
+func (ts *TenderService) DraftClone(ctx context.Context, input *CloneTenderInput) (*CreateTenderInput, error) {
+	const op = "service - TenderService - DraftClone"
+
+	source, err := ts.tenderRepo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+	if (source.Sealed && input.SubmissionDeadline == nil) || (source.IsAuction() && input.AuctionEndsAt == nil) {
+		return nil, ErrCloneScheduleRequired
+	}
+
+	criteria, err := ts.evaluationRepo.GetCriteriaByTender(ctx, source.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+	lots, err := ts.lotRepo.GetLotsByTender(ctx, source.ID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+
+	clone := &CreateTenderInput{
+		Name:               source.Name,
+		Description:        source.Description,
+		ServiceType:        source.ServiceType,
+		OrganizationID:     source.OrganizationID,
+		CreatorUsername:    input.CreatorUsername,
+		Sealed:             source.Sealed,
+		SubmissionDeadline: input.SubmissionDeadline,
+		AuctionStartsAt:    input.AuctionStartsAt,
+		AuctionEndsAt:      input.AuctionEndsAt,
+		AccessMode:         source.AccessMode,
+	}
+	if input.Name != "" {
+		clone.Name = input.Name
+	}
+	if source.Budget != nil {
+		clone.Budget = *source.Budget
+	}
+	if source.Currency != nil {
+		clone.Currency = *source.Currency
+	}
+	for _, c := range criteria {
+		clone.Criteria = append(clone.Criteria, CriterionInput{Name: c.Name, Weight: c.Weight})
+	}
+	for _, l := range lots {
+		lot := LotInput{Name: l.Name, Description: l.Description}
+		if l.Budget != nil {
+			lot.Budget = *l.Budget
+		}
+		clone.Lots = append(clone.Lots, lot)
+	}
+
+	return clone, nil
+}
+
+// CloneTender creates the validated draft of a copy of the source tender
+// through CreateTender and copies the attachments of the source. Attachment
+// contents are immutable, so the copies share the stored files of the
+// source.
+func (ts *TenderService) CloneTender(ctx context.Context, sourceID uuid.UUID, input *CreateTenderInput) (*TenderOutput, error) {
+	const op = "service - TenderService - CloneTender"
+
+	attachments, err := ts.attachmentRepo.GetAttachmentsByTender(ctx, sourceID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+
+	var result *TenderOutput
+	err = ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		result, err = ts.CreateTender(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range attachments {
+			_, err = ts.attachmentRepo.CreateAttachment(ctx, &entity.Attachment{
+				ID:          uuid.New(),
+				TenderID:    &result.ID,
+				FileName:    a.FileName,
+				ContentType: a.ContentType,
+				Size:        a.Size,
+				Checksum:    a.Checksum,
+				StorageKey:  a.StorageKey,
+				UploadedBy:  a.UploadedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return ts.audit.record(ctx, "tender.clone", entity.AuditTender, result.ID, nil, map[string]any{
+			"SourceTenderID": sourceID,
+			"Attachments":    len(attachments),
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidTender), errors.Is(err, ErrInvalidServiceType), errors.Is(err, ErrAuctionServiceType), errors.Is(err, ErrTenderAlreadyExists):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotCreateTender
+	}
+
+	sl.Info(op, sl.Any("source_tender_id", sourceID), sl.Any("tender_id", result.ID))
+	return result, nil
 }
 
 func (ts *TenderService) GetTenders(ctx context.Context, input *GetTendersInput) ([]*TenderOutput, error) {
 	const op = "service - TenderService - GetTenders"
 
-	tenders, err := ts.tenderRepo.GetTenders(ctx, input.Limit, input.Offset, input.ServiceTypes)
+	tenders, err := ts.tenderRepo.GetTenders(ctx, input.Limit, input.Offset, input.ServiceTypes, input.ViewerID)
 	if err != nil {
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, ErrCannotGetTenders
@@ -62,15 +353,7 @@ func (ts *TenderService) GetTenders(ctx context.Context, input *GetTendersInput)
 
 	result := make([]*TenderOutput, 0, len(tenders))
 	for _, t := range tenders {
-		result = append(result, &TenderOutput{
-			ID:          t.ID,
-			Name:        t.Name,
-			Description: t.Description,
-			ServiceType: t.ServiceType,
-			Status:      t.Status,
-			Version:     t.Version,
-			CreatedAt:   t.CreatedAt,
-		})
+		result = append(result, newTenderOutput(t))
 	}
 
 	return result, nil
@@ -87,15 +370,7 @@ func (ts *TenderService) GetUserTenders(ctx context.Context, input *GetUserTende
 
 	result := make([]*TenderOutput, 0, len(tenders))
 	for _, t := range tenders {
-		result = append(result, &TenderOutput{
-			ID:          t.ID,
-			Name:        t.Name,
-			Description: t.Description,
-			ServiceType: t.ServiceType,
-			Status:      t.Status,
-			Version:     t.Version,
-			CreatedAt:   t.CreatedAt,
-		})
+		result = append(result, newTenderOutput(t))
 	}
 
 	return result, nil
@@ -114,74 +389,263 @@ func (ts *TenderService) GetTenderByID(ctx context.Context, tenderID uuid.UUID)
 		return nil, ErrCannotGetTender
 	}
 
-	return &TenderOutput{
-		ID:              tender.ID,
-		Name:            tender.Name,
-		Description:     tender.Description,
-		ServiceType:     tender.ServiceType,
-		Status:          tender.Status,
-		Version:         tender.Version,
-		CreatorUsername: tender.CreatorUsername,
-		OrganizationID:  tender.OrganizationID,
-		CreatedAt:       tender.CreatedAt,
-	}, nil
+	return newTenderOutput(tender), nil
 }
 
 func (ts *TenderService) UpdateTenderStatus(ctx context.Context, input *UpdateTenderStatusInput) (*TenderOutput, error) {
 	const op = "service - TenderService - UpdateTenderStatus"
 
-	tender, err := ts.tenderRepo.UpdateTenderStatus(ctx, input.TenderID, input.Status)
+	var result *TenderOutput
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ts.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		if current.IsCanceled() {
+			return ErrTenderCanceled
+		}
+
+		if input.Status == entity.TenderStatusPublished && current.Status != entity.TenderStatusPublished {
+			request, err := requestApproval(ctx, ts.approvalRepo, ts.audit, current, input.RequesterID)
+			if err != nil {
+				return err
+			}
+			if request != nil {
+				result = newTenderOutput(current)
+				result.ApprovalRequestID = &request.ID
+				return nil
+			}
+		}
+
+		tender, err := ts.tenderRepo.UpdateTenderStatus(ctx, input.TenderID, input.Status)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		result = newTenderOutput(tender)
+
+		if err = ts.events.publish(ctx, entity.TenderStatusEvent(tender.Status), entity.AggregateTender, tender.ID, result); err != nil {
+			return err
+		}
+		return ts.audit.record(ctx, "tender.update_status", entity.AuditTender, tender.ID, newTenderOutput(current), result)
+	})
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			sl.Error(op, sl.Any("error", "Tender not found"))
-			return nil, ErrTenderNotFound
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrApprovalPending):
+			return nil, err
 		}
 		sl.Error(op, sl.Any("error", err.Error()))
 		return nil, ErrCannotUpdateTenderStatus
 	}
 
-	return &TenderOutput{
-		ID:          tender.ID,
-		Name:        tender.Name,
-		Description: tender.Description,
-		ServiceType: tender.ServiceType,
-		Status:      tender.Status,
-		Version:     tender.Version,
-		CreatedAt:   tender.CreatedAt,
-	}, nil
+	return result, nil
 }
 
+// UpdateTender edits a tender. Edits of a published tender are amendments:
+// they need a reason, bump the tender version, and every active bid has to be
+// acknowledged or revised by its author before it can be awarded. A budget
+// change must stay within the sign-off the publication got.
 func (ts *TenderService) UpdateTender(ctx context.Context, input *UpdateTenderInput) (*TenderOutput, error) {
 	const op = "service - TenderService - UpdateTender"
 
-	updates := make(map[string]interface{})
-	if input.Name != "" {
-		updates["name"] = input.Name
+	var updated *entity.Tender
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ts.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		if current.IsCanceled() {
+			return ErrTenderCanceled
+		}
+
+		updates := make(map[string]interface{})
+		changes := make(map[string]entity.AmendmentChange)
+		set := func(column string, old *string, value string) {
+			if value == "" || (old != nil && *old == value) {
+				return
+			}
+			updates[column] = value
+			changes[column] = entity.AmendmentChange{Old: old, New: &value}
+		}
+
+		set("name", &current.Name, input.Name)
+		set("description", &current.Description, input.Description)
+		if input.ServiceType != "" && input.ServiceType != current.ServiceType {
+			if err = ts.checkServiceType(ctx, input.ServiceType, current.IsAuction()); err != nil {
+				return err
+			}
+			set("service_type", &current.ServiceType, input.ServiceType)
+		}
+		set("budget", current.Budget, input.Budget)
+		set("currency", current.Currency, input.Currency)
+		if _, ok := updates["budget"]; ok {
+			if err = checkBudgetChange(ctx, ts.approvalRepo, current, input.Budget); err != nil {
+				return err
+			}
+		}
+
+		if len(updates) == 0 {
+			updated = current
+			return nil
+		}
+		if current.Status != entity.TenderStatusPublished {
+			if updated, err = ts.tenderRepo.UpdateTender(ctx, current.ID, updates); err != nil {
+				return err
+			}
+			if err = ts.events.publish(ctx, entity.EventTenderUpdated, entity.AggregateTender, updated.ID, newTenderOutput(updated)); err != nil {
+				return err
+			}
+			return ts.audit.record(ctx, "tender.update", entity.AuditTender, updated.ID, newTenderOutput(current), newTenderOutput(updated))
+		}
+
+		if input.Reason == "" {
+			return ErrAmendmentReasonRequired
+		}
+		updates["version"] = current.Version + 1
+		if updated, err = ts.tenderRepo.UpdateTender(ctx, current.ID, updates); err != nil {
+			return err
+		}
+
+		_, err = ts.tenderRepo.CreateTenderAmendment(ctx, &entity.TenderAmendment{
+			TenderID:  updated.ID,
+			Version:   updated.Version,
+			Reason:    input.Reason,
+			Changes:   changes,
+			CreatedBy: input.EditorUsername,
+		})
+		if err != nil {
+			return err
+		}
+
+		bids, err := ts.bidRepo.RequireAcknowledgement(ctx, updated.ID)
+		if err != nil {
+			return err
+		}
+		for _, bid := range bids {
+			_, err = ts.notificationRepo.CreateNotification(ctx, &entity.Notification{
+				RecipientID: bid.AuthorID,
+				Type:        entity.NotificationTenderAmended,
+				TenderID:    &updated.ID,
+				BidID:       &bid.ID,
+				Message: fmt.Sprintf("Tender %q was amended (version %d): %s. Acknowledge or revise your bid to stay eligible for award.",
+					updated.Name, updated.Version, input.Reason),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		sl.Info(op, sl.Any("tender_id", updated.ID), sl.Any("version", updated.Version), sl.Any("bids_to_acknowledge", len(bids)))
+
+		if err = ts.events.publish(ctx, entity.EventTenderAmended, entity.AggregateTender, updated.ID, newTenderOutput(updated)); err != nil {
+			return err
+		}
+		return ts.audit.record(ctx, "tender.amend", entity.AuditTender, updated.ID, newTenderOutput(current), newTenderOutput(updated))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrInvalidServiceType), errors.Is(err, ErrAuctionServiceType),
+			errors.Is(err, ErrAmendmentReasonRequired), errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrApprovalPending),
+			errors.Is(err, ErrBudgetNeedsApproval):
+			return nil, err
+		}
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotUpdateTender
 	}
-	if input.Description != "" {
-		updates["description"] = input.Description
+
+	return newTenderOutput(updated), nil
+}
+
+func (ts *TenderService) GetTenderAmendments(ctx context.Context, tenderID uuid.UUID) ([]*TenderAmendmentOutput, error) {
+	const op = "service - TenderService - GetTenderAmendments"
+
+	amendments, err := ts.tenderRepo.GetTenderAmendments(ctx, tenderID)
+	if err != nil {
+		sl.Error(op, sl.Any("error", err.Error()))
+		return nil, ErrCannotGetAmendments
 	}
-	if input.ServiceType != "" {
-		updates["service_Type"] = input.ServiceType
+
+	output := make([]*TenderAmendmentOutput, 0, len(amendments))
+	for _, a := range amendments {
+		output = append(output, &TenderAmendmentOutput{
+			ID:        a.ID,
+			TenderID:  a.TenderID,
+			Version:   a.Version,
+			Reason:    a.Reason,
+			Changes:   a.Changes,
+			CreatedBy: a.CreatedBy,
+			CreatedAt: a.CreatedAt,
+		})
 	}
+	return output, nil
+}
+
+// CancelTender calls the procurement off. Every active bid is canceled with
+// it and its author is notified.
+func (ts *TenderService) CancelTender(ctx context.Context, input *CancelTenderInput) (*TenderOutput, error) {
+	const op = "service - TenderService - CancelTender"
+
+	var canceled *entity.Tender
+	err := ts.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := ts.tenderRepo.GetTenderForUpdate(ctx, input.TenderID)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			return err
+		}
+		switch current.Status {
+		case entity.TenderStatusCanceled:
+			return ErrTenderCanceled
+		case entity.TenderStatusClosed:
+			return ErrTenderAlreadyClosed
+		}
+
+		if canceled, err = ts.tenderRepo.CancelTender(ctx, current.ID, input.Category, input.Reason); err != nil {
+			return err
+		}
+		if err = ts.audit.record(ctx, "tender.cancel", entity.AuditTender, canceled.ID, newTenderOutput(current), newTenderOutput(canceled)); err != nil {
+			return err
+		}
+		if err = ts.events.publish(ctx, entity.EventTenderCanceled, entity.AggregateTender, canceled.ID, newTenderOutput(canceled)); err != nil {
+			return err
+		}
+
+		bids, err := ts.bidRepo.CancelBidsByTender(ctx, canceled.ID)
+		if err != nil {
+			return err
+		}
+		for _, bid := range bids {
+			_, err = ts.notificationRepo.CreateNotification(ctx, &entity.Notification{
+				RecipientID: bid.AuthorID,
+				Type:        entity.NotificationTenderCanceled,
+				TenderID:    &canceled.ID,
+				BidID:       &bid.ID,
+				Message:     fmt.Sprintf("Tender %q was canceled (%s): %s. Your bid was canceled with it.", canceled.Name, input.Category, input.Reason),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		sl.Info(op, sl.Any("tender_id", canceled.ID), sl.Any("category", input.Category), sl.Any("canceled_bids", len(bids)))
 
-	tender, err := ts.tenderRepo.UpdateTender(ctx, input.TenderID, updates)
+		return nil
+	})
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			sl.Error(op, sl.Any("error", "Tender not found"))
-			return nil, ErrTenderNotFound
+		switch {
+		case errors.Is(err, ErrTenderNotFound), errors.Is(err, ErrTenderCanceled), errors.Is(err, ErrTenderAlreadyClosed):
+			return nil, err
 		}
 		sl.Error(op, sl.Any("error", err.Error()))
-		return nil, ErrCannotUpdateTender
+		return nil, ErrCannotCancelTender
 	}
 
-	return &TenderOutput{
-		ID:          tender.ID,
-		Name:        tender.Name,
-		Description: tender.Description,
-		ServiceType: tender.ServiceType,
-		Status:      tender.Status,
-		Version:     tender.Version,
-		CreatedAt:   tender.CreatedAt,
-	}, nil
+	return newTenderOutput(canceled), nil
 }
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS tender_opening;
+
+ALTER TABLE bid
+  DROP COLUMN IF EXISTS sealed_payload;
+
+ALTER TABLE tender
+  DROP COLUMN IF EXISTS opened_at,
+  DROP COLUMN IF EXISTS submission_deadline,
+  DROP COLUMN IF EXISTS sealed;
@@ -0,0 +1,14 @@
+ALTER TABLE tender
+  ADD COLUMN sealed BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN submission_deadline TIMESTAMP,
+  ADD COLUMN opened_at TIMESTAMP;
+
+ALTER TABLE bid
+  ADD COLUMN sealed_payload BYTEA;
+
+CREATE TABLE tender_opening (
+  id UUID PRIMARY KEY,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  bid_count INT NOT NULL,
+  opened_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+ALTER TABLE bid
+  DROP COLUMN IF EXISTS validity_days,
+  DROP COLUMN IF EXISTS delivery_days,
+  DROP COLUMN IF EXISTS currency,
+  DROP COLUMN IF EXISTS price;
+
+ALTER TABLE tender
+  DROP COLUMN IF EXISTS currency,
+  DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,9 @@
+ALTER TABLE tender
+  ADD COLUMN budget NUMERIC(18, 2),
+  ADD COLUMN currency VARCHAR(3);
+
+ALTER TABLE bid
+  ADD COLUMN price NUMERIC(18, 2),
+  ADD COLUMN currency VARCHAR(3),
+  ADD COLUMN delivery_days INT,
+  ADD COLUMN validity_days INT;
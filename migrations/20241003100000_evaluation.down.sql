@@ -0,0 +1,6 @@
+ALTER TABLE bid
+  DROP COLUMN IF EXISTS total_score;
+
+DROP TABLE IF EXISTS bid_score;
+
+DROP TABLE IF EXISTS tender_criterion;
@@ -0,0 +1,21 @@
+CREATE TABLE tender_criterion (
+  id UUID PRIMARY KEY,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  weight NUMERIC(5, 2) NOT NULL CHECK (weight > 0 AND weight <= 100),
+  UNIQUE (tender_id, name)
+);
+
+CREATE TABLE bid_score (
+  id UUID PRIMARY KEY,
+  bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+  criterion_id UUID REFERENCES tender_criterion(id) ON DELETE CASCADE,
+  reviewer_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+  score NUMERIC(5, 2) NOT NULL CHECK (score >= 0 AND score <= 100),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (bid_id, criterion_id, reviewer_id)
+);
+
+ALTER TABLE bid
+  ADD COLUMN total_score NUMERIC(6, 2);
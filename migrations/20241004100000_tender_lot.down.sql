@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bid_lot;
+
+DROP TABLE IF EXISTS tender_lot;
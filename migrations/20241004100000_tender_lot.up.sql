@@ -0,0 +1,17 @@
+CREATE TABLE tender_lot (
+  id UUID PRIMARY KEY,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  description TEXT NOT NULL,
+  budget NUMERIC(18, 2),
+  status VARCHAR(50) NOT NULL DEFAULT 'Open',
+  winner_bid_id UUID REFERENCES bid(id) ON DELETE SET NULL,
+  decided_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bid_lot (
+  bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+  lot_id UUID REFERENCES tender_lot(id) ON DELETE CASCADE,
+  PRIMARY KEY (bid_id, lot_id)
+);
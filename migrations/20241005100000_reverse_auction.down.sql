@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS auction_offer;
+
+ALTER TABLE tender
+  DROP COLUMN IF EXISTS auction_ends_at,
+  DROP COLUMN IF EXISTS auction_starts_at;
@@ -0,0 +1,13 @@
+ALTER TABLE tender
+  ADD COLUMN auction_starts_at TIMESTAMP,
+  ADD COLUMN auction_ends_at TIMESTAMP;
+
+CREATE TABLE auction_offer (
+  id UUID PRIMARY KEY,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+  price NUMERIC(18, 2) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX auction_offer_tender_id_idx ON auction_offer (tender_id, created_at);
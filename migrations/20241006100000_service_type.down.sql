@@ -0,0 +1,4 @@
+ALTER TABLE tender
+  DROP CONSTRAINT IF EXISTS tender_service_type_fkey;
+
+DROP TABLE IF EXISTS service_type;
@@ -0,0 +1,19 @@
+CREATE TABLE service_type (
+  id UUID PRIMARY KEY,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  code VARCHAR(20) NOT NULL UNIQUE,
+  parent_id UUID REFERENCES service_type(id) ON DELETE RESTRICT,
+  labels JSONB NOT NULL DEFAULT '{}',
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX service_type_parent_id_idx ON service_type (parent_id);
+
+INSERT INTO service_type (id, name, code, labels) VALUES
+  (gen_random_uuid(), 'Construction', '45000000-7', '{"en": "Construction", "ru": "Строительство"}'),
+  (gen_random_uuid(), 'Delivery', '60000000-8', '{"en": "Delivery", "ru": "Доставка"}'),
+  (gen_random_uuid(), 'Manufacture', '42000000-6', '{"en": "Manufacture", "ru": "Производство"}');
+
+ALTER TABLE tender
+  ADD CONSTRAINT tender_service_type_fkey FOREIGN KEY (service_type) REFERENCES service_type(name) ON UPDATE CASCADE;
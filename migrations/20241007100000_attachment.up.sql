@@ -0,0 +1,16 @@
+CREATE TABLE attachment (
+  id UUID PRIMARY KEY,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+  file_name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL,
+  checksum VARCHAR(64) NOT NULL,
+  storage_key VARCHAR(255) NOT NULL UNIQUE,
+  uploaded_by UUID REFERENCES employee(id),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  CHECK ((tender_id IS NULL) <> (bid_id IS NULL))
+);
+
+CREATE INDEX attachment_tender_id_idx ON attachment (tender_id);
+CREATE INDEX attachment_bid_id_idx ON attachment (bid_id);
@@ -0,0 +1,14 @@
+CREATE TABLE tender_question (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  question TEXT NOT NULL,
+  answer TEXT,
+  answered_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+  visibility VARCHAR(10) CHECK (visibility IN ('Public', 'Private')),
+  tender_version INT,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  answered_at TIMESTAMP
+);
+
+CREATE INDEX tender_question_tender_id_idx ON tender_question (tender_id);
@@ -0,0 +1,10 @@
+CREATE TABLE bid_message (
+  id UUID PRIMARY KEY,
+  bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+  sender_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  read_at TIMESTAMP
+);
+
+CREATE INDEX bid_message_bid_id_created_at_idx ON bid_message (bid_id, created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tender_invitation;
+
+ALTER TABLE tender
+  DROP COLUMN IF EXISTS access_mode;
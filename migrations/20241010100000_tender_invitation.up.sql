@@ -0,0 +1,13 @@
+ALTER TABLE tender
+  ADD COLUMN access_mode VARCHAR(20) NOT NULL DEFAULT 'Open' CHECK (access_mode IN ('Open', 'InvitationOnly'));
+
+CREATE TABLE tender_invitation (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+  employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  CHECK ((organization_id IS NULL) <> (employee_id IS NULL)),
+  UNIQUE (tender_id, organization_id),
+  UNIQUE (tender_id, employee_id)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notification;
+
+ALTER TABLE bid
+  DROP COLUMN IF EXISTS needs_ack;
+
+DROP TABLE IF EXISTS tender_amendment;
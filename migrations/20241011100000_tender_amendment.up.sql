@@ -0,0 +1,27 @@
+CREATE TABLE tender_amendment (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  version INT NOT NULL,
+  reason TEXT NOT NULL,
+  changes JSONB NOT NULL,
+  created_by VARCHAR(50) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX tender_amendment_tender_id_idx ON tender_amendment (tender_id);
+
+ALTER TABLE bid
+  ADD COLUMN needs_ack BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE notification (
+  id UUID PRIMARY KEY,
+  recipient_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  type VARCHAR(50) NOT NULL,
+  tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+  bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+  message TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  read_at TIMESTAMP
+);
+
+CREATE INDEX notification_recipient_id_created_at_idx ON notification (recipient_id, created_at);
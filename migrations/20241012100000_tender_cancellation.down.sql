@@ -0,0 +1,4 @@
+ALTER TABLE tender
+  DROP COLUMN IF EXISTS canceled_at,
+  DROP COLUMN IF EXISTS cancellation_reason,
+  DROP COLUMN IF EXISTS cancellation_category;
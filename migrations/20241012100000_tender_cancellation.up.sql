@@ -0,0 +1,4 @@
+ALTER TABLE tender
+  ADD COLUMN cancellation_category VARCHAR(50),
+  ADD COLUMN cancellation_reason TEXT,
+  ADD COLUMN canceled_at TIMESTAMP;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS bid_active_author_idx;
+
+DROP TABLE IF EXISTS bid_version;
@@ -0,0 +1,33 @@
+CREATE TABLE bid_version (
+  id UUID PRIMARY KEY,
+  bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+  version INT NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  description TEXT NOT NULL,
+  price NUMERIC(18, 2),
+  currency VARCHAR(3),
+  delivery_days INT,
+  validity_days INT,
+  sealed_payload BYTEA,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (bid_id, version)
+);
+
+-- An author may already hold several active bids on a tender: only the
+-- latest one is kept, the others are canceled so the index can be built.
+UPDATE bid SET status = 'Canceled'
+WHERE id IN (
+  SELECT id FROM (
+    SELECT id, ROW_NUMBER() OVER (
+      PARTITION BY tender_id, author_id
+      ORDER BY created_at DESC, version DESC, id DESC
+    ) AS rn
+    FROM bid
+    WHERE status NOT IN ('Canceled', 'Withdrawn')
+  ) ranked
+  WHERE rn > 1
+);
+
+CREATE UNIQUE INDEX bid_active_author_idx ON bid (tender_id, author_id)
+  WHERE status NOT IN ('Canceled', 'Withdrawn');
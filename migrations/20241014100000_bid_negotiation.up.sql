@@ -0,0 +1,18 @@
+CREATE TABLE bid_negotiation_round (
+  id UUID PRIMARY KEY,
+  bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+  round INT NOT NULL,
+  bid_version INT NOT NULL,
+  proposed_by UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  target_price NUMERIC(18, 2),
+  comment TEXT NOT NULL,
+  status VARCHAR(50) NOT NULL DEFAULT 'Open',
+  response_comment TEXT,
+  response_bid_version INT,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  responded_at TIMESTAMP,
+  UNIQUE (bid_id, round)
+);
+
+CREATE UNIQUE INDEX bid_negotiation_round_open_idx ON bid_negotiation_round (bid_id)
+  WHERE status = 'Open';
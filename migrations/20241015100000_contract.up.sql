@@ -0,0 +1,32 @@
+CREATE TABLE contract (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+  lot_id UUID REFERENCES tender_lot(id) ON DELETE CASCADE,
+  bid_version INT NOT NULL,
+  buyer_organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  supplier_organization_id UUID REFERENCES organization(id) ON DELETE SET NULL,
+  supplier_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  price NUMERIC(18, 2),
+  currency VARCHAR(3),
+  status VARCHAR(50) NOT NULL DEFAULT 'Active',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP,
+  UNIQUE NULLS NOT DISTINCT (bid_id, lot_id)
+);
+
+CREATE INDEX contract_supplier_organization_id_idx ON contract (supplier_organization_id);
+
+CREATE TABLE contract_milestone (
+  id UUID PRIMARY KEY,
+  contract_id UUID NOT NULL REFERENCES contract(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  due_date TIMESTAMP NOT NULL,
+  supplier_confirmed_at TIMESTAMP,
+  buyer_confirmed_at TIMESTAMP,
+  completed_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX contract_milestone_contract_id_idx ON contract_milestone (contract_id, due_date);
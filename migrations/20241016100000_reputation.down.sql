@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reputation;
+
+ALTER TABLE bid DROP COLUMN IF EXISTS feedback_rating;
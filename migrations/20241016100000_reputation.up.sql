@@ -0,0 +1,17 @@
+ALTER TABLE bid ADD COLUMN feedback_rating SMALLINT CHECK (feedback_rating BETWEEN 1 AND 5);
+
+CREATE TABLE reputation (
+  subject_type VARCHAR(20) NOT NULL,
+  subject_id UUID NOT NULL,
+  bids_submitted INT NOT NULL DEFAULT 0,
+  bids_withdrawn INT NOT NULL DEFAULT 0,
+  bids_decided INT NOT NULL DEFAULT 0,
+  bids_approved INT NOT NULL DEFAULT 0,
+  ratings_count INT NOT NULL DEFAULT 0,
+  ratings_sum INT NOT NULL DEFAULT 0,
+  milestones_completed INT NOT NULL DEFAULT 0,
+  milestones_on_time INT NOT NULL DEFAULT 0,
+  score DOUBLE PRECISION,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (subject_type, subject_id)
+);
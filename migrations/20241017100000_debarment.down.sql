@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS debarment_block;
+
+DROP TABLE IF EXISTS debarment;
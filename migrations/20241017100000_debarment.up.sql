@@ -0,0 +1,26 @@
+CREATE TABLE debarment (
+  id UUID PRIMARY KEY,
+  organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+  employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+  buyer_organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  expires_at TIMESTAMP,
+  created_by UUID NOT NULL REFERENCES employee(id),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  lifted_at TIMESTAMP,
+  CHECK ((organization_id IS NULL) <> (employee_id IS NULL))
+);
+
+CREATE INDEX debarment_organization_id_idx ON debarment (organization_id) WHERE lifted_at IS NULL;
+
+CREATE INDEX debarment_employee_id_idx ON debarment (employee_id) WHERE lifted_at IS NULL;
+
+CREATE TABLE debarment_block (
+  id UUID PRIMARY KEY,
+  debarment_id UUID NOT NULL REFERENCES debarment(id) ON DELETE CASCADE,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  attempted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX debarment_block_debarment_id_idx ON debarment_block (debarment_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS conflict_flag;
+
+DROP TABLE IF EXISTS conflict_declaration;
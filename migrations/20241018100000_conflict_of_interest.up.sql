@@ -0,0 +1,23 @@
+CREATE TABLE conflict_declaration (
+  id UUID PRIMARY KEY,
+  employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  relationship VARCHAR(50) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (employee_id, organization_id, relationship)
+);
+
+CREATE TABLE conflict_flag (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+  employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  organization_id UUID REFERENCES organization(id) ON DELETE SET NULL,
+  stage VARCHAR(50) NOT NULL,
+  rule VARCHAR(50) NOT NULL,
+  detail TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX conflict_flag_tender_id_idx ON conflict_flag (tender_id, created_at);
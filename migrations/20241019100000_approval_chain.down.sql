@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS approval_step;
+
+DROP TABLE IF EXISTS approval_request;
+
+DROP TABLE IF EXISTS approval_policy;
@@ -0,0 +1,34 @@
+CREATE TABLE approval_policy (
+  id UUID PRIMARY KEY,
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  min_budget NUMERIC(18, 2),
+  required_approvals INT NOT NULL CHECK (required_approvals > 0),
+  created_by UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX approval_policy_organization_id_idx ON approval_policy (organization_id);
+
+CREATE TABLE approval_request (
+  id UUID PRIMARY KEY,
+  tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+  policy_id UUID REFERENCES approval_policy(id) ON DELETE SET NULL,
+  required_approvals INT NOT NULL,
+  requested_by UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  status VARCHAR(50) NOT NULL DEFAULT 'Pending',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  decided_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX approval_request_pending_idx ON approval_request (tender_id) WHERE status = 'Pending';
+
+CREATE TABLE approval_step (
+  id UUID PRIMARY KEY,
+  request_id UUID NOT NULL REFERENCES approval_request(id) ON DELETE CASCADE,
+  approver_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  decision VARCHAR(50) NOT NULL,
+  comment TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (request_id, approver_id)
+);
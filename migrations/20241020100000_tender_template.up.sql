@@ -0,0 +1,9 @@
+CREATE TABLE tender_template (
+  id UUID PRIMARY KEY,
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  content JSONB NOT NULL,
+  created_by UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (organization_id, name)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tender_series_instance;
+
+DROP TABLE IF EXISTS tender_series;
@@ -0,0 +1,28 @@
+CREATE TABLE tender_series (
+  id UUID PRIMARY KEY,
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  template_id UUID NOT NULL REFERENCES tender_template(id) ON DELETE CASCADE,
+  rule VARCHAR(200) NOT NULL,
+  starts_at TIMESTAMP NOT NULL,
+  placeholder_values JSONB NOT NULL DEFAULT '{}',
+  submission_days INT CHECK (submission_days > 0),
+  auto_publish BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(20) NOT NULL DEFAULT 'Active',
+  next_occurrence INT NOT NULL DEFAULT 0,
+  next_run_at TIMESTAMP,
+  last_error TEXT,
+  created_by UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX tender_series_due_idx ON tender_series (next_run_at) WHERE status = 'Active';
+
+CREATE TABLE tender_series_instance (
+  series_id UUID NOT NULL REFERENCES tender_series(id) ON DELETE CASCADE,
+  tender_id UUID NOT NULL UNIQUE REFERENCES tender(id) ON DELETE CASCADE,
+  occurrence INT NOT NULL,
+  scheduled_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (series_id, occurrence)
+);
@@ -0,0 +1,26 @@
+CREATE TABLE audit_event (
+  id UUID PRIMARY KEY,
+  actor VARCHAR(100) NOT NULL,
+  action VARCHAR(100) NOT NULL,
+  entity_type VARCHAR(50) NOT NULL,
+  entity_id UUID NOT NULL,
+  before JSONB,
+  after JSONB,
+  request_id VARCHAR(100) NOT NULL DEFAULT '',
+  ip VARCHAR(64) NOT NULL DEFAULT '',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_event_entity_idx ON audit_event (entity_type, entity_id);
+CREATE INDEX audit_event_actor_idx ON audit_event (actor);
+CREATE INDEX audit_event_created_at_idx ON audit_event (created_at);
+
+CREATE FUNCTION audit_event_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_event_append_only
+  BEFORE UPDATE OR DELETE ON audit_event
+  FOR EACH ROW EXECUTE FUNCTION audit_event_append_only();
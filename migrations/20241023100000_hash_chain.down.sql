@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS chain_checkpoint;
+
+DROP TABLE IF EXISTS bid_submission;
+
+DROP FUNCTION IF EXISTS chain_append_only;
+
+ALTER TABLE audit_event
+  DROP COLUMN IF EXISTS hash,
+  DROP COLUMN IF EXISTS prev_hash,
+  DROP COLUMN IF EXISTS seq;
+
+DROP TABLE IF EXISTS hash_chain;
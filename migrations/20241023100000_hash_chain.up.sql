@@ -0,0 +1,59 @@
+CREATE TABLE hash_chain (
+  name VARCHAR(50) PRIMARY KEY,
+  seq BIGINT NOT NULL DEFAULT 0,
+  hash VARCHAR(64) NOT NULL DEFAULT '',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO hash_chain (name) VALUES ('audit'), ('bid');
+
+ALTER TABLE audit_event
+  ADD COLUMN seq BIGINT UNIQUE,
+  ADD COLUMN prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+  ADD COLUMN hash VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE bid_submission (
+  seq BIGINT PRIMARY KEY,
+  bid_id UUID NOT NULL,
+  tender_id UUID NOT NULL,
+  author_id UUID NOT NULL,
+  version INT NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  description TEXT NOT NULL,
+  price NUMERIC(18, 2),
+  currency VARCHAR(3),
+  delivery_days INT,
+  validity_days INT,
+  sealed_payload BYTEA,
+  prev_hash VARCHAR(64) NOT NULL,
+  hash VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  UNIQUE (bid_id, version)
+);
+
+CREATE TABLE chain_checkpoint (
+  id UUID PRIMARY KEY,
+  chain VARCHAR(50) NOT NULL REFERENCES hash_chain(name),
+  seq BIGINT NOT NULL,
+  hash VARCHAR(64) NOT NULL,
+  public_key BYTEA NOT NULL,
+  signature BYTEA NOT NULL,
+  created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX chain_checkpoint_chain_idx ON chain_checkpoint (chain, seq);
+
+CREATE FUNCTION chain_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bid_submission_append_only
+  BEFORE UPDATE OR DELETE ON bid_submission
+  FOR EACH ROW EXECUTE FUNCTION chain_append_only();
+
+CREATE TRIGGER chain_checkpoint_append_only
+  BEFORE UPDATE OR DELETE ON chain_checkpoint
+  FOR EACH ROW EXECUTE FUNCTION chain_append_only();
@@ -0,0 +1,26 @@
+CREATE TABLE employee_key (
+  id UUID PRIMARY KEY,
+  employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+  algorithm VARCHAR(20) NOT NULL,
+  public_key BYTEA NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  revoked_at TIMESTAMP,
+  UNIQUE (employee_id, fingerprint)
+);
+
+CREATE TABLE bid_signature (
+  id UUID PRIMARY KEY,
+  bid_id UUID NOT NULL REFERENCES bid(id),
+  version INT NOT NULL,
+  key_id UUID NOT NULL REFERENCES employee_key(id),
+  document BYTEA NOT NULL,
+  sealed BOOLEAN NOT NULL DEFAULT FALSE,
+  signature BYTEA NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (bid_id, version)
+);
+
+CREATE TRIGGER bid_signature_append_only
+  BEFORE UPDATE OR DELETE ON bid_signature
+  FOR EACH ROW EXECUTE FUNCTION chain_append_only();
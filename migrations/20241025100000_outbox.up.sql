@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+  id UUID PRIMARY KEY,
+  seq BIGSERIAL UNIQUE,
+  type VARCHAR(100) NOT NULL,
+  aggregate_type VARCHAR(50) NOT NULL,
+  aggregate_id UUID NOT NULL,
+  payload JSONB NOT NULL,
+  occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_error TEXT NOT NULL DEFAULT '',
+  delivered_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE delivered_at IS NULL;
+CREATE INDEX outbox_aggregate_idx ON outbox (aggregate_id, seq) WHERE delivered_at IS NULL;
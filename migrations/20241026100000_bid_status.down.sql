@@ -0,0 +1,3 @@
+UPDATE bid_version SET status = 'CREATED' WHERE status = 'Created';
+
+UPDATE bid SET status = 'CREATED' WHERE status = 'Created';
@@ -0,0 +1,2 @@
+UPDATE bid SET status = 'Created' WHERE status = 'CREATED';
+UPDATE bid_version SET status = 'Created' WHERE status = 'CREATED';
@@ -0,0 +1,39 @@
+// Package blobstore keeps binary content such as uploaded files outside of
+// the database, addressed by opaque keys.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrNotFound = errors.New("blobstore: object not found")
+
+	keyPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+)
+
+// BlobStore stores immutable objects. Keys are slash separated paths made of
+// letters, digits, dashes and dots.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey rejects keys that would need escaping or could leave the store.
+func checkKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("blobstore: invalid key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("blobstore: invalid key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Local keeps objects as files below a root directory.
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("blobstore - NewLocal - MkdirAll: %w", err)
+	}
+	return &Local{root: root}, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object to a temporary file first so that readers never see
+// partial content.
+func (l *Local) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("blobstore - Local - Put: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("blobstore - Local - Put: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("blobstore - Local - Put: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("blobstore - Local - Put: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("blobstore - Local - Put: %w", err)
+	}
+	return nil
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("blobstore - Local - Get: %w", err)
+	}
+	return f, nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("blobstore - Local - Delete: %w", err)
+	}
+	return nil
+}
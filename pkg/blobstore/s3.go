@@ -0,0 +1,224 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3TimeFormat    = "20060102T150405Z"
+	s3DateFormat    = "20060102"
+	s3ClientTimeout = 30 * time.Second
+)
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3 talks to an S3 compatible object storage (AWS S3, MinIO) with path-style
+// requests signed with AWS Signature Version 4.
+type S3 struct {
+	endpoint *url.URL
+	cfg      S3Config
+	client   *http.Client
+}
+
+func NewS3(cfg S3Config) (*S3, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("blobstore - NewS3: invalid endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("blobstore - NewS3: bucket and credentials are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	return &S3{
+		endpoint: endpoint,
+		cfg:      cfg,
+		client:   &http.Client{Timeout: s3ClientTimeout},
+	}, nil
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// EnsureBucket creates the bucket unless it already exists.
+func (s *S3) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodPut, "", nil, "")
+	if err != nil {
+		return fmt.Errorf("blobstore - S3 - EnsureBucket: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	apiErr := readS3Error(resp)
+	if apiErr.Code == "BucketAlreadyOwnedByYou" || apiErr.Code == "BucketAlreadyExists" {
+		return nil
+	}
+	return fmt.Errorf("blobstore - S3 - EnsureBucket: %d %s: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+}
+
+// Put uploads the object in a single request. The content is read into
+// memory to compute the payload hash the signature requires.
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	body, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("blobstore - S3 - Put: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, body, contentType)
+	if err != nil {
+		return fmt.Errorf("blobstore - S3 - Put: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := readS3Error(resp)
+		return fmt.Errorf("blobstore - S3 - Put: %d %s: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+	}
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("blobstore - S3 - Get: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		apiErr := readS3Error(resp)
+		return nil, fmt.Errorf("blobstore - S3 - Get: %d %s: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+	}
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return fmt.Errorf("blobstore - S3 - Delete: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		apiErr := readS3Error(resp)
+		return fmt.Errorf("blobstore - S3 - Delete: %d %s: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+	}
+	return nil
+}
+
+func readS3Error(resp *http.Response) s3Error {
+	var apiErr s3Error
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = xml.Unmarshal(data, &apiErr)
+	return apiErr
+}
+
+// do sends a signed request for the object key, or for the bucket itself
+// when key is empty.
+func (s *S3) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	path := "/" + s.cfg.Bucket
+	if key != "" {
+		if err := checkKey(key); err != nil {
+			return nil, err
+		}
+		path += "/" + key
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = ""
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(req, body, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign adds the Signature Version 4 authorization header to req. Bucket names
+// and keys never need escaping, so the request path is already canonical.
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format(s3TimeFormat)
+	date := now.Format(s3DateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
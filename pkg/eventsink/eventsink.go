@@ -0,0 +1,26 @@
+// Package eventsink delivers domain events to the systems reacting to them.
+// Deliveries are at least once: a sink may be given the same event again,
+// and consumers are expected to skip the event IDs they already handled.
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a domain event as it is delivered.
+type Event struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+}
+
+// Sink delivers an event. An error means the event was not delivered and is
+// to be retried.
+type Sink interface {
+	Deliver(ctx context.Context, event Event) error
+}
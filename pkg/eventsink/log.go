@@ -0,0 +1,27 @@
+package eventsink
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log writes the events to a logger. It never fails, which makes it the
+// sink of the deployments nothing listens to yet.
+type Log struct {
+	logger *slog.Logger
+}
+
+func NewLog(logger *slog.Logger) *Log {
+	return &Log{logger: logger}
+}
+
+func (l *Log) Deliver(ctx context.Context, event Event) error {
+	l.logger.InfoContext(ctx, "domain event",
+		slog.String("id", event.ID),
+		slog.String("type", event.Type),
+		slog.String("aggregate_type", event.AggregateType),
+		slog.String("aggregate_id", event.AggregateID),
+		slog.String("payload", string(event.Payload)),
+	)
+	return nil
+}
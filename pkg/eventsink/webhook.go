@@ -0,0 +1,71 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const webhookClientTimeout = 10 * time.Second
+
+// Webhook posts each event as JSON to a URL. Any response but a 2xx fails
+// the delivery. With a secret, the body is signed with HMAC-SHA256 in the
+// X-Signature-256 header as "sha256=<hex>".
+type Webhook struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+func NewWebhook(rawURL, secret string) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("eventsink - NewWebhook: invalid url %q", rawURL)
+	}
+
+	return &Webhook{
+		url:    rawURL,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: webhookClientTimeout},
+	}, nil
+}
+
+func (wh *Webhook) Deliver(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("eventsink - Webhook - Deliver: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("eventsink - Webhook - Deliver: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.ID)
+	req.Header.Set("X-Event-Type", event.Type)
+	if len(wh.secret) > 0 {
+		mac := hmac.New(sha256.New, wh.secret)
+		mac.Write(body)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := wh.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("eventsink - Webhook - Deliver: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("eventsink - Webhook - Deliver: unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+// Querier is the subset of pgx methods shared by the pool and a transaction.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// WithinTx runs fn inside a transaction carried by ctx. Nested calls reuse
+// the outer transaction, so repositories can be composed freely by services.
+func (p *Postgres) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgdb - WithinTx - Begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("pgdb - WithinTx - Commit: %w", err)
+	}
+
+	return nil
+}
+
+// Querier returns the transaction stored in ctx or the pool if there is none.
+func (p *Postgres) Querier(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return p.Pool
+}
@@ -0,0 +1,69 @@
+package sealer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrMalformed = errors.New("sealer: malformed ciphertext")
+
+// Sealer encrypts small payloads with AES-256-GCM. The random nonce is
+// prepended to the ciphertext.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+func New(key []byte) (*Sealer, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("sealer - New: key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("sealer - New - aes.NewCipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("sealer - New - cipher.NewGCM: %w", err)
+	}
+
+	return &Sealer{aead: aead}, nil
+}
+
+// NewFromBase64 builds a Sealer from a standard base64 encoded key.
+func NewFromBase64(encoded string) (*Sealer, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("sealer - NewFromBase64: %w", err)
+	}
+	return New(key)
+}
+
+func (s *Sealer) Seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("sealer - Seal: %w", err)
+	}
+
+	return s.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (s *Sealer) Open(ciphertext []byte) ([]byte, error) {
+	size := s.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, ErrMalformed
+	}
+
+	plaintext, err := s.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("sealer - Open: %w", err)
+	}
+
+	return plaintext, nil
+}
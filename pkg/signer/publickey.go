@@ -0,0 +1,106 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	_ "crypto/sha512" // SHA-384 and SHA-512 of the ECDSA P-384 and P-521 keys
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	AlgorithmEd25519 = "ed25519"
+	AlgorithmECDSA   = "ecdsa"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported public key, Ed25519 or ECDSA P-256, P-384 or P-521 expected")
+
+// PublicKey is a public key signatures are verified with. DER is the PKIX
+// encoding of the key, which is how it is stored.
+type PublicKey struct {
+	Algorithm string
+	DER       []byte
+	key       crypto.PublicKey
+}
+
+// ParsePublicKey reads an Ed25519 or ECDSA public key, either PEM or DER
+// encoded in the PKIX format, or as the 32 raw bytes of an Ed25519 key.
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	} else if len(data) == ed25519.PublicKeySize {
+		der, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(data))
+		if err != nil {
+			return nil, fmt.Errorf("signer - ParsePublicKey: %w", err)
+		}
+		data = der
+	}
+
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("signer - ParsePublicKey: %w", err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return &PublicKey{Algorithm: AlgorithmEd25519, DER: data, key: k}, nil
+	case *ecdsa.PublicKey:
+		if ecdsaHash(k.Curve) == 0 {
+			return nil, ErrUnsupportedKey
+		}
+		return &PublicKey{Algorithm: AlgorithmECDSA, DER: data, key: k}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// Fingerprint returns the hex encoded SHA-256 of the DER encoding of the key.
+func (k *PublicKey) Fingerprint() string {
+	sum := sha256.Sum256(k.DER)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify reports whether signature is a valid signature of message. ECDSA
+// signatures are made over the hash matching the curve, SHA-256 for P-256,
+// and are either ASN.1 DER encoded or the concatenation of r and s.
+func (k *PublicKey) Verify(message, signature []byte) bool {
+	switch key := k.key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, signature)
+	case *ecdsa.PublicKey:
+		hash := ecdsaHash(key.Curve)
+		h := hash.New()
+		h.Write(message)
+		digest := h.Sum(nil)
+
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) == 2*size {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			return ecdsa.Verify(key, digest, r, s)
+		}
+		return ecdsa.VerifyASN1(key, digest, signature)
+	default:
+		return false
+	}
+}
+
+func ecdsaHash(curve elliptic.Curve) crypto.Hash {
+	switch curve {
+	case elliptic.P256():
+		return crypto.SHA256
+	case elliptic.P384():
+		return crypto.SHA384
+	case elliptic.P521():
+		return crypto.SHA512
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,221 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func marshalPKIX(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	return der
+}
+
+func TestParsePublicKey(t *testing.T) {
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+
+	edDER := marshalPKIX(t, edPublic)
+	p256DER := marshalPKIX(t, &p256.PublicKey)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		wantAlgorithm string
+		wantDER       []byte
+		wantErr       error
+	}{
+		{
+			name:          "ed25519 der",
+			data:          edDER,
+			wantAlgorithm: AlgorithmEd25519,
+			wantDER:       edDER,
+		},
+		{
+			name:          "ed25519 pem",
+			data:          pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edDER}),
+			wantAlgorithm: AlgorithmEd25519,
+			wantDER:       edDER,
+		},
+		{
+			name:          "ed25519 raw",
+			data:          edPublic,
+			wantAlgorithm: AlgorithmEd25519,
+			wantDER:       edDER,
+		},
+		{
+			name:          "ecdsa p-256 pem",
+			data:          pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: p256DER}),
+			wantAlgorithm: AlgorithmECDSA,
+			wantDER:       p256DER,
+		},
+		{
+			name:    "ecdsa p-224",
+			data:    marshalPKIX(t, &p224.PublicKey),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "rsa",
+			data:    marshalPKIX(t, &rsaKey.PublicKey),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name: "garbage",
+			data: []byte("not a key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePublicKey(tt.data)
+			if tt.wantAlgorithm == "" {
+				if err == nil {
+					t.Fatalf("ParsePublicKey() = %+v, want an error", key)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParsePublicKey() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePublicKey() error = %v", err)
+			}
+			if key.Algorithm != tt.wantAlgorithm || string(key.DER) != string(tt.wantDER) {
+				t.Errorf("ParsePublicKey() = %s %x, want %s %x", key.Algorithm, key.DER, tt.wantAlgorithm, tt.wantDER)
+			}
+
+			sum := sha256.Sum256(tt.wantDER)
+			if got := key.Fingerprint(); got != hex.EncodeToString(sum[:]) {
+				t.Errorf("Fingerprint() = %s", got)
+			}
+		})
+	}
+}
+
+// rawSignature encodes r and s as the fixed size concatenation used by
+// WebCrypto and JWS.
+func rawSignature(t *testing.T, key *ecdsa.PrivateKey, digest []byte) []byte {
+	t.Helper()
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		t.Fatalf("ecdsa.Sign: %v", err)
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signature
+}
+
+func TestPublicKeyVerify(t *testing.T) {
+	message := []byte(`{"name":"Delivery"}`)
+	changed := []byte(`{"name":"Delivery!"}`)
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+
+	type curveCase struct {
+		name string
+		key  *ecdsa.PrivateKey
+		hash crypto.Hash
+	}
+	var curves []curveCase
+	for _, c := range []struct {
+		name  string
+		curve elliptic.Curve
+		hash  crypto.Hash
+	}{
+		{"p-256", elliptic.P256(), crypto.SHA256},
+		{"p-384", elliptic.P384(), crypto.SHA384},
+		{"p-521", elliptic.P521(), crypto.SHA512},
+	} {
+		key, err := ecdsa.GenerateKey(c.curve, rand.Reader)
+		if err != nil {
+			t.Fatalf("ecdsa.GenerateKey: %v", err)
+		}
+		curves = append(curves, curveCase{name: c.name, key: key, hash: c.hash})
+	}
+
+	type verifyCase struct {
+		name      string
+		key       crypto.PublicKey
+		message   []byte
+		signature []byte
+		want      bool
+	}
+	tests := []verifyCase{
+		{name: "ed25519", key: edPublic, message: message, signature: ed25519.Sign(edPrivate, message), want: true},
+		{name: "ed25519 changed message", key: edPublic, message: changed, signature: ed25519.Sign(edPrivate, message)},
+		{name: "ed25519 truncated signature", key: edPublic, message: message, signature: ed25519.Sign(edPrivate, message)[:10]},
+	}
+	for _, c := range curves {
+		h := c.hash.New()
+		h.Write(message)
+		digest := h.Sum(nil)
+
+		asn1, err := ecdsa.SignASN1(rand.Reader, c.key, digest)
+		if err != nil {
+			t.Fatalf("ecdsa.SignASN1: %v", err)
+		}
+		raw := rawSignature(t, c.key, digest)
+
+		// A SHA-256 signature is only valid for P-256.
+		sha := sha256.Sum256(message)
+		other, err := ecdsa.SignASN1(rand.Reader, c.key, sha[:])
+		if err != nil {
+			t.Fatalf("ecdsa.SignASN1: %v", err)
+		}
+
+		tests = append(tests,
+			verifyCase{name: c.name + " asn1", key: &c.key.PublicKey, message: message, signature: asn1, want: true},
+			verifyCase{name: c.name + " raw", key: &c.key.PublicKey, message: message, signature: raw, want: true},
+			verifyCase{name: c.name + " changed message", key: &c.key.PublicKey, message: changed, signature: asn1},
+			verifyCase{name: c.name + " raw changed message", key: &c.key.PublicKey, message: changed, signature: raw},
+			verifyCase{name: c.name + " truncated raw", key: &c.key.PublicKey, message: message, signature: raw[:len(raw)-1]},
+			verifyCase{name: c.name + " sha-256 digest", key: &c.key.PublicKey, message: message, signature: other, want: c.hash == crypto.SHA256},
+		)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePublicKey(marshalPKIX(t, tt.key))
+			if err != nil {
+				t.Fatalf("ParsePublicKey() error = %v", err)
+			}
+			if got := key.Verify(tt.message, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+)
+
+// Signer signs messages with an Ed25519 private key.
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// New builds a Signer from a 32 bytes seed or a 64 bytes private key.
+func New(key []byte) (*Signer, error) {
+	switch len(key) {
+	case ed25519.SeedSize:
+		return &Signer{key: ed25519.NewKeyFromSeed(key)}, nil
+	case ed25519.PrivateKeySize:
+		return &Signer{key: ed25519.PrivateKey(key)}, nil
+	default:
+		return nil, fmt.Errorf("signer - New: key must be %d or %d bytes, got %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(key))
+	}
+}
+
+// NewFromBase64 builds a Signer from a standard base64 encoded key.
+func NewFromBase64(encoded string) (*Signer, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("signer - NewFromBase64: %w", err)
+	}
+	return New(key)
+}
+
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+func (s *Signer) Sign(message []byte) []byte {
+	return ed25519.Sign(s.key, message)
+}
+
+// Verify reports whether signature is a valid signature of message by the
+// public key.
+func Verify(publicKey, message, signature []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, message, signature)
+}
@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	want := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		wantErr bool
+	}{
+		{name: "seed", key: seed},
+		{name: "private key", key: ed25519.NewKeyFromSeed(seed)},
+		{name: "empty", key: nil, wantErr: true},
+		{name: "short", key: seed[:16], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("New() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if !bytes.Equal(s.PublicKey(), want) {
+				t.Errorf("PublicKey() = %x, want %x", s.PublicKey(), want)
+			}
+		})
+	}
+}
+
+func TestNewFromBase64(t *testing.T) {
+	if _, err := NewFromBase64(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))); err != nil {
+		t.Errorf("NewFromBase64() error = %v", err)
+	}
+	if _, err := NewFromBase64("not base64!"); err == nil {
+		t.Error("NewFromBase64() succeeded on invalid base64, want an error")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	s, err := New(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	other, err := New(bytes.Repeat([]byte{8}, ed25519.SeedSize))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	message := []byte("tender-chain-checkpoint")
+	signature := s.Sign(message)
+
+	tests := []struct {
+		name      string
+		publicKey []byte
+		message   []byte
+		signature []byte
+		want      bool
+	}{
+		{name: "valid", publicKey: s.PublicKey(), message: message, signature: signature, want: true},
+		{name: "other key", publicKey: other.PublicKey(), message: message, signature: signature},
+		{name: "changed message", publicKey: s.PublicKey(), message: []byte("tender-chain-checkpoinT"), signature: signature},
+		{name: "truncated signature", publicKey: s.PublicKey(), message: message, signature: signature[:32]},
+		{name: "malformed key", publicKey: s.PublicKey()[:16], message: message, signature: signature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.publicKey, tt.message, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+// Package websocket implements the server side of the WebSocket protocol
+// (RFC 6455): the opening handshake, message framing, control frames and the
+// closing handshake. Extensions and subprotocols are not supported.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Message types (frame opcodes).
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+
+	continuationFrame = 0
+)
+
+// Close codes.
+const (
+	CloseNormalClosure   = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseNoStatus        = 1005
+	CloseInvalidPayload  = 1007
+	CloseMessageTooBig   = 1009
+)
+
+const (
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	defaultMaxMessageSize = 64 << 10
+	maxControlPayload     = 125
+)
+
+var (
+	ErrBadHandshake   = errors.New("websocket: bad handshake")
+	ErrProtocol       = errors.New("websocket: protocol error")
+	ErrMessageTooBig  = errors.New("websocket: message too big")
+	ErrInvalidPayload = errors.New("websocket: invalid UTF-8 in text message")
+	ErrClosed         = errors.New("websocket: close sent")
+)
+
+// CloseError is returned by ReadMessage when the peer closes the connection.
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Text)
+}
+
+// Conn is a server side WebSocket connection. ReadMessage must be called from
+// a single goroutine; writes are safe for concurrent use.
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	maxMessageSize int
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+
+	wmu       sync.Mutex
+	closeSent bool
+}
+
+// Upgrade performs the opening handshake and takes over the underlying
+// connection. On failure an HTTP error has already been written to w.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "WebSocket handshake requires GET", http.StatusMethodNotAllowed)
+		return nil, ErrBadHandshake
+	}
+	if !headerContainsToken(r.Header, "Connection", "upgrade") || !headerContainsToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "WebSocket upgrade expected", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "Unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, ErrBadHandshake
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	netConn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "WebSocket upgrade is not supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("websocket - Upgrade - Hijack: %w", err)
+	}
+
+	// Deadlines set by the HTTP server for the request must not apply to the
+	// long-lived connection.
+	if err = netConn.SetDeadline(time.Time{}); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket - Upgrade - SetDeadline: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err = netConn.Write([]byte(response)); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("websocket - Upgrade - Write: %w", err)
+	}
+
+	return &Conn{
+		conn:           netConn,
+		br:             brw.Reader,
+		maxMessageSize: defaultMaxMessageSize,
+	}, nil
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetMaxMessageSize limits the size of a reassembled incoming message.
+func (c *Conn) SetMaxMessageSize(n int) {
+	c.maxMessageSize = n
+}
+
+// SetReadTimeout makes every received frame, pongs included, push the read
+// deadline forward by d. Zero disables the timeout.
+func (c *Conn) SetReadTimeout(d time.Duration) error {
+	c.readTimeout = d
+	return c.extendReadDeadline()
+}
+
+// SetWriteTimeout bounds the time a single frame write may take.
+func (c *Conn) SetWriteTimeout(d time.Duration) {
+	c.wmu.Lock()
+	c.writeTimeout = d
+	c.wmu.Unlock()
+}
+
+func (c *Conn) extendReadDeadline() error {
+	if c.readTimeout == 0 {
+		return c.conn.SetReadDeadline(time.Time{})
+	}
+	return c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+}
+
+// ReadMessage returns the next data message. Pings are answered and pongs are
+// skipped transparently. A close frame from the peer is answered and reported
+// as *CloseError.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		messageType int
+		message     []byte
+	)
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, c.fail(err)
+		}
+		if err = c.extendReadDeadline(); err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err = c.WriteMessage(PongMessage, payload); err != nil && !errors.Is(err, ErrClosed) {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			return 0, nil, c.handleClose(payload)
+		case continuationFrame:
+			if messageType == 0 {
+				return 0, nil, c.fail(ErrProtocol)
+			}
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail(ErrProtocol)
+			}
+			messageType = opcode
+		default:
+			return 0, nil, c.fail(ErrProtocol)
+		}
+
+		if len(message)+len(payload) > c.maxMessageSize {
+			return 0, nil, c.fail(ErrMessageTooBig)
+		}
+		message = append(message, payload...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, c.fail(ErrInvalidPayload)
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (bool, int, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	// No extension is negotiated, so the RSV bits must be clear, and clients
+	// must mask every frame they send.
+	if header[0]&0x70 != 0 || !masked {
+		return false, 0, nil, ErrProtocol
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+		if length>>63 != 0 {
+			return false, 0, nil, ErrProtocol
+		}
+	}
+
+	if opcode >= CloseMessage && (!fin || length > maxControlPayload) {
+		return false, 0, nil, ErrProtocol
+	}
+	if length > uint64(c.maxMessageSize) {
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// fail sends the close frame matching a protocol violation before reporting it.
+func (c *Conn) fail(err error) error {
+	code := 0
+	switch {
+	case errors.Is(err, ErrProtocol):
+		code = CloseProtocolError
+	case errors.Is(err, ErrMessageTooBig):
+		code = CloseMessageTooBig
+	case errors.Is(err, ErrInvalidPayload):
+		code = CloseInvalidPayload
+	}
+	if code != 0 {
+		_ = c.WriteClose(code, "")
+	}
+	return err
+}
+
+func (c *Conn) handleClose(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatus}
+	switch {
+	case len(payload) == 1:
+		return c.fail(ErrProtocol)
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+		if !utf8.ValidString(closeErr.Text) {
+			return c.fail(ErrInvalidPayload)
+		}
+	}
+
+	// Echo the status code back as required by the closing handshake.
+	code := closeErr.Code
+	if code == CloseNoStatus {
+		code = CloseNormalClosure
+	}
+	if err := c.WriteClose(code, ""); err != nil && !errors.Is(err, ErrClosed) {
+		return err
+	}
+	return closeErr
+}
+
+// WriteMessage sends data as a single unfragmented frame.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrClosed
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WriteJSON sends v encoded as a JSON text message.
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, data)
+}
+
+// WriteClose starts the closing handshake. No data can be written afterwards.
+func (c *Conn) WriteClose(code int, text string) error {
+	payload := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, text...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.closeSent {
+		return ErrClosed
+	}
+	c.closeSent = true
+	return c.writeFrame(CloseMessage, payload)
+}
+
+func (c *Conn) writeFrame(opcode int, data []byte) error {
+	frame := make([]byte, 0, len(data)+10)
+	frame = append(frame, 0x80|byte(opcode))
+
+	switch length := len(data); {
+	case length <= 125:
+		frame = append(frame, byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+	frame = append(frame, data...)
+
+	if c.writeTimeout > 0 {
+		if err := c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return err
+		}
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close closes the underlying connection without a closing handshake.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
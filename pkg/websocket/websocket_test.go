@@ -0,0 +1,397 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConn records the frames written by the server. Reads go through the
+// bufio.Reader given to the Conn.
+type fakeConn struct {
+	net.Conn
+	written bytes.Buffer
+}
+
+func (f *fakeConn) Write(p []byte) (int, error)      { return f.written.Write(p) }
+func (f *fakeConn) SetReadDeadline(time.Time) error  { return nil }
+func (f *fakeConn) SetWriteDeadline(time.Time) error { return nil }
+func (f *fakeConn) Close() error                     { return nil }
+
+func newTestConn(input []byte) (*Conn, *fakeConn) {
+	fc := &fakeConn{}
+	return &Conn{
+		conn:           fc,
+		br:             bufio.NewReader(bytes.NewReader(input)),
+		maxMessageSize: defaultMaxMessageSize,
+	}, fc
+}
+
+// clientFrame encodes a frame the way a client sends it. A nil mask leaves
+// the frame unmasked.
+func clientFrame(fin bool, opcode int, payload []byte, mask []byte) []byte {
+	var frame []byte
+	b0 := byte(opcode)
+	if fin {
+		b0 |= 0x80
+	}
+	frame = append(frame, b0)
+
+	var maskBit byte
+	if mask != nil {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length <= 125:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	if mask == nil {
+		return append(frame, payload...)
+	}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+var testMask = []byte{0x37, 0xfa, 0x21, 0x3d}
+
+func frames(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func closePayload(code int, text string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(code)), text...)
+}
+
+func TestReadMessage(t *testing.T) {
+	large := bytes.Repeat([]byte("a"), 70000)
+	medium := bytes.Repeat([]byte("b"), 300)
+
+	tests := []struct {
+		name        string
+		input       []byte
+		maxSize     int
+		wantType    int
+		wantMessage []byte
+		wantErr     error
+		wantClose   int
+	}{
+		{
+			name:        "masked text",
+			input:       clientFrame(true, TextMessage, []byte("Hello"), testMask),
+			wantType:    TextMessage,
+			wantMessage: []byte("Hello"),
+		},
+		{
+			name:        "binary with 16-bit length",
+			input:       clientFrame(true, BinaryMessage, medium, testMask),
+			wantType:    BinaryMessage,
+			wantMessage: medium,
+		},
+		{
+			name:        "64-bit length",
+			input:       clientFrame(true, BinaryMessage, large, testMask),
+			maxSize:     len(large),
+			wantType:    BinaryMessage,
+			wantMessage: large,
+		},
+		{
+			name: "fragmented text",
+			input: frames(
+				clientFrame(false, TextMessage, []byte("Hel"), testMask),
+				clientFrame(false, continuationFrame, []byte("lo, "), testMask),
+				clientFrame(true, continuationFrame, []byte("world"), testMask),
+			),
+			wantType:    TextMessage,
+			wantMessage: []byte("Hello, world"),
+		},
+		{
+			name: "control frames between fragments",
+			input: frames(
+				clientFrame(false, TextMessage, []byte("Hel"), testMask),
+				clientFrame(true, PingMessage, []byte("ping"), testMask),
+				clientFrame(true, PongMessage, nil, testMask),
+				clientFrame(true, continuationFrame, []byte("lo"), testMask),
+			),
+			wantType:    TextMessage,
+			wantMessage: []byte("Hello"),
+		},
+		{
+			name: "utf-8 split across fragments",
+			input: frames(
+				clientFrame(false, TextMessage, []byte("\xd0"), testMask),
+				clientFrame(true, continuationFrame, []byte("\x9f"), testMask),
+			),
+			wantType:    TextMessage,
+			wantMessage: []byte("П"),
+		},
+		{
+			name:      "unmasked frame",
+			input:     clientFrame(true, TextMessage, []byte("Hello"), nil),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "reserved bits",
+			input:     append([]byte{0xc1}, clientFrame(true, TextMessage, []byte("Hello"), testMask)[1:]...),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "unknown opcode",
+			input:     clientFrame(true, 3, []byte("Hello"), testMask),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "continuation without a message",
+			input:     clientFrame(true, continuationFrame, []byte("lo"), testMask),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name: "new message inside a fragmented one",
+			input: frames(
+				clientFrame(false, TextMessage, []byte("Hel"), testMask),
+				clientFrame(true, TextMessage, []byte("lo"), testMask),
+			),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "fragmented control frame",
+			input:     clientFrame(false, PingMessage, []byte("ping"), testMask),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "oversized control frame",
+			input:     clientFrame(true, PingMessage, bytes.Repeat([]byte("p"), 126), testMask),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "oversized frame",
+			input:     clientFrame(true, BinaryMessage, medium, testMask),
+			maxSize:   len(medium) - 1,
+			wantErr:   ErrMessageTooBig,
+			wantClose: CloseMessageTooBig,
+		},
+		{
+			name: "oversized reassembled message",
+			input: frames(
+				clientFrame(false, BinaryMessage, medium, testMask),
+				clientFrame(true, continuationFrame, medium, testMask),
+			),
+			maxSize:   len(medium) + 1,
+			wantErr:   ErrMessageTooBig,
+			wantClose: CloseMessageTooBig,
+		},
+		{
+			name:      "64-bit length with the top bit set",
+			input:     append([]byte{0x82, 0xff}, bytes.Repeat([]byte{0xff}, 8)...),
+			wantErr:   ErrProtocol,
+			wantClose: CloseProtocolError,
+		},
+		{
+			name:      "invalid utf-8 text",
+			input:     clientFrame(true, TextMessage, []byte{0xff, 0xfe}, testMask),
+			wantErr:   ErrInvalidPayload,
+			wantClose: CloseInvalidPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fc := newTestConn(tt.input)
+			if tt.maxSize != 0 {
+				c.SetMaxMessageSize(tt.maxSize)
+			}
+
+			messageType, message, err := c.ReadMessage()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadMessage() error = %v, want %v", err, tt.wantErr)
+				}
+				if code := closeCode(t, fc.written.Bytes()); code != tt.wantClose {
+					t.Errorf("close code = %d, want %d", code, tt.wantClose)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadMessage() error = %v", err)
+			}
+			if messageType != tt.wantType || !bytes.Equal(message, tt.wantMessage) {
+				t.Errorf("ReadMessage() = %d, %q, want %d, %q", messageType, message, tt.wantType, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestReadMessagePing(t *testing.T) {
+	c, fc := newTestConn(frames(
+		clientFrame(true, PingMessage, []byte("are you there"), testMask),
+		clientFrame(true, TextMessage, []byte("Hello"), testMask),
+	))
+
+	if _, _, err := c.ReadMessage(); err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+
+	want := serverFrame(PongMessage, []byte("are you there"))
+	if !bytes.Equal(fc.written.Bytes(), want) {
+		t.Errorf("pong = %x, want %x", fc.written.Bytes(), want)
+	}
+}
+
+func TestReadMessageClose(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  []byte
+		wantCode int
+		wantText string
+		wantEcho int
+		wantErr  error
+	}{
+		{
+			name:     "with status",
+			payload:  closePayload(CloseGoingAway, "bye"),
+			wantCode: CloseGoingAway,
+			wantText: "bye",
+			wantEcho: CloseGoingAway,
+		},
+		{
+			name:     "without status",
+			wantCode: CloseNoStatus,
+			wantEcho: CloseNormalClosure,
+		},
+		{
+			name:     "one byte payload",
+			payload:  []byte{0x03},
+			wantErr:  ErrProtocol,
+			wantEcho: CloseProtocolError,
+		},
+		{
+			name:     "invalid utf-8 reason",
+			payload:  closePayload(CloseNormalClosure, "\xff"),
+			wantErr:  ErrInvalidPayload,
+			wantEcho: CloseInvalidPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fc := newTestConn(clientFrame(true, CloseMessage, tt.payload, testMask))
+
+			_, _, err := c.ReadMessage()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadMessage() error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				var closeErr *CloseError
+				if !errors.As(err, &closeErr) {
+					t.Fatalf("ReadMessage() error = %v, want *CloseError", err)
+				}
+				if closeErr.Code != tt.wantCode || closeErr.Text != tt.wantText {
+					t.Errorf("CloseError = %d %q, want %d %q", closeErr.Code, closeErr.Text, tt.wantCode, tt.wantText)
+				}
+			}
+
+			if code := closeCode(t, fc.written.Bytes()); code != tt.wantEcho {
+				t.Errorf("echoed close code = %d, want %d", code, tt.wantEcho)
+			}
+			if err = c.WriteMessage(TextMessage, []byte("late")); !errors.Is(err, ErrClosed) {
+				t.Errorf("WriteMessage() after close error = %v, want %v", err, ErrClosed)
+			}
+		})
+	}
+}
+
+// serverFrame encodes an unmasked final frame as the server must send it.
+func serverFrame(opcode int, payload []byte) []byte {
+	return clientFrame(true, opcode, payload, nil)
+}
+
+func TestWriteMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		length     int
+		wantHeader []byte
+	}{
+		{name: "empty", length: 0, wantHeader: []byte{0x82, 0}},
+		{name: "7-bit length", length: 125, wantHeader: []byte{0x82, 125}},
+		{name: "16-bit length", length: 126, wantHeader: []byte{0x82, 126, 0, 126}},
+		{name: "16-bit length max", length: 0xffff, wantHeader: []byte{0x82, 126, 0xff, 0xff}},
+		{name: "64-bit length", length: 0x10000, wantHeader: []byte{0x82, 127, 0, 0, 0, 0, 0, 1, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fc := newTestConn(nil)
+			payload := bytes.Repeat([]byte{0x5a}, tt.length)
+
+			if err := c.WriteMessage(BinaryMessage, payload); err != nil {
+				t.Fatalf("WriteMessage() error = %v", err)
+			}
+
+			written := fc.written.Bytes()
+			if !bytes.HasPrefix(written, tt.wantHeader) {
+				t.Fatalf("header = %x, want %x", written[:min(len(written), len(tt.wantHeader))], tt.wantHeader)
+			}
+			if !bytes.Equal(written[len(tt.wantHeader):], payload) {
+				t.Errorf("payload differs from the one written")
+			}
+		})
+	}
+}
+
+func TestWriteClose(t *testing.T) {
+	c, fc := newTestConn(nil)
+
+	if err := c.WriteClose(CloseGoingAway, strings.Repeat("x", 200)); err != nil {
+		t.Fatalf("WriteClose() error = %v", err)
+	}
+	written := fc.written.Bytes()
+	if written[0] != 0x88 || int(written[1]) != maxControlPayload {
+		t.Errorf("close header = %x, want 88%x", written[:2], maxControlPayload)
+	}
+	if code := closeCode(t, written); code != CloseGoingAway {
+		t.Errorf("close code = %d, want %d", code, CloseGoingAway)
+	}
+
+	if err := c.WriteClose(CloseNormalClosure, ""); !errors.Is(err, ErrClosed) {
+		t.Errorf("second WriteClose() error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestAcceptKey(t *testing.T) {
+	// The example from RFC 6455, section 1.3.
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey() = %q", got)
+	}
+}
+
+// closeCode returns the status code of the close frame the server wrote.
+func closeCode(t *testing.T, written []byte) int {
+	t.Helper()
+
+	if len(written) < 4 || written[0] != 0x80|CloseMessage || written[1]&0x80 != 0 {
+		t.Fatalf("expected an unmasked close frame, got %x", written)
+	}
+	return int(binary.BigEndian.Uint16(written[2:4]))
+}